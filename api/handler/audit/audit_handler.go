@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"net/http"
+
+	auditDTO "medisuite-api/app/dto/audit"
+	"medisuite-api/app/services"
+	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	"medisuite-api/constants/success"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAuditHandler interface {
+	FindAuditEvents(c *gin.Context)
+}
+
+type AuditHandler struct {
+	s services.IService
+}
+
+func NewAuditHandler(s services.IService) IAuditHandler {
+	return &AuditHandler{s: s}
+}
+
+// Handler method for querying the audit log by actor, entity and time range.
+func (h *AuditHandler) FindAuditEvents(c *gin.Context) {
+	reqDTO := auditDTO.AuditEventQueryDTO{}
+	if err := validators.BindQuery(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute find audit events service
+	result, err := h.s.AuditService().FindAuditEvents(c, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessFindAuditEvents
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
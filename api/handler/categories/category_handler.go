@@ -5,18 +5,20 @@ import (
 
 	categoryDTO "medisuite-api/app/dto/treatments"
 	"medisuite-api/app/services"
-	errValidation "medisuite-api/common/errors"
 	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	errConsts "medisuite-api/constants/errors"
 	"medisuite-api/constants/success"
+	"medisuite-api/pkg/etag"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
 type ICategoryHandler interface {
 	CreateCategory(c *gin.Context)
 	UpdateCategory(c *gin.Context)
+	PatchCategory(c *gin.Context)
 	DeleteCategory(c *gin.Context)
 	FindAllCategory(c *gin.Context)
 	FindByIdCategory(c *gin.Context)
@@ -33,39 +35,20 @@ func NewCategoryHandler(s services.IService) ICategoryHandler {
 // Handler method for creating a new category.
 func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	reqDTO := categoryDTO.CategoryDTO{}
-	if err := c.ShouldBindJSON(&reqDTO); err != nil {
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
 
-	// validation request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
-		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
-		})
-		return
-	}
-
 	// execute create category service
 	result, err := h.s.CategoryService().Create(c, reqDTO.NameCategory)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -82,11 +65,20 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 
 // Handler method for updating a category.
 func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	// updates must name the version they are based on
+	version, err := validators.IfMatch(c)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
 	reqDTO := categoryDTO.CategoryDTO{}
 	if err := c.ShouldBindJSON(&reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: err,
+			Error: errConsts.ErrInvalidRequest,
 			Gin:   c,
 		})
 		return
@@ -95,8 +87,8 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code: http.StatusBadRequest,
-			Gin:  c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -104,45 +96,95 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	// parse id to uuid
 	categoryID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := "Invalid category ID format"
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
 
 	// validation request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
+	if err := validators.Validate(c.Request.Context(), reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
 	// execute update category service
-	result, err := h.s.CategoryService().Update(c, categoryID, reqDTO.NameCategory)
+	result, err := h.s.CategoryService().Update(c, categoryID, version, reqDTO.NameCategory)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	c.Header(etag.HeaderETag, etag.Format(result.Version))
+	resMessage := success.SuccessUpdateCategory
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for partially updating a category with a JSON Merge Patch.
+func (h *CategoryHandler) PatchCategory(c *gin.Context) {
+	// updates must name the version they are based on
+	version, err := validators.IfMatch(c)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	reqDTO := categoryDTO.CategoryPatchDTO{}
+	if err := validators.BindMergePatch(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// parse id to uuid
+	categoryID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute patch category service
+	result, err := h.s.CategoryService().Patch(c, categoryID, version, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
 	// return success response
+	c.Header(etag.HeaderETag, etag.Format(result.Version))
 	resMessage := success.SuccessUpdateCategory
 	response.HttpResponse(response.ParamHttpResp[any]{
 		Code:    http.StatusOK,
@@ -157,8 +199,8 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code: http.StatusBadRequest,
-			Gin:  c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -166,12 +208,9 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	// parse id to uuid
 	categoryID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := "Invalid category ID format"
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -179,12 +218,9 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	// execute delete category service
 	err = h.s.CategoryService().Delete(c, categoryID)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -203,12 +239,9 @@ func (h *CategoryHandler) FindAllCategory(c *gin.Context) {
 	// execute find all category service
 	result, err := h.s.CategoryService().FindAll(c)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -228,8 +261,8 @@ func (h *CategoryHandler) FindByIdCategory(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code: http.StatusBadRequest,
-			Gin:  c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -237,12 +270,9 @@ func (h *CategoryHandler) FindByIdCategory(c *gin.Context) {
 	// parse id to uuid
 	categoryID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := "Invalid category ID format"
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -250,17 +280,15 @@ func (h *CategoryHandler) FindByIdCategory(c *gin.Context) {
 	// execute find by id category service
 	result, err := h.s.CategoryService().FindById(c, categoryID)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
 	// return success response
+	c.Header(etag.HeaderETag, etag.Format(result.Version))
 	resMessage := success.SuccessFindCategoryById
 	response.HttpResponse(response.ParamHttpResp[any]{
 		Code:    http.StatusOK,
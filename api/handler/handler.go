@@ -1,28 +1,42 @@
 package handler
 
 import (
+	auditHandler "medisuite-api/api/handler/audit"
 	categoryHandler "medisuite-api/api/handler/categories"
+	healthHandler "medisuite-api/api/handler/health"
+	inviteHandler "medisuite-api/api/handler/invites"
+	outboxHandler "medisuite-api/api/handler/outbox"
+	patientHandler "medisuite-api/api/handler/patients"
+	roleHandler "medisuite-api/api/handler/roles"
 	treatmentHandler "medisuite-api/api/handler/treatments"
 	userHandler "medisuite-api/api/handler/users"
 	"medisuite-api/app/services"
+	"medisuite-api/common/cookies"
 )
 
 type IHandler interface {
 	UserHandler() userHandler.IUserHandler
 	CategoryHandler() categoryHandler.ICategoryHandler
 	TreatmentHandler() treatmentHandler.ITreatmentHandler
+	RoleHandler() roleHandler.IRoleHandler
+	InviteHandler() inviteHandler.IInviteHandler
+	HealthHandler() healthHandler.IHealthHandler
+	AuditHandler() auditHandler.IAuditHandler
+	PatientHandler() patientHandler.IPatientHandler
+	OutboxHandler() outboxHandler.IOutboxHandler
 }
 
 type Handler struct {
-	s services.IService
+	s       services.IService
+	session *cookies.Session
 }
 
-func NewHandler(s services.IService) IHandler {
-	return &Handler{s: s}
+func NewHandler(s services.IService, session *cookies.Session) IHandler {
+	return &Handler{s: s, session: session}
 }
 
 func (h *Handler) UserHandler() userHandler.IUserHandler {
-	return userHandler.NewUserHandler(h.s)
+	return userHandler.NewUserHandler(h.s, h.session)
 }
 
 func (h *Handler) CategoryHandler() categoryHandler.ICategoryHandler {
@@ -32,3 +46,27 @@ func (h *Handler) CategoryHandler() categoryHandler.ICategoryHandler {
 func (h *Handler) TreatmentHandler() treatmentHandler.ITreatmentHandler {
 	return treatmentHandler.NewTreatmentHandler(h.s)
 }
+
+func (h *Handler) RoleHandler() roleHandler.IRoleHandler {
+	return roleHandler.NewRoleHandler(h.s)
+}
+
+func (h *Handler) InviteHandler() inviteHandler.IInviteHandler {
+	return inviteHandler.NewInviteHandler(h.s)
+}
+
+func (h *Handler) HealthHandler() healthHandler.IHealthHandler {
+	return healthHandler.NewHealthHandler(h.s)
+}
+
+func (h *Handler) AuditHandler() auditHandler.IAuditHandler {
+	return auditHandler.NewAuditHandler(h.s)
+}
+
+func (h *Handler) PatientHandler() patientHandler.IPatientHandler {
+	return patientHandler.NewPatientHandler(h.s)
+}
+
+func (h *Handler) OutboxHandler() outboxHandler.IOutboxHandler {
+	return outboxHandler.NewOutboxHandler(h.s)
+}
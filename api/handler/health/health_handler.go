@@ -0,0 +1,50 @@
+package health
+
+import (
+	"net/http"
+
+	healthDTO "medisuite-api/app/dto/health"
+	"medisuite-api/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IHealthHandler interface {
+	Liveness(c *gin.Context)
+	Readiness(c *gin.Context)
+	Details(c *gin.Context)
+}
+
+type HealthHandler struct {
+	s services.IService
+}
+
+func NewHealthHandler(s services.IService) IHealthHandler {
+	return &HealthHandler{s: s}
+}
+
+// Handler method for liveness. Health reports are plain JSON rather than the API
+// response envelope so orchestrators and uptime tooling can consume them directly.
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, h.s.HealthService().Liveness())
+}
+
+// Handler method for readiness; responds 503 when a critical dependency is down.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	report := h.s.HealthService().Readiness(c.Request.Context())
+	c.JSON(reportStatusCode(report), report)
+}
+
+// Handler method for the detailed health report.
+func (h *HealthHandler) Details(c *gin.Context) {
+	report := h.s.HealthService().Details(c.Request.Context())
+	c.JSON(reportStatusCode(report), report)
+}
+
+// reportStatusCode maps a report to 200, or 503 when the service is down
+func reportStatusCode(report healthDTO.Report) int {
+	if report.Status == healthDTO.StatusDown {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
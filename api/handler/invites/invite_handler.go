@@ -0,0 +1,217 @@
+package invites
+
+import (
+	"net/http"
+
+	inviteDTO "medisuite-api/app/dto/invites"
+	"medisuite-api/app/services"
+	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/success"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IInviteHandler interface {
+	CreateInvite(c *gin.Context)
+	FindPendingInvites(c *gin.Context)
+	ResendInvite(c *gin.Context)
+	RevokeInvite(c *gin.Context)
+	AcceptInvite(c *gin.Context)
+}
+
+type InviteHandler struct {
+	s services.IService
+}
+
+func NewInviteHandler(s services.IService) IInviteHandler {
+	return &InviteHandler{s: s}
+}
+
+// Handler method for inviting a staff member.
+func (h *InviteHandler) CreateInvite(c *gin.Context) {
+	actorID, ok := actorFromContext(c)
+	if !ok {
+		return
+	}
+
+	reqDTO := inviteDTO.InviteDTO{}
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute create invite service
+	result, err := h.s.InviteService().CreateInvite(c, actorID, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessCreateInvite
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusCreated,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for listing pending invites.
+func (h *InviteHandler) FindPendingInvites(c *gin.Context) {
+	// execute find pending invites service
+	result, err := h.s.InviteService().FindPendingInvites(c)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessFindPendingInvite
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for resending an invite with a fresh link.
+func (h *InviteHandler) ResendInvite(c *gin.Context) {
+	actorID, ok := actorFromContext(c)
+	if !ok {
+		return
+	}
+
+	// parse id to uuid
+	inviteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute resend invite service
+	result, err := h.s.InviteService().ResendInvite(c, actorID, inviteID)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessResendInvite
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for revoking a pending invite.
+func (h *InviteHandler) RevokeInvite(c *gin.Context) {
+	actorID, ok := actorFromContext(c)
+	if !ok {
+		return
+	}
+
+	// parse id to uuid
+	inviteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute revoke invite service
+	err = h.s.InviteService().RevokeInvite(c, actorID, inviteID)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessRevokeInvite
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Gin:     c,
+	})
+}
+
+// Handler method for accepting an invite and creating the staff account.
+func (h *InviteHandler) AcceptInvite(c *gin.Context) {
+	reqDTO := inviteDTO.AcceptInviteDTO{}
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute accept invite service
+	result, err := h.s.InviteService().AcceptInvite(c, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessAcceptInvite
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusCreated,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// actorFromContext returns the authenticated user ID set by AuthMiddleware.
+// On failure it writes the error response and returns false.
+func actorFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDs, exists := c.Get("userID")
+	if !exists {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return uuid.Nil, false
+	}
+
+	userID, ok := userIDs.(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
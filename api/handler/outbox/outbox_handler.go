@@ -0,0 +1,90 @@
+package outbox
+
+import (
+	"net/http"
+
+	outboxDTO "medisuite-api/app/dto/outbox"
+	"medisuite-api/app/services"
+	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/success"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IOutboxHandler interface {
+	FindOutboxMessages(c *gin.Context)
+	RequeueOutboxMessage(c *gin.Context)
+}
+
+type OutboxHandler struct {
+	s services.IService
+}
+
+func NewOutboxHandler(s services.IService) IOutboxHandler {
+	return &OutboxHandler{s: s}
+}
+
+// Handler method for listing outbox messages by status, dead-lettered ones by default.
+func (h *OutboxHandler) FindOutboxMessages(c *gin.Context) {
+	reqDTO := outboxDTO.OutboxQueryDTO{}
+	if err := validators.BindQuery(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute find outbox messages service
+	result, err := h.s.OutboxService().FindMessages(c, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessFindOutboxMessages
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for retrying a dead-lettered outbox message.
+func (h *OutboxHandler) RequeueOutboxMessage(c *gin.Context) {
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute requeue outbox message service
+	result, err := h.s.OutboxService().Requeue(c, messageID)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessRequeueOutboxMessage
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
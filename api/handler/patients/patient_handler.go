@@ -0,0 +1,249 @@
+package patients
+
+import (
+	"net/http"
+
+	patientDTO "medisuite-api/app/dto/patients"
+	"medisuite-api/app/policy"
+	"medisuite-api/app/services"
+	errWrap "medisuite-api/common/errors"
+	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/success"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IPatientHandler interface {
+	FindPatientRecord(c *gin.Context)
+	BreakGlass(c *gin.Context)
+	FindAccessLog(c *gin.Context)
+	AssignCareTeam(c *gin.Context)
+	UnassignCareTeam(c *gin.Context)
+	// RecordResource loads the patient record addressed by :id for middlewares.RequirePolicy
+	RecordResource(c *gin.Context) (*policy.Resource, error)
+}
+
+type PatientHandler struct {
+	s services.IService
+}
+
+func NewPatientHandler(s services.IService) IPatientHandler {
+	return &PatientHandler{s: s}
+}
+
+// Handler method for reading a patient record; the purpose query parameter is required.
+func (h *PatientHandler) FindPatientRecord(c *gin.Context) {
+	patientID, ok := patientIDFromParam(c)
+	if !ok {
+		return
+	}
+
+	reqDTO := patientDTO.PatientRecordQueryDTO{}
+	if err := validators.BindQuery(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute find patient record service
+	result, err := h.s.PatientService().FindPatientRecord(c, patientID, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessFindPatientRecord
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for emergency access to a patient record.
+func (h *PatientHandler) BreakGlass(c *gin.Context) {
+	patientID, ok := patientIDFromParam(c)
+	if !ok {
+		return
+	}
+
+	reqDTO := patientDTO.BreakGlassDTO{}
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute break glass service
+	result, err := h.s.PatientService().BreakGlass(c, patientID, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessBreakGlass
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for listing who read the caller's own record.
+func (h *PatientHandler) FindAccessLog(c *gin.Context) {
+	userIDs, exists := c.Get("userID")
+	if !exists {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return
+	}
+
+	userID, ok := userIDs.(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return
+	}
+
+	reqDTO := patientDTO.AccessLogQueryDTO{}
+	if err := validators.BindQuery(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute find access log service
+	result, err := h.s.PatientService().FindAccessLog(c, userID, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessFindAccessLog
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for adding a staff member to a patient's care team.
+func (h *PatientHandler) AssignCareTeam(c *gin.Context) {
+	patientID, ok := patientIDFromParam(c)
+	if !ok {
+		return
+	}
+	staffID, ok := staffIDFromParam(c)
+	if !ok {
+		return
+	}
+
+	// execute assign care team service
+	if err := h.s.PatientService().AssignCareTeam(c, patientID, staffID); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessAssignCareTeam
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Gin:     c,
+	})
+}
+
+// Handler method for removing a staff member from a patient's care team.
+func (h *PatientHandler) UnassignCareTeam(c *gin.Context) {
+	patientID, ok := patientIDFromParam(c)
+	if !ok {
+		return
+	}
+	staffID, ok := staffIDFromParam(c)
+	if !ok {
+		return
+	}
+
+	// execute unassign care team service
+	if err := h.s.PatientService().UnassignCareTeam(c, patientID, staffID); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessUnassignCareTeam
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Gin:     c,
+	})
+}
+
+// RecordResource loads the patient record addressed by :id; nil means the patient does not exist.
+func (h *PatientHandler) RecordResource(c *gin.Context) (*policy.Resource, error) {
+	patientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, errWrap.WrapError(errConsts.ErrInvalidID)
+	}
+	return h.s.PatientService().RecordResource(c, patientID)
+}
+
+// staffIDFromParam parses the :staff_id path parameter, writing the error response when it is not a UUID
+func staffIDFromParam(c *gin.Context) (uuid.UUID, bool) {
+	staffID, err := uuid.Parse(c.Param("staff_id"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return uuid.Nil, false
+	}
+	return staffID, true
+}
+
+// patientIDFromParam parses the :id path parameter, writing the error response when it is not a UUID
+func patientIDFromParam(c *gin.Context) (uuid.UUID, bool) {
+	patientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return uuid.Nil, false
+	}
+	return patientID, true
+}
@@ -0,0 +1,203 @@
+package roles
+
+import (
+	"net/http"
+
+	roleDTO "medisuite-api/app/dto/roles"
+	"medisuite-api/app/services"
+	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/success"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IRoleHandler interface {
+	FindAllRoles(c *gin.Context)
+	CreateRole(c *gin.Context)
+	UpdateRole(c *gin.Context)
+	AssignRole(c *gin.Context)
+}
+
+type RoleHandler struct {
+	s services.IService
+}
+
+func NewRoleHandler(s services.IService) IRoleHandler {
+	return &RoleHandler{s: s}
+}
+
+// Handler method for finding all roles.
+func (h *RoleHandler) FindAllRoles(c *gin.Context) {
+	// execute find all roles service
+	result, err := h.s.RoleService().FindAll(c)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessFindAllRoles
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for creating a new role.
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	actorID, ok := actorFromContext(c)
+	if !ok {
+		return
+	}
+
+	reqDTO := roleDTO.RoleDTO{}
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute create role service
+	result, err := h.s.RoleService().Create(c, actorID, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessCreateRole
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusCreated,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for updating a role.
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	actorID, ok := actorFromContext(c)
+	if !ok {
+		return
+	}
+
+	reqDTO := roleDTO.RoleDTO{}
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// parse id to uuid
+	roleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute update role service
+	result, err := h.s.RoleService().Update(c, actorID, roleID, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessUpdateRole
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for assigning a role to a user.
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	actorID, ok := actorFromContext(c)
+	if !ok {
+		return
+	}
+
+	reqDTO := roleDTO.AssignRoleDTO{}
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// parse user id to uuid
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute assign role service
+	err = h.s.RoleService().AssignRole(c, actorID, userID, reqDTO.RoleID)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessAssignRole
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Gin:     c,
+	})
+}
+
+// actorFromContext returns the authenticated user ID set by AuthMiddleware.
+// On failure it writes the error response and returns false.
+func actorFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDs, exists := c.Get("userID")
+	if !exists {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return uuid.Nil, false
+	}
+
+	userID, ok := userIDs.(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -5,18 +5,20 @@ import (
 
 	treatmentDTO "medisuite-api/app/dto/treatments"
 	"medisuite-api/app/services"
-	errValidation "medisuite-api/common/errors"
 	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	errConsts "medisuite-api/constants/errors"
 	"medisuite-api/constants/success"
+	"medisuite-api/pkg/etag"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
 type ITreatmentHandler interface {
 	CreateTreatment(c *gin.Context)
 	UpdateTreatment(c *gin.Context)
+	PatchTreatment(c *gin.Context)
 	DeleteTreatment(c *gin.Context)
 	FindAllTreatment(c *gin.Context)
 	FindByIdTreatment(c *gin.Context)
@@ -33,39 +35,20 @@ func NewTreatmentHandler(s services.IService) ITreatmentHandler {
 // Handler method for creating a new treatment.
 func (h *TreatmentHandler) CreateTreatment(c *gin.Context) {
 	reqDTO := treatmentDTO.TreatmentDTO{}
-	if err := c.ShouldBindJSON(&reqDTO); err != nil {
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
 
-	// validation request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
-		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
-		})
-		return
-	}
-
 	// execute create treatment service
 	result, err := h.s.TreatmentService().Create(c, reqDTO)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -82,27 +65,21 @@ func (h *TreatmentHandler) CreateTreatment(c *gin.Context) {
 
 // Handler method for updating a treatment.
 func (h *TreatmentHandler) UpdateTreatment(c *gin.Context) {
-	reqDTO := treatmentDTO.TreatmentDTO{}
-	if err := c.ShouldBindJSON(&reqDTO); err != nil {
+	// updates must name the version they are based on
+	version, err := validators.IfMatch(c)
+	if err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
 
-	// validation request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
+	reqDTO := treatmentDTO.TreatmentDTO{}
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -110,8 +87,8 @@ func (h *TreatmentHandler) UpdateTreatment(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code: http.StatusBadRequest,
-			Gin:  c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -119,30 +96,86 @@ func (h *TreatmentHandler) UpdateTreatment(c *gin.Context) {
 	// parse id to uuid
 	treatmentID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := "Invalid treatment ID format"
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
 
 	// execute update treatment service
-	result, err := h.s.TreatmentService().Update(c, treatmentID, reqDTO)
+	result, err := h.s.TreatmentService().Update(c, treatmentID, version, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	c.Header(etag.HeaderETag, etag.Format(result.Version))
+	resMessage := success.SuccessUpdateTreatment
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    result,
+		Gin:     c,
+	})
+}
+
+// Handler method for partially updating a treatment with a JSON Merge Patch.
+func (h *TreatmentHandler) PatchTreatment(c *gin.Context) {
+	// updates must name the version they are based on
+	version, err := validators.IfMatch(c)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	reqDTO := treatmentDTO.TreatmentPatchDTO{}
+	if err := validators.BindMergePatch(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// parse id to uuid
+	treatmentID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute patch treatment service
+	result, err := h.s.TreatmentService().Patch(c, treatmentID, version, reqDTO)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
 	// return success response
+	c.Header(etag.HeaderETag, etag.Format(result.Version))
 	resMessage := success.SuccessUpdateTreatment
 	response.HttpResponse(response.ParamHttpResp[any]{
 		Code:    http.StatusOK,
@@ -157,8 +190,8 @@ func (h *TreatmentHandler) DeleteTreatment(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code: http.StatusBadRequest,
-			Gin:  c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -166,12 +199,9 @@ func (h *TreatmentHandler) DeleteTreatment(c *gin.Context) {
 	// parse id to uuid
 	treatmentID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := "Invalid treatment ID format"
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -179,12 +209,9 @@ func (h *TreatmentHandler) DeleteTreatment(c *gin.Context) {
 	// execute delete treatment service
 	err = h.s.TreatmentService().Delete(c, treatmentID)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -203,12 +230,9 @@ func (h *TreatmentHandler) FindAllTreatment(c *gin.Context) {
 	// execute find all treatment service
 	result, err := h.s.TreatmentService().FindAll(c)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -228,8 +252,8 @@ func (h *TreatmentHandler) FindByIdTreatment(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code: http.StatusBadRequest,
-			Gin:  c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -237,12 +261,9 @@ func (h *TreatmentHandler) FindByIdTreatment(c *gin.Context) {
 	// parse id to uuid
 	treatmentID, err := uuid.Parse(id)
 	if err != nil {
-		errMessage := "Invalid treatment ID format"
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrInvalidID,
+			Gin:   c,
 		})
 		return
 	}
@@ -250,17 +271,15 @@ func (h *TreatmentHandler) FindByIdTreatment(c *gin.Context) {
 	// execute find by id treatment service
 	result, err := h.s.TreatmentService().FindById(c, treatmentID)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
 	// return success response
+	c.Header(etag.HeaderETag, etag.Format(result.Version))
 	resMessage := success.SuccessFindTreatmentById
 	response.HttpResponse(response.ParamHttpResp[any]{
 		Code:    http.StatusOK,
@@ -0,0 +1,359 @@
+package treatments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"medisuite-api/app/repo"
+	auditRepo "medisuite-api/app/repo/audit"
+	categoryRepo "medisuite-api/app/repo/categories"
+	treatmentRepo "medisuite-api/app/repo/treatments"
+	"medisuite-api/app/services"
+	treatmentService "medisuite-api/app/services/treatments"
+	"medisuite-api/common/response"
+	"medisuite-api/constants/locales"
+	"medisuite-api/pkg/audit"
+	categorydb "medisuite-api/pkg/db/categories"
+	treatmentdb "medisuite-api/pkg/db/treatments"
+	"medisuite-api/pkg/etag"
+	"medisuite-api/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// problem is the part of a problem details body the tests inspect
+type problem struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Details []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"details"`
+}
+
+func TestCreateTreatmentRejectsInvalidPayloads(t *testing.T) {
+	bundle, err := locales.NewBundle(locales.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.SetDefault(bundle)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(i18n.Middleware(bundle))
+	// without services a payload that passes validation panics instead of being rejected
+	router.POST("/treatments", NewTreatmentHandler(nil).CreateTreatment)
+
+	tests := []struct {
+		name       string
+		body       string
+		locale     string
+		wantFields []string
+		wantDetail string
+	}{
+		{
+			"empty", `{}`, "en",
+			[]string{"category_id", "description", "duration", "name_treatment", "price", "thumbnail"},
+			"name_treatment is required",
+		},
+		{
+			"negative price and duration",
+			`{"category_id":"8f0e2cc4-5c36-4f57-9c36-6f7c6c1f4e43","name_treatment":"Scaling","description":"Tartar removal","thumbnail":"scaling.png","price":-10,"duration":-5}`,
+			"en", []string{"duration", "price"}, "",
+		},
+		{
+			"fractional cents",
+			`{"category_id":"8f0e2cc4-5c36-4f57-9c36-6f7c6c1f4e43","name_treatment":"Scaling","description":"Tartar removal","thumbnail":"scaling.png","price":10.005,"duration":30}`,
+			"en", []string{"price"}, "",
+		},
+		{
+			"indonesian messages", `{}`, "id",
+			[]string{"category_id", "description", "duration", "name_treatment", "price", "thumbnail"},
+			i18n.Translate(locales.Indonesian, locales.ValidationRequired, "name_treatment"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/treatments", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Language", tt.locale)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusUnprocessableEntity, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, response.ProblemContentType) {
+				t.Errorf("Content-Type = %q, want %s", got, response.ProblemContentType)
+			}
+
+			var body problem
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if body.Code != "VALIDATION_FAILED" {
+				t.Errorf("code = %q, want VALIDATION_FAILED", body.Code)
+			}
+
+			var fields []string
+			messages := map[string]string{}
+			for _, detail := range body.Details {
+				fields = append(fields, detail.Field)
+				messages[detail.Field] = detail.Message
+			}
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+			if tt.wantDetail != "" && messages["name_treatment"] != tt.wantDetail {
+				t.Errorf("name_treatment message = %q, want %q", messages["name_treatment"], tt.wantDetail)
+			}
+		})
+	}
+}
+
+// treatmentStore holds one treatment and applies UpdateTreatment and PatchTreatment the way
+// their queries do: only at the expected version, which they increment, and a patch keeps
+// the columns it leaves nil
+type treatmentStore struct {
+	treatmentRepo.ITreatmentRepo
+	row treatmentdb.Treatment
+}
+
+func (s *treatmentStore) FindById(_ context.Context, id uuid.UUID) (*treatmentdb.Treatment, error) {
+	if id != s.row.ID {
+		return nil, nil
+	}
+	row := s.row
+	return &row, nil
+}
+
+func (s *treatmentStore) Update(_ context.Context, req treatmentdb.UpdateTreatmentParams) (*treatmentdb.Treatment, error) {
+	if req.ID != s.row.ID || req.Version != s.row.Version {
+		return nil, nil
+	}
+	s.row.CategoryID, s.row.NameTreatment, s.row.Description = req.CategoryID, req.NameTreatment, req.Description
+	s.row.Thumbnail, s.row.Price, s.row.Duration, s.row.IsActive = req.Thumbnail, req.Price, req.Duration, req.IsActive
+	s.row.Version++
+	row := s.row
+	return &row, nil
+}
+
+func (s *treatmentStore) Patch(_ context.Context, req treatmentdb.PatchTreatmentParams) (*treatmentdb.Treatment, error) {
+	if req.ID != s.row.ID || req.Version != s.row.Version {
+		return nil, nil
+	}
+	coalesce(&s.row.CategoryID, req.CategoryID)
+	coalesce(&s.row.NameTreatment, req.NameTreatment)
+	coalesce(&s.row.Description, req.Description)
+	coalesce(&s.row.Thumbnail, req.Thumbnail)
+	coalesce(&s.row.Price, req.Price)
+	coalesce(&s.row.Duration, req.Duration)
+	coalesce(&s.row.IsActive, req.IsActive)
+	s.row.Version++
+	row := s.row
+	return &row, nil
+}
+
+func coalesce[T any](column *T, value *T) {
+	if value != nil {
+		*column = *value
+	}
+}
+
+// anyCategory finds every category
+type anyCategory struct{ categoryRepo.ICategoryRepo }
+
+func (anyCategory) FindById(_ context.Context, id uuid.UUID) (*categorydb.Category, error) {
+	return &categorydb.Category{ID: id}, nil
+}
+
+// discardAudit drops audit events
+type discardAudit struct{ auditRepo.IAuditRepo }
+
+func (discardAudit) Record(context.Context, audit.Event) error { return nil }
+
+// treatmentRepos is a repository with just what updating a treatment needs
+type treatmentRepos struct {
+	repo.IRepo
+	treatments *treatmentStore
+}
+
+func (r treatmentRepos) TreatmentRepo() treatmentRepo.ITreatmentRepo { return r.treatments }
+func (r treatmentRepos) CategoryRepo() categoryRepo.ICategoryRepo    { return anyCategory{} }
+func (r treatmentRepos) AuditRepo() auditRepo.IAuditRepo             { return discardAudit{} }
+
+func (r treatmentRepos) ExecTx(_ context.Context, fn func(tx repo.IRepo) error) error {
+	return fn(r)
+}
+
+type treatmentServices struct {
+	services.IService
+	treatments treatmentService.ITreatmentService
+}
+
+func (s treatmentServices) TreatmentService() treatmentService.ITreatmentService { return s.treatments }
+
+// versionedTreatmentRouter serves PUT and PATCH /treatments/:id over a store holding one
+// treatment at version 1
+func versionedTreatmentRouter(t *testing.T) (*gin.Engine, *treatmentStore) {
+	t.Helper()
+	bundle, err := locales.NewBundle(locales.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.SetDefault(bundle)
+
+	store := &treatmentStore{row: treatmentdb.Treatment{
+		ID:            uuid.New(),
+		CategoryID:    uuid.New(),
+		NameTreatment: "Scaling",
+		Description:   "Tartar removal",
+		Thumbnail:     "scaling.png",
+		Price:         150000,
+		Duration:      30,
+		IsActive:      true,
+		Version:       1,
+	}}
+	handler := NewTreatmentHandler(treatmentServices{
+		treatments: treatmentService.NewTreatmentService(treatmentRepos{treatments: store}),
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(i18n.Middleware(bundle))
+	router.PUT("/treatments/:id", handler.UpdateTreatment)
+	router.PATCH("/treatments/:id", handler.PatchTreatment)
+	return router, store
+}
+
+func sendVersioned(router http.Handler, method string, id uuid.UUID, ifMatch string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/treatments/"+id.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set(etag.HeaderIfMatch, ifMatch)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+const fullTreatment = `{"category_id":"8f0e2cc4-5c36-4f57-9c36-6f7c6c1f4e43","name_treatment":"Polishing","description":"Stain removal","thumbnail":"polishing.png","price":200000,"duration":45,"is_active":true}`
+
+func TestUpdateTreatmentRequiresTheCurrentVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		ifMatch    string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"put without If-Match", http.MethodPut, "", fullTreatment, http.StatusPreconditionRequired, "PRECONDITION_REQUIRED"},
+		{"patch without If-Match", http.MethodPatch, "", `{"price":1000}`, http.StatusPreconditionRequired, "PRECONDITION_REQUIRED"},
+		{"put with a stale version", http.MethodPut, `"2"`, fullTreatment, http.StatusPreconditionFailed, "PRECONDITION_FAILED"},
+		{"patch with a stale version", http.MethodPatch, `"2"`, `{"price":1000}`, http.StatusPreconditionFailed, "PRECONDITION_FAILED"},
+		{"weak entity tag", http.MethodPatch, `W/"1"`, `{"price":1000}`, http.StatusPreconditionFailed, "PRECONDITION_FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, store := versionedTreatmentRouter(t)
+			w := sendVersioned(router, tt.method, store.row.ID, tt.ifMatch, tt.body)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			var body problem
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %q, want %s", body.Code, tt.wantCode)
+			}
+			if store.row.Version != 1 {
+				t.Errorf("version = %d after a rejected update, want 1", store.row.Version)
+			}
+		})
+	}
+}
+
+func TestUpdateTreatmentBumpsTheVersion(t *testing.T) {
+	for _, method := range []string{http.MethodPut, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			router, store := versionedTreatmentRouter(t)
+			body := fullTreatment
+			if method == http.MethodPatch {
+				body = `{"price":1000}`
+			}
+
+			w := sendVersioned(router, method, store.row.ID, etag.Format(1), body)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			if got := w.Header().Get(etag.HeaderETag); got != etag.Format(2) {
+				t.Errorf("ETag = %s, want %s", got, etag.Format(2))
+			}
+			var res struct {
+				Data struct {
+					Version int32 `json:"version"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if res.Data.Version != 2 {
+				t.Errorf("version = %d, want 2", res.Data.Version)
+			}
+
+			// the old ETag no longer matches
+			if w := sendVersioned(router, method, store.row.ID, etag.Format(1), body); w.Code != http.StatusPreconditionFailed {
+				t.Errorf("replayed update status = %d, want %d", w.Code, http.StatusPreconditionFailed)
+			}
+		})
+	}
+}
+
+func TestPatchTreatmentLeavesAbsentFieldsUnchanged(t *testing.T) {
+	router, store := versionedTreatmentRouter(t)
+	before := store.row
+
+	w := sendVersioned(router, http.MethodPatch, store.row.ID, etag.Format(1), `{"price":175000,"is_active":false}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	want := before
+	want.Price, want.IsActive, want.Version = 175000, false, 2
+	if store.row != want {
+		t.Errorf("treatment = %+v, want %+v", store.row, want)
+	}
+}
+
+func TestPatchTreatmentRejectsNull(t *testing.T) {
+	router, store := versionedTreatmentRouter(t)
+	before := store.row
+
+	w := sendVersioned(router, http.MethodPatch, store.row.ID, etag.Format(1), `{"price":null,"name_treatment":"Polishing"}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusUnprocessableEntity, w.Body.String())
+	}
+	var body problem
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if len(body.Details) != 1 || body.Details[0].Field != "price" {
+		t.Errorf("details = %+v, want one for price", body.Details)
+	}
+	if store.row != before {
+		t.Errorf("treatment changed by a rejected patch: %+v", store.row)
+	}
+}
@@ -1,17 +1,17 @@
 package users
 
 import (
-	"errors"
 	"net/http"
 
 	userDTO "medisuite-api/app/dto/users"
 	"medisuite-api/app/services"
-	errValidation "medisuite-api/common/errors"
+	"medisuite-api/common/cookies"
 	"medisuite-api/common/response"
+	"medisuite-api/common/validators"
+	errConsts "medisuite-api/constants/errors"
 	"medisuite-api/constants/success"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
@@ -25,52 +25,36 @@ type IUserHandler interface {
 	RefreshToken(c *gin.Context)
 	ForgotPassword(c *gin.Context)
 	ResetPassword(c *gin.Context)
+	UpdateLocale(c *gin.Context)
+	PatchProfile(c *gin.Context)
 }
 
 type UserHandler struct {
-	s services.IService
+	s       services.IService
+	session *cookies.Session
 }
 
-func NewUserHandler(s services.IService) IUserHandler {
-	return &UserHandler{s: s}
+func NewUserHandler(s services.IService, session *cookies.Session) IUserHandler {
+	return &UserHandler{s: s, session: session}
 }
 
 // Handler method for user registration
 func (h *UserHandler) Register(c *gin.Context) {
 	reqDTO := userDTO.RegisterDTO{}
-	if err := c.ShouldBindJSON(&reqDTO); err != nil {
+	if err := validators.BindJSON(c, &reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
 
-	// validate request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
-		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
-		})
-		return
-	}
-
 	// execute register service
 	result, err := h.s.UserService().CreateUser(c, reqDTO)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -92,8 +76,7 @@ func (h *UserHandler) VerifyAccount(c *gin.Context) {
 	// check if token is empty
 	if token == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("token is required"),
+			Error: errConsts.ErrInvalidRequest,
 			Gin:   c,
 		})
 		return
@@ -102,12 +85,9 @@ func (h *UserHandler) VerifyAccount(c *gin.Context) {
 	// check if token is valid
 	result, err := h.s.UserService().VerifyAccount(c, token)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -125,39 +105,20 @@ func (h *UserHandler) VerifyAccount(c *gin.Context) {
 // Handler method for resend verify account
 func (h *UserHandler) ResendVerify(c *gin.Context) {
 	reqDTO := &userDTO.EmailRequest{}
-	if err := c.ShouldBindJSON(reqDTO); err != nil {
+	if err := validators.BindJSON(c, reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
 
-	// validate request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
-		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
-		})
-		return
-	}
-
 	// execute resend verify account service
 	err := h.s.UserService().ResendVerifyAccount(c, reqDTO.Email)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -174,70 +135,36 @@ func (h *UserHandler) ResendVerify(c *gin.Context) {
 // Handler method for login
 func (h *UserHandler) Login(c *gin.Context) {
 	reqDTO := &userDTO.LoginDTO{}
-	if err := c.ShouldBindJSON(reqDTO); err != nil {
+	if err := validators.BindJSON(c, reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
-
-	// validate request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
-		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
-		})
-		return
-	}
 	// execute login service (pass client IP for session tracking)
 	clientIP := c.ClientIP()
 	result, err := h.s.UserService().LoginUser(c, reqDTO, clientIP)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
-	// set refresh token to cookie
-	cookieSecure := false
-	cookieDomain := ""
+	// set refresh token and CSRF token cookies
+	csrfToken := h.session.Start(c, result.RefreshToken)
 
-	// in production, use secure cookies and proper domain
-	if gin.Mode() == gin.ReleaseMode {
-		cookieSecure = true
-	}
-
-	c.SetCookie(
-		"refresh_token",     // name
-		result.RefreshToken, // value
-		7*24*60*60,          // maxAge (7 days in seconds)
-		"/",                 // path
-		cookieDomain,        // domain
-		cookieSecure,        // secure (true in production)
-		true,                // httpOnly
-	)
-
-	// return success response (only access token in body)
+	// return success response (access and CSRF tokens in body; the refresh token stays in its HttpOnly cookie)
 	dataResult := map[string]any{
-		"id":       result.ID,
-		"name":     result.Name,
-		"email":    result.Email,
-		"roleid":   result.RoleID,
-		"verified": result.IsVerified,
-		"token":    result.Token,
+		"id":         result.ID,
+		"name":       result.Name,
+		"email":      result.Email,
+		"roleid":     result.RoleID,
+		"verified":   result.IsVerified,
+		"token":      result.Token,
+		"csrf_token": csrfToken,
 	}
 	resMessage := "User logged in successfully"
 	response.HttpResponse(response.ParamHttpResp[any]{
@@ -254,8 +181,7 @@ func (h *UserHandler) Logout(c *gin.Context) {
 	userIDs, exists := c.Get("userID")
 	if !exists {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("user ID not found"),
+			Error: errConsts.ErrUnauthorized,
 			Gin:   c,
 		})
 		return
@@ -265,8 +191,7 @@ func (h *UserHandler) Logout(c *gin.Context) {
 	userID, ok := userIDs.(uuid.UUID)
 	if !ok {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("invalid user ID format"),
+			Error: errConsts.ErrUnauthorized,
 			Gin:   c,
 		})
 		return
@@ -275,8 +200,7 @@ func (h *UserHandler) Logout(c *gin.Context) {
 	// validate user ID is not empty
 	if userID == uuid.Nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("user ID cannot be empty"),
+			Error: errConsts.ErrUnauthorized,
 			Gin:   c,
 		})
 		return
@@ -285,37 +209,15 @@ func (h *UserHandler) Logout(c *gin.Context) {
 	// execute logout service
 	err := h.s.UserService().Logout(c, userID)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
-	// clear refresh token cookie
-	cookieSecure := false
-	cookieDomain := ""
-
-	// in production, use secure cookies and proper domain
-	if gin.Mode() == gin.ReleaseMode {
-		cookieSecure = true
-		// cookieDomain can be set from config if needed
-	}
-
-	c.SetCookie(
-		"refresh_token", // name
-		"",              // value (empty to clear)
-		-1,              // maxAge (negative to delete)
-		"/",             // path
-		cookieDomain,    // domain
-		cookieSecure,    // secure (true in production)
-		true,            // httpOnly
-	)
-	// clear refresh token cookie
-	c.SetCookie("refresh_token", "", -1, "/", "", true, true)
+	// clear refresh token and CSRF token cookies
+	h.session.End(c)
 
 	// return success response
 	resMessage := success.SuccessLogoutUser
@@ -333,8 +235,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	userIDs, exists := c.Get("userID")
 	if !exists {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("user ID not found"),
+			Error: errConsts.ErrUnauthorized,
 			Gin:   c,
 		})
 		return
@@ -344,8 +245,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	userID, ok := userIDs.(uuid.UUID)
 	if !ok {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("invalid user ID format"),
+			Error: errConsts.ErrUnauthorized,
 			Gin:   c,
 		})
 		return
@@ -354,8 +254,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	// validate user ID is not empty
 	if userID == uuid.Nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("user ID cannot be empty"),
+			Error: errConsts.ErrUnauthorized,
 			Gin:   c,
 		})
 		return
@@ -364,12 +263,9 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	// execute get user service
 	user, err := h.s.UserService().GetUser(c, userID)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -385,6 +281,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 			"email":    user.Email,
 			"role":     user.RoleDetail,
 			"verified": user.IsVerified,
+			"locale":   user.Locale,
 		},
 		Gin: c,
 	})
@@ -393,14 +290,11 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 // Handler method for refresh token
 func (h *UserHandler) RefreshToken(c *gin.Context) {
 	// get refresh token from cookie
-	refreshToken, err := c.Cookie("refresh_token")
+	refreshToken, err := h.session.RefreshToken(c)
 	if err != nil {
-		errMessage := "Refresh token not found in cookie"
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnauthorized,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
 		})
 		return
 	}
@@ -409,43 +303,25 @@ func (h *UserHandler) RefreshToken(c *gin.Context) {
 	clientIP := c.ClientIP()
 	result, err := h.s.UserService().RefreshToken(c, refreshToken, clientIP)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnauthorized,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
 
-	// set new refresh token to cookie
-	cookieSecure := false
-	cookieDomain := ""
-
-	// in production, use secure cookies and proper domain
-	if gin.Mode() == gin.ReleaseMode {
-		cookieSecure = true
-	}
-
-	c.SetCookie(
-		"refresh_token",     // name
-		result.RefreshToken, // value
-		7*24*60*60,          // maxAge (7 days in seconds)
-		"/",                 // path
-		cookieDomain,        // domain
-		cookieSecure,        // secure (true in production)
-		true,                // httpOnly
-	)
+	// set new refresh token and CSRF token cookies
+	csrfToken := h.session.Start(c, result.RefreshToken)
 
-	// return success response (only access token in body)
+	// return success response (access and CSRF tokens in body; the refresh token stays in its HttpOnly cookie)
 	dataResult := map[string]any{
-		"id":       result.ID,
-		"name":     result.Name,
-		"email":    result.Email,
-		"role_id":  result.RoleID,
-		"verified": result.IsVerified,
-		"token":    result.Token,
+		"id":         result.ID,
+		"name":       result.Name,
+		"email":      result.Email,
+		"role_id":    result.RoleID,
+		"verified":   result.IsVerified,
+		"token":      result.Token,
+		"csrf_token": csrfToken,
 	}
 	resMessage := success.SuccessRefreshToken
 	response.HttpResponse(response.ParamHttpResp[any]{
@@ -459,37 +335,19 @@ func (h *UserHandler) RefreshToken(c *gin.Context) {
 // Handler method for forgot password
 func (h *UserHandler) ForgotPassword(c *gin.Context) {
 	reqDTO := &userDTO.EmailRequest{}
-	if err := c.ShouldBindJSON(reqDTO); err != nil {
+	if err := validators.BindJSON(c, reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
-	// validate request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
-		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
-		})
-		return
-	}
 	// execute forgot password service
 	err := h.s.UserService().ForgotPassword(c, reqDTO)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
@@ -509,54 +367,131 @@ func (h *UserHandler) ResetPassword(c *gin.Context) {
 	// check if token is empty
 	if token == "" {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
-			Error: errors.New("token is required"),
+			Error: errConsts.ErrInvalidRequest,
 			Gin:   c,
 		})
 		return
 	}
 
 	reqDTO := &userDTO.ResetPasswordDTO{}
-	if err := c.ShouldBindJSON(reqDTO); err != nil {
+	if err := validators.BindJSON(c, reqDTO); err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:  http.StatusBadRequest,
 			Error: err,
 			Gin:   c,
 		})
 		return
 	}
-	// validate request
-	validate := validator.New()
-	if err := validate.Struct(reqDTO); err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errValidation.ErrValidationResponse(err)
+
+	// execute reset password service
+	err := h.s.UserService().ResetPassword(c, reqDTO)
+	if err != nil {
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Error:   err,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
+	// return success response
+	resMessage := success.SendEmailSuccessResetPassword
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Gin:     c,
+	})
+}
 
-	// execute reset password service
-	err := h.s.UserService().ResetPassword(c, reqDTO)
+// Handler method for updating the user's preferred locale
+func (h *UserHandler) UpdateLocale(c *gin.Context) {
+	userIDs, exists := c.Get("userID")
+	if !exists {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return
+	}
+
+	userID, ok := userIDs.(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return
+	}
+
+	reqDTO := userDTO.UpdateLocaleDTO{}
+	if err := c.ShouldBindJSON(&reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrInvalidRequest,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute update locale service
+	if err := h.s.UserService().UpdateLocale(c, userID, reqDTO.Locale); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// return success response
+	resMessage := success.SuccessUpdateLocale
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Gin:     c,
+	})
+}
+
+// Handler method for partially updating the caller's profile with a JSON Merge Patch.
+func (h *UserHandler) PatchProfile(c *gin.Context) {
+	userIDs, exists := c.Get("userID")
+	if !exists {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return
+	}
+
+	userID, ok := userIDs.(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConsts.ErrUnauthorized,
+			Gin:   c,
+		})
+		return
+	}
+
+	reqDTO := userDTO.ProfilePatchDTO{}
+	if err := validators.BindMergePatch(c, &reqDTO); err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	// execute patch profile service
+	result, err := h.s.UserService().PatchProfile(c, userID, reqDTO)
 	if err != nil {
-		errMessage := err.Error()
 		response.HttpResponse(response.ParamHttpResp[any]{
-			Code:    http.StatusBadRequest,
-			Error:   err,
-			Message: &errMessage,
-			Gin:     c,
+			Error: err,
+			Gin:   c,
 		})
 		return
 	}
+
 	// return success response
-	resMessage := success.SendEmailSuccessResetPassword
+	resMessage := success.SuccessUpdateUser
 	response.HttpResponse(response.ParamHttpResp[any]{
 		Code:    http.StatusOK,
 		Message: &resMessage,
+		Data:    result,
 		Gin:     c,
 	})
 }
@@ -0,0 +1,98 @@
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"medisuite-api/common/response"
+	"medisuite-api/constants/locales"
+	"medisuite-api/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// problem is the part of a problem details body the tests inspect
+type problem struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Details []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"details"`
+}
+
+func TestRegisterRejectsInvalidPayloads(t *testing.T) {
+	bundle, err := locales.NewBundle(locales.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.SetDefault(bundle)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(i18n.Middleware(bundle))
+	// without services a payload that passes validation panics instead of being rejected
+	router.POST("/register", NewUserHandler(nil, nil).Register)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+		wantFields []string
+	}{
+		{"malformed json", `{"name":`, http.StatusBadRequest, "INVALID_REQUEST", nil},
+		{"empty", `{}`, http.StatusUnprocessableEntity, "VALIDATION_FAILED", []string{"email", "name", "password", "phone_number", "role_id"}},
+		{
+			"weak password and foreign phone",
+			`{"name":"Jane","email":"jane@example.com","password":"password123","phone_number":"+14155550123","role_id":"8f0e2cc4-5c36-4f57-9c36-6f7c6c1f4e43"}`,
+			http.StatusUnprocessableEntity, "VALIDATION_FAILED", []string{"password", "phone_number"},
+		},
+		{
+			"invalid email and role",
+			`{"name":"Jane","email":"jane","password":"Str0ng!pass","phone_number":"081234567890","role_id":"00000000-0000-0000-0000-000000000000"}`,
+			http.StatusUnprocessableEntity, "VALIDATION_FAILED", []string{"email", "role_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, response.ProblemContentType) {
+				t.Errorf("Content-Type = %q, want %s", got, response.ProblemContentType)
+			}
+
+			var body problem
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", body.Code, tt.wantCode)
+			}
+
+			var fields []string
+			for _, detail := range body.Details {
+				if detail.Message == "" {
+					t.Errorf("field %s has no message", detail.Field)
+				}
+				fields = append(fields, detail.Field)
+			}
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
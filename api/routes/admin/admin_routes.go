@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"net/http"
+
+	"medisuite-api/api/handler"
+	"medisuite-api/api/routes/registry"
+	"medisuite-api/app/repo"
+	"medisuite-api/constants/roles"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAdminRoute interface {
+	Run()
+}
+
+type AdminRoute struct {
+	g    *gin.RouterGroup
+	r    repo.IRepo
+	h    handler.IHandler
+	reg  *registry.Registry
+	auth gin.HandlerFunc
+}
+
+func NewAdminRoute(group *gin.RouterGroup, repo repo.IRepo, h handler.IHandler, reg *registry.Registry, auth gin.HandlerFunc) *AdminRoute {
+	return &AdminRoute{
+		g:    group,
+		r:    repo,
+		h:    h,
+		reg:  reg,
+		auth: auth,
+	}
+}
+
+func (r *AdminRoute) Run() {
+	groups := r.g.Group("/admin", r.auth)
+	{
+		// routes
+		r.reg.Handle(groups, http.MethodGet, "/routes", registry.RequireMinLevel(roles.ADMIN), r.reg.ListRoutes)
+
+		// audit log
+		r.reg.Handle(groups, http.MethodGet, "/audit-events", registry.RequireMinLevel(roles.ADMIN), r.h.AuditHandler().FindAuditEvents)
+
+		// outbox dead letters
+		r.reg.Handle(groups, http.MethodGet, "/outbox", registry.RequireMinLevel(roles.ADMIN), r.h.OutboxHandler().FindOutboxMessages)
+		r.reg.Handle(groups, http.MethodPost, "/outbox/:id/requeue", registry.RequireMinLevel(roles.ADMIN), r.h.OutboxHandler().RequeueOutboxMessage)
+	}
+}
@@ -1,9 +1,11 @@
 package categories
 
 import (
+	"net/http"
+
 	"medisuite-api/api/handler"
+	"medisuite-api/api/routes/registry"
 	"medisuite-api/app/repo"
-	"medisuite-api/common/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,16 +15,22 @@ type ICategoryRoute interface {
 }
 
 type CategoryRoute struct {
-	h handler.IHandler
-	g *gin.RouterGroup
-	r repo.IRepo
+	h           handler.IHandler
+	g           *gin.RouterGroup
+	r           repo.IRepo
+	reg         *registry.Registry
+	auth        gin.HandlerFunc
+	idempotency gin.HandlerFunc
 }
 
-func NewCategoryRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo) *CategoryRoute {
+func NewCategoryRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo, reg *registry.Registry, auth gin.HandlerFunc, idempotency gin.HandlerFunc) *CategoryRoute {
 	return &CategoryRoute{
-		h: handler,
-		g: group,
-		r: repo,
+		h:           handler,
+		g:           group,
+		r:           repo,
+		reg:         reg,
+		auth:        auth,
+		idempotency: idempotency,
 	}
 }
 
@@ -32,8 +40,12 @@ func (r *CategoryRoute) Run() {
 		// routes
 		groups.GET("/find_all", r.h.CategoryHandler().FindAllCategory)
 		groups.GET("/:id", r.h.CategoryHandler().FindByIdCategory)
-		groups.POST("/create", middlewares.AuthMiddleware(), middlewares.RequirePermission(r.r, "category", "create"), r.h.CategoryHandler().CreateCategory)
-		groups.PUT("/update/:id", middlewares.AuthMiddleware(), middlewares.RequirePermission(r.r, "category", "update"), r.h.CategoryHandler().UpdateCategory)
-		groups.DELETE("/delete/:id", middlewares.AuthMiddleware(), middlewares.RequirePermission(r.r, "category", "delete"), r.h.CategoryHandler().DeleteCategory)
+
+		// changes need an authenticated user with the matching permission
+		authed := groups.Group("", r.auth)
+		r.reg.Handle(authed, http.MethodPost, "/create", registry.RequirePermission("category", "create"), r.idempotency, r.h.CategoryHandler().CreateCategory)
+		r.reg.Handle(authed, http.MethodPut, "/update/:id", registry.RequirePermission("category", "update"), r.h.CategoryHandler().UpdateCategory)
+		r.reg.Handle(authed, http.MethodPatch, "/update/:id", registry.RequirePermission("category", "update"), r.h.CategoryHandler().PatchCategory)
+		r.reg.Handle(authed, http.MethodDelete, "/delete/:id", registry.RequirePermission("category", "delete"), r.h.CategoryHandler().DeleteCategory)
 	}
 }
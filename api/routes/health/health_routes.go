@@ -0,0 +1,44 @@
+package health
+
+import (
+	"net/http"
+
+	"medisuite-api/api/handler"
+	"medisuite-api/api/routes/registry"
+	"medisuite-api/app/repo"
+	"medisuite-api/constants/roles"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IHealthRoute interface {
+	Run()
+}
+
+type HealthRoute struct {
+	h    handler.IHandler
+	g    *gin.RouterGroup
+	r    repo.IRepo
+	reg  *registry.Registry
+	auth gin.HandlerFunc
+}
+
+func NewHealthRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo, reg *registry.Registry, auth gin.HandlerFunc) *HealthRoute {
+	return &HealthRoute{
+		h:    handler,
+		g:    group,
+		r:    repo,
+		reg:  reg,
+		auth: auth,
+	}
+}
+
+// Run registers the admin-only detailed report; /healthz and /readyz are
+// served at the root by the server so probes need no prefix or credentials
+func (r *HealthRoute) Run() {
+	groups := r.g.Group("/health", r.auth)
+	{
+		// routes
+		r.reg.Handle(groups, http.MethodGet, "/details", registry.RequireMinLevel(roles.ADMIN), r.h.HealthHandler().Details)
+	}
+}
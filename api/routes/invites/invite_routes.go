@@ -0,0 +1,52 @@
+package invites
+
+import (
+	"net/http"
+
+	"medisuite-api/api/handler"
+	"medisuite-api/api/routes/registry"
+	"medisuite-api/app/repo"
+	"medisuite-api/constants/roles"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IInviteRoute interface {
+	Run()
+}
+
+type InviteRoute struct {
+	h           handler.IHandler
+	g           *gin.RouterGroup
+	r           repo.IRepo
+	reg         *registry.Registry
+	auth        gin.HandlerFunc
+	idempotency gin.HandlerFunc
+}
+
+func NewInviteRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo, reg *registry.Registry, auth gin.HandlerFunc, idempotency gin.HandlerFunc) *InviteRoute {
+	return &InviteRoute{
+		h:           handler,
+		g:           group,
+		r:           repo,
+		reg:         reg,
+		auth:        auth,
+		idempotency: idempotency,
+	}
+}
+
+func (r *InviteRoute) Run() {
+	groups := r.g.Group("/invites")
+	{
+		// accepting is public; the single-use token in the link is the credential
+		groups.POST("/accept", r.h.InviteHandler().AcceptInvite)
+
+		// invite management is limited to admin level and above;
+		// the service additionally rejects roles at or above the caller's own level
+		authed := groups.Group("", r.auth)
+		r.reg.Handle(authed, http.MethodPost, "/create", registry.RequireMinLevel(roles.ADMIN), r.idempotency, r.h.InviteHandler().CreateInvite)
+		r.reg.Handle(authed, http.MethodGet, "/pending", registry.RequireMinLevel(roles.ADMIN), r.h.InviteHandler().FindPendingInvites)
+		r.reg.Handle(authed, http.MethodPost, "/resend/:id", registry.RequireMinLevel(roles.ADMIN), r.h.InviteHandler().ResendInvite)
+		r.reg.Handle(authed, http.MethodDelete, "/revoke/:id", registry.RequireMinLevel(roles.ADMIN), r.h.InviteHandler().RevokeInvite)
+	}
+}
@@ -0,0 +1,53 @@
+package patients
+
+import (
+	"net/http"
+
+	"medisuite-api/api/handler"
+	"medisuite-api/api/routes/registry"
+	"medisuite-api/app/policy"
+	"medisuite-api/app/repo"
+	"medisuite-api/common/middlewares"
+	"medisuite-api/constants/roles"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IPatientRoute interface {
+	Run()
+}
+
+type PatientRoute struct {
+	h      handler.IHandler
+	g      *gin.RouterGroup
+	r      repo.IRepo
+	reg    *registry.Registry
+	auth   gin.HandlerFunc
+	policy *policy.Engine
+}
+
+func NewPatientRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo, reg *registry.Registry, auth gin.HandlerFunc) *PatientRoute {
+	return &PatientRoute{
+		h:      handler,
+		g:      group,
+		r:      repo,
+		reg:    reg,
+		auth:   auth,
+		policy: policy.Default(),
+	}
+}
+
+func (r *PatientRoute) Run() {
+	// every read of a patient record is logged; doctors read only the records of patients whose
+	// care team they are on, and other doctors are told to break the glass in an emergency,
+	// which alerts the patient
+	groups := r.g.Group("/patients", r.auth)
+	{
+		// routes
+		groups.GET("/access-log", r.h.PatientHandler().FindAccessLog)
+		r.reg.Handle(groups, http.MethodGet, "/record/:id", registry.RequirePermission("patient", "read"), middlewares.RequirePolicy(r.r, r.policy, policy.ActionRead, r.h.PatientHandler().RecordResource), r.h.PatientHandler().FindPatientRecord)
+		r.reg.Handle(groups, http.MethodPost, "/record/:id/break-glass", registry.RequireMinLevel(roles.DOCTOR), r.h.PatientHandler().BreakGlass)
+		r.reg.Handle(groups, http.MethodPut, "/record/:id/care-team/:staff_id", registry.RequireMinLevel(roles.ADMIN), r.h.PatientHandler().AssignCareTeam)
+		r.reg.Handle(groups, http.MethodDelete, "/record/:id/care-team/:staff_id", registry.RequireMinLevel(roles.ADMIN), r.h.PatientHandler().UnassignCareTeam)
+	}
+}
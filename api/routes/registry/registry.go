@@ -0,0 +1,216 @@
+package registry
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+
+	"medisuite-api/app/repo"
+	"medisuite-api/common/middlewares"
+	"medisuite-api/common/response"
+	"medisuite-api/constants/success"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route describes a registered route and the authorization it requires.
+// Routes without Module/Action or MinRole are public or only require authentication.
+type Route struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Module  string `json:"module,omitempty"`
+	Action  string `json:"action,omitempty"`
+	MinRole string `json:"min_role,omitempty"`
+}
+
+// Permission is a module/action pair referenced by at least one route
+type Permission struct {
+	Module string `json:"module"`
+	Action string `json:"action"`
+}
+
+// Access is the authorization a route requires; build it with RequirePermission or RequireMinLevel
+type Access struct {
+	module  string
+	action  string
+	minRole string
+}
+
+// RequirePermission requires the module/action permission
+func RequirePermission(module, action string) Access {
+	return Access{module: module, action: action}
+}
+
+// RequireMinLevel requires at least the level of minRoleCode
+func RequireMinLevel(minRoleCode string) Access {
+	return Access{minRole: minRoleCode}
+}
+
+// Registry is the central record of which route requires which permission.
+// Routes that need authorization are registered through Handle so the two never drift apart.
+type Registry struct {
+	mu         sync.RWMutex
+	repository repo.IRepo
+	routes     map[string]Route
+	routesInfo func() gin.RoutesInfo
+}
+
+// New creates a registry whose middlewares authorize against repository. routesInfo (usually
+// engine.Routes) lists every route known to gin, so Describe can include routes that need no permission.
+func New(repository repo.IRepo, routesInfo func() gin.RoutesInfo) *Registry {
+	return &Registry{
+		repository: repository,
+		routes:     make(map[string]Route),
+		routesInfo: routesInfo,
+	}
+}
+
+// Handle registers method+path on group, guarded by the middleware enforcing access ahead of handlers,
+// and records the route with its authorization. Authentication must come from the group.
+func (reg *Registry) Handle(group *gin.RouterGroup, method, relativePath string, access Access, handlers ...gin.HandlerFunc) {
+	route := Route{
+		Method:  method,
+		Path:    joinPath(group.BasePath(), relativePath),
+		Module:  access.module,
+		Action:  access.action,
+		MinRole: access.minRole,
+	}
+
+	var authorize gin.HandlerFunc
+	if access.minRole != "" {
+		authorize = middlewares.RequireMinLevel(reg.repository, access.minRole)
+	} else {
+		authorize = middlewares.RequirePermission(reg.repository, access.module, access.action)
+	}
+
+	reg.add(route)
+	group.Handle(method, relativePath, append([]gin.HandlerFunc{authorize}, handlers...)...)
+}
+
+// Describe lists every route, sorted by path and method, with its required authorization
+func (reg *Registry) Describe() []Route {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	seen := make(map[string]bool, len(reg.routes))
+	routes := make([]Route, 0, len(reg.routes))
+	if reg.routesInfo != nil {
+		for _, info := range reg.routesInfo() {
+			key := routeKey(info.Method, info.Path)
+			route, ok := reg.routes[key]
+			if !ok {
+				route = Route{Method: info.Method, Path: info.Path}
+			}
+			seen[key] = true
+			routes = append(routes, route)
+		}
+	}
+	for key, route := range reg.routes {
+		if !seen[key] {
+			routes = append(routes, route)
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Permissions lists the distinct module/action pairs referenced by registered routes
+func (reg *Registry) Permissions() []Permission {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	seen := make(map[Permission]bool)
+	permissions := make([]Permission, 0)
+	for _, route := range reg.routes {
+		if route.Module == "" {
+			continue
+		}
+		perm := Permission{Module: route.Module, Action: route.Action}
+		if !seen[perm] {
+			seen[perm] = true
+			permissions = append(permissions, perm)
+		}
+	}
+
+	sort.Slice(permissions, func(i, j int) bool {
+		if permissions[i].Module != permissions[j].Module {
+			return permissions[i].Module < permissions[j].Module
+		}
+		return permissions[i].Action < permissions[j].Action
+	})
+	return permissions
+}
+
+// MissingPermissions returns permissions referenced by routes but absent (or inactive) in the permissions table
+func (reg *Registry) MissingPermissions(ctx context.Context, repository repo.IRepo) ([]Permission, error) {
+	active, err := repository.PermissionRepo().FindActivePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[Permission]bool, len(active))
+	for _, perm := range active {
+		known[Permission{Module: perm.Module, Action: perm.Action}] = true
+	}
+
+	missing := make([]Permission, 0)
+	for _, perm := range reg.Permissions() {
+		if !known[perm] {
+			missing = append(missing, perm)
+		}
+	}
+	return missing, nil
+}
+
+// WarnMissingPermissions logs a warning for every permission referenced in code but missing from the database.
+// Routes guarded by a missing permission are unreachable for every role.
+func (reg *Registry) WarnMissingPermissions(ctx context.Context, repository repo.IRepo) {
+	missing, err := reg.MissingPermissions(ctx, repository)
+	if err != nil {
+		slog.Warn("Could not verify route permissions against database", "err", err)
+		return
+	}
+	for _, perm := range missing {
+		slog.Warn("Route permission missing from permissions table", "module", perm.Module, "action", perm.Action)
+	}
+}
+
+// ListRoutes is the handler for the admin route introspection endpoint
+func (reg *Registry) ListRoutes(c *gin.Context) {
+	resMessage := success.SuccessFindAllRoutes
+	response.HttpResponse(response.ParamHttpResp[any]{
+		Code:    http.StatusOK,
+		Message: &resMessage,
+		Data:    reg.Describe(),
+		Gin:     c,
+	})
+}
+
+// add stores a route, keyed by method and full path
+func (reg *Registry) add(route Route) {
+	reg.mu.Lock()
+	reg.routes[routeKey(route.Method, route.Path)] = route
+	reg.mu.Unlock()
+}
+
+// routeKey identifies a route by method and full path
+func routeKey(method, fullPath string) string {
+	return method + " " + fullPath
+}
+
+// joinPath joins a group base path and a relative route path the way gin does
+func joinPath(basePath, relativePath string) string {
+	if relativePath == "" {
+		return basePath
+	}
+	return path.Join(basePath, relativePath)
+}
@@ -0,0 +1,49 @@
+package roles
+
+import (
+	"net/http"
+
+	"medisuite-api/api/handler"
+	"medisuite-api/api/routes/registry"
+	"medisuite-api/app/repo"
+	"medisuite-api/constants/roles"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IRoleRoute interface {
+	Run()
+}
+
+type RoleRoute struct {
+	h           handler.IHandler
+	g           *gin.RouterGroup
+	r           repo.IRepo
+	reg         *registry.Registry
+	auth        gin.HandlerFunc
+	idempotency gin.HandlerFunc
+}
+
+func NewRoleRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo, reg *registry.Registry, auth gin.HandlerFunc, idempotency gin.HandlerFunc) *RoleRoute {
+	return &RoleRoute{
+		h:           handler,
+		g:           group,
+		r:           repo,
+		reg:         reg,
+		auth:        auth,
+		idempotency: idempotency,
+	}
+}
+
+func (r *RoleRoute) Run() {
+	// role management is limited to admin level and above;
+	// the service additionally rejects roles at or above the caller's own level
+	groups := r.g.Group("/roles", r.auth)
+	{
+		// routes
+		r.reg.Handle(groups, http.MethodGet, "/find_all", registry.RequireMinLevel(roles.ADMIN), r.h.RoleHandler().FindAllRoles)
+		r.reg.Handle(groups, http.MethodPost, "/create", registry.RequireMinLevel(roles.ADMIN), r.idempotency, r.h.RoleHandler().CreateRole)
+		r.reg.Handle(groups, http.MethodPut, "/update/:id", registry.RequireMinLevel(roles.ADMIN), r.h.RoleHandler().UpdateRole)
+		r.reg.Handle(groups, http.MethodPut, "/assign/:user_id", registry.RequireMinLevel(roles.ADMIN), r.h.RoleHandler().AssignRole)
+	}
+}
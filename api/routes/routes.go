@@ -2,7 +2,13 @@ package routes
 
 import (
 	"medisuite-api/api/handler"
+	adminRoutes "medisuite-api/api/routes/admin"
 	categoryRoutes "medisuite-api/api/routes/categories"
+	healthRoutes "medisuite-api/api/routes/health"
+	inviteRoutes "medisuite-api/api/routes/invites"
+	patientRoutes "medisuite-api/api/routes/patients"
+	"medisuite-api/api/routes/registry"
+	roleRoutes "medisuite-api/api/routes/roles"
 	treatmentRoutes "medisuite-api/api/routes/treatments"
 	userRoutes "medisuite-api/api/routes/users"
 	"medisuite-api/app/repo"
@@ -15,19 +21,35 @@ type IRoutes interface {
 	UserRoutes() userRoutes.IUserRoutes
 	CategoryRoutes() categoryRoutes.ICategoryRoute
 	TreatmentRoutes() treatmentRoutes.ITreatmentRoute
+	RoleRoutes() roleRoutes.IRoleRoute
+	AdminRoutes() adminRoutes.IAdminRoute
+	InviteRoutes() inviteRoutes.IInviteRoute
+	HealthRoutes() healthRoutes.IHealthRoute
+	PatientRoutes() patientRoutes.IPatientRoute
 }
 
 type Routes struct {
-	h handler.IHandler
-	g *gin.RouterGroup
-	r repo.IRepo
+	h           handler.IHandler
+	g           *gin.RouterGroup
+	r           repo.IRepo
+	reg         *registry.Registry
+	auth        gin.HandlerFunc
+	csrf        gin.HandlerFunc
+	idempotency gin.HandlerFunc
 }
 
-func NewRoutes(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo) IRoutes {
+// NewRoutes wires every route group; auth is the shared authentication middleware,
+// csrf protects the endpoints authenticated by the session cookie and idempotency
+// makes retried creates safe
+func NewRoutes(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo, reg *registry.Registry, auth gin.HandlerFunc, csrf gin.HandlerFunc, idempotency gin.HandlerFunc) IRoutes {
 	return &Routes{
-		h: handler,
-		g: group,
-		r: repo,
+		h:           handler,
+		g:           group,
+		r:           repo,
+		reg:         reg,
+		auth:        auth,
+		csrf:        csrf,
+		idempotency: idempotency,
 	}
 }
 
@@ -35,16 +57,41 @@ func (r *Routes) Serve() {
 	r.UserRoutes().Run()
 	r.CategoryRoutes().Run()
 	r.TreatmentRoutes().Run()
+	r.RoleRoutes().Run()
+	r.AdminRoutes().Run()
+	r.InviteRoutes().Run()
+	r.HealthRoutes().Run()
+	r.PatientRoutes().Run()
 }
 
 func (r *Routes) UserRoutes() userRoutes.IUserRoutes {
-	return userRoutes.NewUserRoutes(r.h, r.g)
+	return userRoutes.NewUserRoutes(r.h, r.g, r.auth, r.csrf)
 }
 
 func (r *Routes) CategoryRoutes() categoryRoutes.ICategoryRoute {
-	return categoryRoutes.NewCategoryRoute(r.h, r.g, r.r)
+	return categoryRoutes.NewCategoryRoute(r.h, r.g, r.r, r.reg, r.auth, r.idempotency)
 }
 
 func (r *Routes) TreatmentRoutes() treatmentRoutes.ITreatmentRoute {
-	return treatmentRoutes.NewTreatmentRoute(r.h, r.g, r.r)
+	return treatmentRoutes.NewTreatmentRoute(r.h, r.g, r.r, r.reg, r.auth, r.idempotency)
+}
+
+func (r *Routes) RoleRoutes() roleRoutes.IRoleRoute {
+	return roleRoutes.NewRoleRoute(r.h, r.g, r.r, r.reg, r.auth, r.idempotency)
+}
+
+func (r *Routes) AdminRoutes() adminRoutes.IAdminRoute {
+	return adminRoutes.NewAdminRoute(r.g, r.r, r.h, r.reg, r.auth)
+}
+
+func (r *Routes) InviteRoutes() inviteRoutes.IInviteRoute {
+	return inviteRoutes.NewInviteRoute(r.h, r.g, r.r, r.reg, r.auth, r.idempotency)
+}
+
+func (r *Routes) HealthRoutes() healthRoutes.IHealthRoute {
+	return healthRoutes.NewHealthRoute(r.h, r.g, r.r, r.reg, r.auth)
+}
+
+func (r *Routes) PatientRoutes() patientRoutes.IPatientRoute {
+	return patientRoutes.NewPatientRoute(r.h, r.g, r.r, r.reg, r.auth)
 }
@@ -1,9 +1,11 @@
 package treatments
 
 import (
+	"net/http"
+
 	"medisuite-api/api/handler"
+	"medisuite-api/api/routes/registry"
 	"medisuite-api/app/repo"
-	"medisuite-api/common/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,16 +15,22 @@ type ITreatmentRoute interface {
 }
 
 type TreatmentRoute struct {
-	h handler.IHandler
-	g *gin.RouterGroup
-	r repo.IRepo
+	h           handler.IHandler
+	g           *gin.RouterGroup
+	r           repo.IRepo
+	reg         *registry.Registry
+	auth        gin.HandlerFunc
+	idempotency gin.HandlerFunc
 }
 
-func NewTreatmentRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo) *TreatmentRoute {
+func NewTreatmentRoute(handler handler.IHandler, group *gin.RouterGroup, repo repo.IRepo, reg *registry.Registry, auth gin.HandlerFunc, idempotency gin.HandlerFunc) *TreatmentRoute {
 	return &TreatmentRoute{
-		h: handler,
-		g: group,
-		r: repo,
+		h:           handler,
+		g:           group,
+		r:           repo,
+		reg:         reg,
+		auth:        auth,
+		idempotency: idempotency,
 	}
 }
 
@@ -32,8 +40,12 @@ func (r *TreatmentRoute) Run() {
 		// routes
 		groups.GET("/find_all", r.h.TreatmentHandler().FindAllTreatment)
 		groups.GET("/:id", r.h.TreatmentHandler().FindByIdTreatment)
-		groups.POST("/create", middlewares.AuthMiddleware(), middlewares.RequirePermission(r.r, "treatment", "create"), r.h.TreatmentHandler().CreateTreatment)
-		groups.PUT("/update/:id", middlewares.AuthMiddleware(), middlewares.RequirePermission(r.r, "treatment", "update"), r.h.TreatmentHandler().UpdateTreatment)
-		groups.DELETE("/delete/:id", middlewares.AuthMiddleware(), middlewares.RequirePermission(r.r, "treatment", "delete"), r.h.TreatmentHandler().DeleteTreatment)
+
+		// changes need an authenticated user with the matching permission
+		authed := groups.Group("", r.auth)
+		r.reg.Handle(authed, http.MethodPost, "/create", registry.RequirePermission("treatment", "create"), r.idempotency, r.h.TreatmentHandler().CreateTreatment)
+		r.reg.Handle(authed, http.MethodPut, "/update/:id", registry.RequirePermission("treatment", "update"), r.h.TreatmentHandler().UpdateTreatment)
+		r.reg.Handle(authed, http.MethodPatch, "/update/:id", registry.RequirePermission("treatment", "update"), r.h.TreatmentHandler().PatchTreatment)
+		r.reg.Handle(authed, http.MethodDelete, "/delete/:id", registry.RequirePermission("treatment", "delete"), r.h.TreatmentHandler().DeleteTreatment)
 	}
 }
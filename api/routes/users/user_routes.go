@@ -2,7 +2,6 @@ package users
 
 import (
 	"medisuite-api/api/handler"
-	"medisuite-api/common/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,14 +11,18 @@ type IUserRoutes interface {
 }
 
 type UserRoutes struct {
-	h handler.IHandler
-	g *gin.RouterGroup
+	h    handler.IHandler
+	g    *gin.RouterGroup
+	auth gin.HandlerFunc
+	csrf gin.HandlerFunc
 }
 
-func NewUserRoutes(handlers handler.IHandler, group *gin.RouterGroup) *UserRoutes {
+func NewUserRoutes(handlers handler.IHandler, group *gin.RouterGroup, auth gin.HandlerFunc, csrf gin.HandlerFunc) *UserRoutes {
 	return &UserRoutes{
-		h: handlers,
-		g: group,
+		h:    handlers,
+		g:    group,
+		auth: auth,
+		csrf: csrf,
 	}
 }
 
@@ -31,9 +34,12 @@ func (r *UserRoutes) Run() {
 		groups.POST("/verify-account", r.h.UserHandler().VerifyAccount)
 		groups.POST("/resend-verify", r.h.UserHandler().ResendVerify)
 		groups.POST("/login", r.h.UserHandler().Login)
-		groups.GET("/getuser", middlewares.AuthMiddleware(), r.h.UserHandler().GetUser)
-		groups.POST("/logout", middlewares.AuthMiddleware(), r.h.UserHandler().Logout)
-		groups.POST("/refresh-token", middlewares.AuthMiddleware(), r.h.UserHandler().RefreshToken)
+		groups.GET("/getuser", r.auth, r.h.UserHandler().GetUser)
+		groups.PUT("/locale", r.auth, r.h.UserHandler().UpdateLocale)
+		groups.PATCH("/profile", r.auth, r.h.UserHandler().PatchProfile)
+		// these read or clear the refresh token cookie, so they also require the CSRF token
+		groups.POST("/logout", r.csrf, r.auth, r.h.UserHandler().Logout)
+		groups.POST("/refresh-token", r.csrf, r.auth, r.h.UserHandler().RefreshToken)
 		groups.POST("/forgot-password", r.h.UserHandler().ForgotPassword)
 		groups.POST("/reset-password", r.h.UserHandler().ResetPassword)
 	}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEventQueryDTO filters the audit log; from and to are RFC 3339 timestamps
+type AuditEventQueryDTO struct {
+	ActorID    string     `form:"actor_id" json:"actor_id" validate:"omitempty,uuid"`
+	EntityType string     `form:"entity_type" json:"entity_type" validate:"omitempty,audit_entity"`
+	EntityID   string     `form:"entity_id" json:"entity_id" validate:"omitempty,max=64"`
+	From       *time.Time `form:"from" json:"from"`
+	To         *time.Time `form:"to" json:"to"`
+	Limit      int32      `form:"limit" json:"limit" validate:"omitempty,min=1,max=200"`
+	Offset     int32      `form:"offset" json:"offset" validate:"omitempty,min=0"`
+}
+
+type AuditEventResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	ActorUserID uuid.UUID       `json:"actor_user_id"`
+	ActorRole   string          `json:"actor_role"`
+	ActorIP     string          `json:"actor_ip"`
+	RequestID   string          `json:"request_id"`
+	Action      string          `json:"action"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    string          `json:"entity_id"`
+	Diff        json.RawMessage `json:"diff"`
+}
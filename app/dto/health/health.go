@@ -0,0 +1,29 @@
+package health
+
+import "time"
+
+// Health statuses used in reports and checks
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// Report is the JSON body of /healthz, /readyz and /health/details.
+// Status is down when a critical check fails and degraded when only a non-critical one does.
+type Report struct {
+	Status    string        `json:"status"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Checks    []CheckResult `json:"checks,omitempty"`
+}
+
+// CheckResult is the outcome of one dependency check.
+// Latency, detail and error are only included in the admin details report.
+type CheckResult struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms,omitempty"`
+	Detail    string  `json:"detail,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
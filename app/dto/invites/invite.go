@@ -0,0 +1,31 @@
+package invites
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InviteDTO struct {
+	Email  string    `json:"email" validate:"required,email"`
+	RoleID uuid.UUID `json:"role_id" validate:"required,uuid"`
+}
+
+type AcceptInviteDTO struct {
+	Token         string `json:"token" validate:"required"`
+	Name          string `json:"name" validate:"required"`
+	PhoneNumber   string `json:"phone_number" validate:"required,phone_id"`
+	Password      string `json:"password" validate:"required,min=8,max=72,password"`
+	RetryPassword string `json:"retry_password" validate:"required,min=8,max=72,password"`
+}
+
+type InviteResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	RoleID    uuid.UUID `json:"role_id"`
+	InvitedBy uuid.UUID `json:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Expired   bool      `json:"expired"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxQueryDTO lists outbox messages by status, dead-lettered ones by default
+type OutboxQueryDTO struct {
+	Status string `form:"status" json:"status" validate:"omitempty,oneof=pending sent dead"`
+	Limit  int32  `form:"limit" json:"limit" validate:"omitempty,min=1,max=200"`
+	Offset int32  `form:"offset" json:"offset" validate:"omitempty,min=0"`
+}
+
+// OutboxMessageResponse leaves out the payload, emails in it can carry one-time links
+type OutboxMessageResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Topic         string     `json:"topic"`
+	Status        string     `json:"status"`
+	Attempts      int32      `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error"`
+	RequestID     string     `json:"request_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at"`
+}
@@ -0,0 +1,44 @@
+package patients
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PatientRecordQueryDTO states why a patient record is being read
+type PatientRecordQueryDTO struct {
+	Purpose string `form:"purpose" json:"purpose" validate:"required,max=255"`
+}
+
+// BreakGlassDTO justifies emergency access to a patient record
+type BreakGlassDTO struct {
+	Reason string `json:"reason" validate:"required,min=10,max=1000"`
+}
+
+type AccessLogQueryDTO struct {
+	Limit  int32 `form:"limit" json:"limit" validate:"omitempty,min=1,max=200"`
+	Offset int32 `form:"offset" json:"offset" validate:"omitempty,min=0"`
+}
+
+type PatientRecordResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	PhoneNumber string    `json:"phone_number"`
+	IsVerified  bool      `json:"is_verified"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AccessLogResponse is one read of the caller's record, as shown to the patient
+type AccessLogResponse struct {
+	ID         uuid.UUID `json:"id"`
+	AccessedAt time.Time `json:"accessed_at"`
+	ActorName  string    `json:"actor_name"`
+	ActorRole  string    `json:"actor_role"`
+	Resource   string    `json:"resource"`
+	Purpose    string    `json:"purpose"`
+	BreakGlass bool      `json:"break_glass"`
+	Reason     string    `json:"reason,omitempty"`
+}
@@ -0,0 +1,32 @@
+package roles
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoleDTO struct {
+	Name               string `json:"name" validate:"required"`
+	Code               string `json:"code" validate:"required"`
+	Level              int32  `json:"level" validate:"required"`
+	Description        string `json:"description"`
+	CanSelfRegister    bool   `json:"can_self_register"`
+	InheritPermissions bool   `json:"inherit_permissions"`
+}
+
+type AssignRoleDTO struct {
+	RoleID uuid.UUID `json:"role_id" validate:"required,uuid"`
+}
+
+type RoleResponse struct {
+	ID                 uuid.UUID `json:"id"`
+	Name               string    `json:"name"`
+	Code               string    `json:"code"`
+	Level              int32     `json:"level"`
+	Description        string    `json:"description"`
+	CanSelfRegister    bool      `json:"can_self_register"`
+	InheritPermissions bool      `json:"inherit_permissions"`
+	CreatedAt          time.Time `json:"created_at,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at,omitempty"`
+}
@@ -7,13 +7,24 @@ import (
 )
 
 type TreatmentDTO struct {
-	CategoryID    uuid.UUID `json:"category_id" validation:"required"`
-	NameTreatment string    `json:"name_treatment" validation:"required"`
-	Description   string    `json:"description" validation:"required"`
-	Thumbnail     string    `json:"thumbnail" validation:"required"`
-	Price         float64   `json:"price" validation:"required"`
-	Duration      int32     `json:"duration" validation:"required"`
-	IsActive      bool      `json:"is_active" validation:"required"`
+	CategoryID    uuid.UUID `json:"category_id" validate:"required,uuid"`
+	NameTreatment string    `json:"name_treatment" validate:"required"`
+	Description   string    `json:"description" validate:"required"`
+	Thumbnail     string    `json:"thumbnail" validate:"required"`
+	Price         float64   `json:"price" validate:"required,money"`
+	Duration      int32     `json:"duration" validate:"required,min=1"`
+	IsActive      bool      `json:"is_active"`
+}
+
+// TreatmentPatchDTO is a JSON Merge Patch of a treatment; absent fields are left unchanged
+type TreatmentPatchDTO struct {
+	CategoryID    *uuid.UUID `json:"category_id" validate:"omitnil,uuid"`
+	NameTreatment *string    `json:"name_treatment" validate:"omitnil,min=1"`
+	Description   *string    `json:"description" validate:"omitnil,min=1"`
+	Thumbnail     *string    `json:"thumbnail" validate:"omitnil,min=1"`
+	Price         *float64   `json:"price" validate:"omitnil,money"`
+	Duration      *int32     `json:"duration" validate:"omitnil,min=1"`
+	IsActive      *bool      `json:"is_active"`
 }
 
 type TreatmentResponse struct {
@@ -27,10 +38,16 @@ type TreatmentResponse struct {
 	IsActive      bool      `json:"is_active"`
 	CreatedAt     time.Time `json:"created_at,omitempty"`
 	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+	Version       int32     `json:"version"` // also sent as the ETag of GET /treatments/:id
 }
 
 type CategoryDTO struct {
-	NameCategory string `json:"name_category" validation:"required"`
+	NameCategory string `json:"name_category" validate:"required"`
+}
+
+// CategoryPatchDTO is a JSON Merge Patch of a category; absent fields are left unchanged
+type CategoryPatchDTO struct {
+	NameCategory *string `json:"name_category" validate:"omitnil,min=1"`
 }
 
 type CategoryResponse struct {
@@ -38,4 +55,5 @@ type CategoryResponse struct {
 	NameCategory string    `json:"name_category"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	Version      int32     `json:"version"` // also sent as the ETag of GET /categories/:id
 }
@@ -7,11 +7,12 @@ import (
 )
 
 type RegisterDTO struct {
-	Name        string    `json:"name" validation:"required"`
-	Email       string    `json:"email" validation:"required, email"`
-	Password    string    `json:"password" validation:"required min=8 max=20 alphaNum cap special"`
-	PhoneNumber string    `json:"phone_number" validation:"required"`
-	RoleID      uuid.UUID `json:"role_id" validation:"required"`
+	Name        string    `json:"name" validate:"required"`
+	Email       string    `json:"email" validate:"required,email"`
+	Password    string    `json:"password" validate:"required,min=8,max=72,password"`
+	PhoneNumber string    `json:"phone_number" validate:"required,phone_id"`
+	RoleID      uuid.UUID `json:"role_id" validate:"required,uuid"`
+	Locale      string    `json:"locale"` // optional preferred locale (en or id); empty follows Accept-Language
 }
 
 type AuthResponse struct {
@@ -21,6 +22,7 @@ type AuthResponse struct {
 	PhoneNumber  string        `json:"phone_number"`
 	IsVerified   bool          `json:"is_verified"`
 	RoleID       uuid.UUID     `json:"role_id"`
+	Locale       string        `json:"locale"`
 	RoleDetail   *RoleResponse `json:"role_detail,omitempty"`
 	CreatedAt    time.Time     `json:"created_at"`
 	UpdatedAt    time.Time     `json:"updated_at"`
@@ -37,16 +39,26 @@ type RoleResponse struct {
 }
 
 type LoginDTO struct {
-	Email    string `json:"email" validation:"required"`
-	Password string `json:"password" validation:"required min=8 max=20 alphaNum cap special"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"` // strength is only enforced when a password is set
 }
 
 type EmailRequest struct {
-	Email string `json:"email" validation:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type UpdateLocaleDTO struct {
+	Locale string `json:"locale"` // en or id; empty clears the preference
+}
+
+// ProfilePatchDTO is a JSON Merge Patch of the caller's profile; absent fields are left unchanged
+type ProfilePatchDTO struct {
+	Name        *string `json:"name" validate:"omitnil,min=1"`
+	PhoneNumber *string `json:"phone_number" validate:"omitnil,phone_id"`
 }
 
 type ResetPasswordDTO struct {
-	Password      string `json:"password" validation:"required min=8 max=20 alphaNum cap special"`
-	RetryPassword string `json:"retry_password" validation:"required min=8 max=20 alphaNum cap special"`
-	VerifyCode    string `json:"verify_code" validation:"required"`
+	Password      string `json:"password" validate:"required,min=8,max=72,password"`
+	RetryPassword string `json:"retry_password" validate:"required,min=8,max=72,password"`
+	VerifyCode    string `json:"verify_code" validate:"required"`
 }
@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"context"
+	"log/slog"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+
+	"github.com/google/uuid"
+)
+
+// Actions evaluated by policies
+const (
+	ActionRead   = "read"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Resource types known to the default rules
+const (
+	ResourceAppointment   = "appointment"
+	ResourcePatientRecord = "patient_record"
+)
+
+// Principal is the authenticated user an access decision is made for
+type Principal struct {
+	UserID    uuid.UUID
+	RoleCode  string
+	RoleLevel int32
+}
+
+// Resource describes the loaded entity being accessed.
+// OwnerID is the patient the resource belongs to; AssignedIDs are the staff (e.g. doctors) assigned to it.
+type Resource struct {
+	Type        string
+	ID          uuid.UUID
+	OwnerID     uuid.UUID
+	AssignedIDs []uuid.UUID
+}
+
+// IsAssigned reports whether userID is one of the staff assigned to the resource
+func (r Resource) IsAssigned(userID uuid.UUID) bool {
+	for _, id := range r.AssignedIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Decision is the outcome of a policy evaluation, with a human readable reason.
+// Err is the error a denial is reported with; nil reports ErrForbidden.
+type Decision struct {
+	Allowed bool
+	Reason  string
+	Err     error
+}
+
+// Allow returns an allowing decision
+func Allow(reason string) Decision {
+	return Decision{Allowed: true, Reason: reason}
+}
+
+// Deny returns a denying decision
+func Deny(reason string) Decision {
+	return Decision{Allowed: false, Reason: reason}
+}
+
+// DenyWith returns a denying decision reported with err, e.g. to point at another way in
+func DenyWith(reason string, err error) Decision {
+	return Decision{Allowed: false, Reason: reason, Err: err}
+}
+
+// Rule evaluates a principal's access to a resource.
+// It returns applies=false when it has no opinion, letting the next rule decide.
+type Rule func(p Principal, action string, res Resource) (decision Decision, applies bool)
+
+// Engine evaluates rules in order; the first rule that applies decides, and no applicable rule means deny.
+type Engine struct {
+	global []Rule
+	rules  map[string][]Rule
+}
+
+// NewEngine creates an engine without rules
+func NewEngine() *Engine {
+	return &Engine{rules: make(map[string][]Rule)}
+}
+
+// AddGlobalRule registers a rule evaluated for every resource type, before type specific rules
+func (e *Engine) AddGlobalRule(rule Rule) *Engine {
+	e.global = append(e.global, rule)
+	return e
+}
+
+// AddRule registers a rule for a resource type
+func (e *Engine) AddRule(resourceType string, rule Rule) *Engine {
+	e.rules[resourceType] = append(e.rules[resourceType], rule)
+	return e
+}
+
+// Evaluate returns the access decision for a principal performing action on res
+func (e *Engine) Evaluate(p Principal, action string, res Resource) Decision {
+	for _, rule := range e.global {
+		if decision, applies := rule(p, action, res); applies {
+			return decision
+		}
+	}
+	for _, rule := range e.rules[res.Type] {
+		if decision, applies := rule(p, action, res); applies {
+			return decision
+		}
+	}
+	return Deny("no policy grants " + action + " on " + res.Type)
+}
+
+// Authorize evaluates access and returns the decision's error, or ErrForbidden, on denial, logging the reason
+func (e *Engine) Authorize(ctx context.Context, p Principal, action string, res Resource) error {
+	decision := e.Evaluate(p, action, res)
+	if !decision.Allowed {
+		slog.WarnContext(ctx, "Policy denied",
+			"user_id", p.UserID,
+			"role", p.RoleCode,
+			"action", action,
+			"resource", res.Type,
+			"resource_id", res.ID,
+			"reason", decision.Reason,
+		)
+		if decision.Err != nil {
+			return errWrap.WrapError(decision.Err)
+		}
+		return errWrap.WrapError(errConsts.ErrForbidden)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/roles"
+
+	"github.com/google/uuid"
+)
+
+func TestRules(t *testing.T) {
+	patientID := uuid.New()
+	doctorID := uuid.New()
+	otherID := uuid.New()
+
+	record := Resource{Type: ResourcePatientRecord, ID: patientID, OwnerID: patientID, AssignedIDs: []uuid.UUID{doctorID}}
+	patient := Principal{UserID: patientID, RoleCode: roles.PATIENT}
+	doctor := Principal{UserID: doctorID, RoleCode: roles.DOCTOR}
+
+	tests := []struct {
+		name        string
+		rule        Rule
+		principal   Principal
+		action      string
+		wantApplies bool
+		wantAllowed bool
+		wantErr     error
+	}{
+		{"staff override allows owner", StaffOverride, Principal{UserID: otherID, RoleCode: roles.OWNER}, ActionDelete, true, true, nil},
+		{"staff override allows admin", StaffOverride, Principal{UserID: otherID, RoleCode: roles.ADMIN}, ActionRead, true, true, nil},
+		{"staff override ignores doctor", StaffOverride, doctor, ActionRead, false, false, nil},
+
+		{"patient reads own record", PatientOwnsResource, patient, ActionRead, true, true, nil},
+		{"patient updates own record", PatientOwnsResource, patient, ActionUpdate, true, true, nil},
+		{"patient cannot delete own record", PatientOwnsResource, patient, ActionDelete, true, false, nil},
+		{"patient cannot read another record", PatientOwnsResource, Principal{UserID: otherID, RoleCode: roles.PATIENT}, ActionRead, true, false, nil},
+		{"patient rule ignores doctor", PatientOwnsResource, doctor, ActionRead, false, false, nil},
+
+		{"assigned doctor reads record", DoctorAssignedToResource, doctor, ActionRead, true, true, nil},
+		{"assigned doctor updates record", DoctorAssignedToResource, doctor, ActionUpdate, true, true, nil},
+		{"assigned doctor cannot delete record", DoctorAssignedToResource, doctor, ActionDelete, true, false, nil},
+		{"unassigned doctor is sent to break the glass", DoctorAssignedToResource, Principal{UserID: otherID, RoleCode: roles.DOCTOR}, ActionRead, true, false, errConsts.ErrBreakGlassRequired},
+		{"unassigned doctor cannot update record", DoctorAssignedToResource, Principal{UserID: otherID, RoleCode: roles.DOCTOR}, ActionUpdate, true, false, nil},
+		{"doctor rule ignores patient", DoctorAssignedToResource, patient, ActionRead, false, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, applies := tt.rule(tt.principal, tt.action, record)
+			if applies != tt.wantApplies {
+				t.Fatalf("applies = %v, want %v", applies, tt.wantApplies)
+			}
+			if applies && decision.Allowed != tt.wantAllowed {
+				t.Errorf("allowed = %v (%s), want %v", decision.Allowed, decision.Reason, tt.wantAllowed)
+			}
+			if decision.Err != tt.wantErr {
+				t.Errorf("err = %v, want %v", decision.Err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultEngine(t *testing.T) {
+	patientID := uuid.New()
+	doctorID := uuid.New()
+	otherID := uuid.New()
+
+	record := Resource{Type: ResourcePatientRecord, ID: patientID, OwnerID: patientID, AssignedIDs: []uuid.UUID{doctorID}}
+	appointment := Resource{Type: ResourceAppointment, ID: uuid.New(), OwnerID: patientID, AssignedIDs: []uuid.UUID{doctorID}}
+	unknown := Resource{Type: "invoice", ID: uuid.New(), OwnerID: patientID}
+
+	tests := []struct {
+		name      string
+		principal Principal
+		action    string
+		resource  Resource
+		wantErr   error
+	}{
+		{"admin reads any record", Principal{UserID: otherID, RoleCode: roles.ADMIN}, ActionRead, record, nil},
+		{"patient reads own record", Principal{UserID: patientID, RoleCode: roles.PATIENT}, ActionRead, record, nil},
+		{"patient denied another record", Principal{UserID: otherID, RoleCode: roles.PATIENT}, ActionRead, record, errConsts.ErrForbidden},
+		{"assigned doctor reads record", Principal{UserID: doctorID, RoleCode: roles.DOCTOR}, ActionRead, record, nil},
+		{"unassigned doctor must break the glass", Principal{UserID: otherID, RoleCode: roles.DOCTOR}, ActionRead, record, errConsts.ErrBreakGlassRequired},
+		{"unassigned doctor denied updating record", Principal{UserID: otherID, RoleCode: roles.DOCTOR}, ActionUpdate, record, errConsts.ErrForbidden},
+		{"unassigned doctor denied appointment", Principal{UserID: otherID, RoleCode: roles.DOCTOR}, ActionRead, appointment, errConsts.ErrForbidden},
+		{"assigned doctor updates appointment", Principal{UserID: doctorID, RoleCode: roles.DOCTOR}, ActionUpdate, appointment, nil},
+		{"patient denied deleting appointment", Principal{UserID: patientID, RoleCode: roles.PATIENT}, ActionDelete, appointment, errConsts.ErrForbidden},
+		{"role without a rule is denied", Principal{UserID: otherID, RoleCode: roles.APOTEKER}, ActionRead, record, errConsts.ErrForbidden},
+		{"resource without rules is denied", Principal{UserID: patientID, RoleCode: roles.PATIENT}, ActionRead, unknown, errConsts.ErrForbidden},
+		{"owner reads resource without rules", Principal{UserID: otherID, RoleCode: roles.OWNER}, ActionRead, unknown, nil},
+	}
+
+	engine := Default()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := engine.Authorize(context.Background(), tt.principal, tt.action, tt.resource)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Authorize() = %v, want allowed", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authorize() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
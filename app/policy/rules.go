@@ -0,0 +1,58 @@
+package policy
+
+import (
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/roles"
+)
+
+// Default returns the engine with the clinic's standard ownership rules
+func Default() *Engine {
+	return NewEngine().
+		AddGlobalRule(StaffOverride).
+		AddRule(ResourceAppointment, PatientOwnsResource).
+		AddRule(ResourceAppointment, DoctorAssignedToResource).
+		AddRule(ResourcePatientRecord, PatientOwnsResource).
+		AddRule(ResourcePatientRecord, DoctorAssignedToResource)
+}
+
+// StaffOverride lets owners and admins access every resource
+func StaffOverride(p Principal, action string, res Resource) (Decision, bool) {
+	switch p.RoleCode {
+	case roles.OWNER, roles.ADMIN:
+		return Allow(p.RoleCode + " has full access"), true
+	}
+	return Decision{}, false
+}
+
+// PatientOwnsResource lets patients read and update only resources they own.
+// Patients never delete, and are denied anything owned by someone else.
+func PatientOwnsResource(p Principal, action string, res Resource) (Decision, bool) {
+	if p.RoleCode != roles.PATIENT {
+		return Decision{}, false
+	}
+	if res.OwnerID != p.UserID {
+		return Deny("patient does not own this " + res.Type), true
+	}
+	if action == ActionDelete {
+		return Deny("patients cannot delete " + res.Type), true
+	}
+	return Allow("patient owns this " + res.Type), true
+}
+
+// DoctorAssignedToResource lets doctors access only resources of patients assigned to them.
+// Other doctors reading a patient record are told to break the glass instead.
+func DoctorAssignedToResource(p Principal, action string, res Resource) (Decision, bool) {
+	if p.RoleCode != roles.DOCTOR {
+		return Decision{}, false
+	}
+	if !res.IsAssigned(p.UserID) {
+		if res.Type == ResourcePatientRecord && action == ActionRead {
+			return DenyWith("doctor is not assigned to this "+res.Type, errConsts.ErrBreakGlassRequired), true
+		}
+		return Deny("doctor is not assigned to this " + res.Type), true
+	}
+	if action == ActionDelete {
+		return Deny("doctors cannot delete " + res.Type), true
+	}
+	return Allow("doctor is assigned to this " + res.Type), true
+}
@@ -2,16 +2,24 @@
 INSERT INTO categories (name_category) VALUES ($1) RETURNING *;
 
 -- name: FindCategories :many
-SELECT id, name_category, created_at, updated_at FROM categories;
+SELECT id, name_category, created_at, updated_at, version FROM categories;
 
 -- name: FindCategoryById :one
-SELECT id, name_category, created_at, updated_at FROM categories WHERE id = $1;
+SELECT id, name_category, created_at, updated_at, version FROM categories WHERE id = $1;
 
 -- name: FindCategoryByName :one
-SELECT id, name_category, created_at, updated_at FROM categories WHERE name_category = $1;
+SELECT id, name_category, created_at, updated_at, version FROM categories WHERE name_category = $1;
 
 -- name: UpdateCategory :one
-UPDATE categories SET name_category = $2 WHERE id = $1 RETURNING *;
+UPDATE categories SET name_category = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND version = $3 RETURNING *;
+
+-- name: PatchCategory :one
+UPDATE categories SET
+  name_category = COALESCE(sqlc.narg(name_category), name_category),
+  version = version + 1,
+  updated_at = NOW()
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) RETURNING *;
 
 -- name: DeleteCategory :exec
 DELETE FROM categories WHERE id = $1;
@@ -0,0 +1,34 @@
+-- name: ClaimIdempotencyKey :one
+-- Inserts the key, or takes over an expired one or an in-flight one for the same request
+-- whose lock went stale; returns no row when another request holds the key.
+INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, expires_at)
+VALUES (sqlc.arg(user_id), sqlc.arg(idempotency_key), sqlc.arg(request_hash), sqlc.arg(expires_at))
+ON CONFLICT (user_id, idempotency_key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = 0,
+    content_type = '',
+    response_body = NULL,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW(),
+    updated_at = NOW()
+WHERE idempotency_keys.expires_at <= NOW()
+   OR (idempotency_keys.status_code = 0
+       AND idempotency_keys.request_hash = EXCLUDED.request_hash
+       AND idempotency_keys.updated_at <= sqlc.arg(stale_before))
+RETURNING *;
+
+-- name: FindIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE user_id = $1 AND idempotency_key = $2 AND expires_at > NOW()
+LIMIT 1;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $3, content_type = $4, response_body = $5, updated_at = NOW()
+WHERE user_id = $1 AND idempotency_key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys WHERE expires_at <= NOW();
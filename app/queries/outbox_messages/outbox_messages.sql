@@ -0,0 +1,48 @@
+-- name: CreateOutboxMessage :exec
+INSERT INTO outbox_messages (topic, payload, request_id)
+VALUES ($1, $2, $3);
+
+-- name: ClaimOutboxMessages :many
+-- Due messages are leased by pushing next_attempt_at past the lease, so concurrent
+-- dispatchers skip them and a crashed dispatcher's messages become due again.
+UPDATE outbox_messages
+SET next_attempt_at = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::float8)
+WHERE id IN (
+    SELECT id FROM outbox_messages
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(row_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, topic, payload, status, attempts, next_attempt_at, last_error, request_id, created_at, sent_at;
+
+-- name: MarkOutboxMessageSent :exec
+-- the payload is cleared once delivered, emails carry one-time links
+UPDATE outbox_messages
+SET status = 'sent', attempts = attempts + 1, payload = '{}', last_error = '', sent_at = NOW()
+WHERE id = $1;
+
+-- name: RetryOutboxMessage :exec
+UPDATE outbox_messages
+SET attempts = attempts + 1,
+    next_attempt_at = NOW() + make_interval(secs => sqlc.arg(delay_seconds)::float8),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: DeadLetterOutboxMessage :exec
+UPDATE outbox_messages
+SET status = 'dead', attempts = attempts + 1, last_error = $2
+WHERE id = $1;
+
+-- name: RequeueOutboxMessage :one
+UPDATE outbox_messages
+SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+WHERE id = $1 AND status = 'dead'
+RETURNING id, topic, payload, status, attempts, next_attempt_at, last_error, request_id, created_at, sent_at;
+
+-- name: ListOutboxMessages :many
+SELECT id, topic, payload, status, attempts, next_attempt_at, last_error, request_id, created_at, sent_at
+FROM outbox_messages
+WHERE status = $1
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
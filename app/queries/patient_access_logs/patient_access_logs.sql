@@ -0,0 +1,12 @@
+-- name: CreatePatientAccessLog :exec
+INSERT INTO patient_access_logs
+(patient_id, actor_user_id, actor_role, actor_ip, request_id, resource, purpose, break_glass, reason)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ListPatientAccessLogs :many
+SELECT l.id, l.accessed_at, l.actor_user_id, u.name AS actor_name, l.actor_role, l.resource, l.purpose, l.break_glass, l.reason
+FROM patient_access_logs l
+JOIN users u ON u.id = l.actor_user_id
+WHERE l.patient_id = sqlc.arg(patient_id)
+ORDER BY l.accessed_at DESC, l.id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
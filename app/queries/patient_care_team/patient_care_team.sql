@@ -0,0 +1,14 @@
+-- name: AddCareTeamMember :exec
+INSERT INTO patient_care_team (patient_id, staff_user_id, assigned_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (patient_id, staff_user_id) DO NOTHING;
+
+-- name: ListCareTeam :many
+SELECT staff_user_id
+FROM patient_care_team
+WHERE patient_id = $1
+ORDER BY created_at;
+
+-- name: RemoveCareTeamMember :execrows
+DELETE FROM patient_care_team
+WHERE patient_id = $1 AND staff_user_id = $2;
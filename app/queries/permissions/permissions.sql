@@ -4,3 +4,8 @@ WHERE module = $1 AND action = $2 AND is_active = true
 LIMIT 1;
 
 
+
+-- name: GetActivePermissions :many
+SELECT module, action FROM permissions
+WHERE is_active = true
+ORDER BY module, action;
@@ -4,3 +4,14 @@ SELECT
 FROM role_permissions rp
 JOIN permissions p ON rp.permission_id = p.id
 WHERE rp.role_id = $1::uuid AND p.is_active = true;
+
+-- name: GetEffectiveRolePermissions :many
+-- Permissions granted to the role itself, plus those of every lower-level role
+-- when the role is configured to inherit them.
+SELECT DISTINCT
+    p.module, p.action, p.name
+FROM roles r
+JOIN roles src ON src.id = r.id OR (r.inherit_permissions AND src.level < r.level)
+JOIN role_permissions rp ON rp.role_id = src.id
+JOIN permissions p ON rp.permission_id = p.id
+WHERE r.id = $1::uuid AND p.is_active = true;
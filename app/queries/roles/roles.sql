@@ -1,8 +1,24 @@
 -- name: GetRoleByID :one
-SELECT id, name, code, level, description, can_self_register, created_at, updated_at FROM roles r WHERE r.id = $1;
+SELECT id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions FROM roles r WHERE r.id = $1;
 
 -- name: GetRoleByCode :one
-SELECT id, name, code, level, description, can_self_register, created_at, updated_at FROM roles WHERE code = $1 LIMIT 1;
+SELECT id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions FROM roles WHERE code = $1 LIMIT 1;
 
 -- name: GetAllRoles :many
-SELECT id, name, code, level, description, can_self_register, created_at, updated_at FROM roles;
+SELECT id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions FROM roles ORDER BY level DESC;
+
+-- name: CreateRole :one
+INSERT INTO roles (name, code, level, description, can_self_register, inherit_permissions)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions;
+
+-- name: UpdateRole :one
+UPDATE roles SET
+  name = $2,
+  level = $3,
+  description = $4,
+  can_self_register = $5,
+  inherit_permissions = $6,
+  updated_at = NOW()
+WHERE id = $1
+RETURNING id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions;
@@ -0,0 +1,41 @@
+-- name: CreateInvite :one
+INSERT INTO staff_invites (email, role_id, token_hash, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: FindInviteById :one
+SELECT * FROM staff_invites WHERE id = $1 LIMIT 1;
+
+-- name: FindPendingInviteByEmail :one
+SELECT * FROM staff_invites
+WHERE email = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: FindPendingInviteByTokenHash :one
+SELECT * FROM staff_invites
+WHERE token_hash = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+LIMIT 1;
+
+-- name: ListPendingInvites :many
+SELECT * FROM staff_invites
+WHERE accepted_at IS NULL AND revoked_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: MarkInviteAccepted :one
+UPDATE staff_invites
+SET accepted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()
+RETURNING *;
+
+-- name: RefreshInviteToken :one
+UPDATE staff_invites
+SET token_hash = $2, expires_at = $3, updated_at = NOW()
+WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeInvite :one
+UPDATE staff_invites
+SET revoked_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+RETURNING *;
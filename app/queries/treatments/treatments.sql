@@ -4,13 +4,13 @@ INSERT INTO treatments
 VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 
 -- name: FindTreatments :many
-SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at FROM treatments;
+SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version FROM treatments;
 
 -- name: FindTreatmentById :one
-SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at FROM treatments WHERE id = $1;
+SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version FROM treatments WHERE id = $1;
 
 -- name: FindTreatmentByName :one
-SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at FROM treatments WHERE name_treatment = $1;
+SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version FROM treatments WHERE name_treatment = $1;
 
 -- name: UpdateTreatment :one
 UPDATE treatments SET
@@ -20,8 +20,23 @@ UPDATE treatments SET
   thumbnail = $5,
   price = $6,
   duration = $7,
-  is_active = $8
-WHERE id = $1 RETURNING *;
+  is_active = $8,
+  version = version + 1,
+  updated_at = NOW()
+WHERE id = $1 AND version = $9 RETURNING *;
+
+-- name: PatchTreatment :one
+UPDATE treatments SET
+  category_id = COALESCE(sqlc.narg(category_id), category_id),
+  name_treatment = COALESCE(sqlc.narg(name_treatment), name_treatment),
+  description = COALESCE(sqlc.narg(description), description),
+  thumbnail = COALESCE(sqlc.narg(thumbnail), thumbnail),
+  price = COALESCE(sqlc.narg(price), price),
+  duration = COALESCE(sqlc.narg(duration), duration),
+  is_active = COALESCE(sqlc.narg(is_active), is_active),
+  version = version + 1,
+  updated_at = NOW()
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) RETURNING *;
 
 -- name: DeleteTreatment :exec
 DELETE FROM treatments WHERE id = $1;
@@ -7,9 +7,11 @@ INSERT INTO users(
   role_id,
   is_verified,
   verify_code,
-  verify_expires_at
+  verify_expires_at,
+  locale,
+  phone_number_bidx
 )VALUES(
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
 RETURNING
   id,
@@ -27,7 +29,7 @@ RETURNING
 -- name: FindUserByEmail :one
 SELECT
     u.id, u.email, u.password, u.name, u.phone_number,
-    u.role_id, u.is_verified, COALESCE(u.verify_code, '') AS verify_code,COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,u.created_at, u.updated_at,
+    u.role_id, u.is_verified, COALESCE(u.verify_code, '') AS verify_code,COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,u.created_at, u.updated_at, u.locale,
     r.id as role_id, r.name as role_name, r.code as role_code,
     r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
 FROM users u
@@ -40,18 +42,37 @@ SELECT
 	u.role_id, u.is_verified,
 	COALESCE(u.verify_code, '') AS verify_code,
 	COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,
-	u.created_at, u.updated_at,
+	u.created_at, u.updated_at, u.locale,
 	r.id as role_id, r.name as role_name, r.code as role_code,
 	r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
 FROM users u
 JOIN roles r ON u.role_id = r.id
 WHERE u.id = $1;
 
+-- name: FindUserRoleID :one
+SELECT role_id
+FROM users
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: FindUserByPhoneBidx :one
+SELECT
+	u.id, u.email, u.name, u.phone_number,
+	u.role_id, u.is_verified,
+	COALESCE(u.verify_code, '') AS verify_code,
+	COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,
+	u.created_at, u.updated_at, u.locale,
+	r.id as role_id, r.name as role_name, r.code as role_code,
+	r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
+FROM users u
+JOIN roles r ON u.role_id = r.id
+WHERE u.phone_number_bidx = $1 AND u.deleted_at IS NULL
+LIMIT 1;
+
 -- name: FindUserByVerifyCode :one
 SELECT
     u.id, u.email, u.password, u.name, u.phone_number,
     u.role_id, u.is_verified, u.verify_code, u.verify_expires_at,
-    u.created_at, u.updated_at,
+    u.created_at, u.updated_at, u.locale,
     r.id as role_id, r.name as role_name, r.code as role_code,
     r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
 FROM users u
@@ -69,6 +90,7 @@ SET
     is_verified = COALESCE($6, is_verified),
     verify_code = $7,
     verify_expires_at = $8,
+    phone_number_bidx = COALESCE($9, phone_number_bidx),
     updated_at = NOW()
 WHERE id = $1
 RETURNING
@@ -81,6 +103,25 @@ RETURNING
     created_at,
     updated_at;
 
+-- name: PatchUser :one
+UPDATE users
+SET
+    name = COALESCE(sqlc.narg(name), name),
+    phone_number = COALESCE(sqlc.narg(phone_number), phone_number),
+    phone_number_bidx = COALESCE(sqlc.narg(phone_number_bidx), phone_number_bidx),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    email,
+    phone_number,
+    role_id,
+    is_verified,
+    locale,
+    created_at,
+    updated_at;
+
 -- name: DeleteUser :one
 UPDATE users
 SET deleted_at = NOW()
@@ -104,3 +145,40 @@ FROM users u
 JOIN roles r ON u.role_id = r.id
 ORDER BY u.created_at DESC
 LIMIT $1;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET
+    role_id = $2,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    email,
+    phone_number,
+    role_id,
+    is_verified,
+    created_at,
+    updated_at;
+
+-- name: UpdateUserLocale :exec
+UPDATE users
+SET
+    locale = $2,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListUserPhoneNumbers :many
+SELECT id, phone_number, phone_number_bidx
+FROM users
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: UpdateUserPhoneNumber :execrows
+UPDATE users
+SET
+    phone_number = sqlc.arg(phone_number),
+    phone_number_bidx = sqlc.arg(phone_number_bidx)
+WHERE id = sqlc.arg(id) AND phone_number = sqlc.arg(old_phone_number);
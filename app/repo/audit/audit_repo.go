@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/pkg/audit"
+	auditdb "medisuite-api/pkg/db/audit_events"
+	"medisuite-api/pkg/logs"
+)
+
+type IAuditRepo interface {
+	Record(ctx context.Context, event audit.Event) error
+	List(ctx context.Context, req auditdb.ListAuditEventsParams) ([]auditdb.AuditEvent, error)
+}
+
+type AuditRepo struct {
+	q *auditdb.Queries
+}
+
+func NewAuditRepo(q *auditdb.Queries) IAuditRepo {
+	return &AuditRepo{q: q}
+}
+
+// Repository method for recording an audit event on behalf of the actor of ctx.
+// Call it with the repository of the ExecTx that makes the change, so both commit together.
+func (r *AuditRepo) Record(ctx context.Context, event audit.Event) error {
+	diff, err := audit.Diff(event.Before, event.After)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error building audit diff", "error", err, "entity_type", event.EntityType, "entity_id", event.EntityID)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	err = r.q.CreateAuditEvent(ctx, auditdb.CreateAuditEventParams{
+		ActorUserID: actor.UserID,
+		ActorRole:   actor.Role,
+		ActorIp:     actor.IP,
+		RequestID:   logs.RequestIDFromContext(ctx),
+		Action:      event.Action,
+		EntityType:  event.EntityType,
+		EntityID:    event.EntityID,
+		Diff:        diff,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error recording audit event", "error", err, "action", event.Action, "entity_type", event.EntityType, "entity_id", event.EntityID)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for listing audit events, newest first.
+func (r *AuditRepo) List(ctx context.Context, req auditdb.ListAuditEventsParams) ([]auditdb.AuditEvent, error) {
+	events, err := r.q.ListAuditEvents(ctx, req)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing audit events", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return events, nil
+}
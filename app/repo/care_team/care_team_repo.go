@@ -0,0 +1,59 @@
+package care_team
+
+import (
+	"context"
+	"log/slog"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/pkg/audit"
+	careteamdb "medisuite-api/pkg/db/patient_care_team"
+
+	"github.com/google/uuid"
+)
+
+type ICareTeamRepo interface {
+	ListStaff(ctx context.Context, patientID uuid.UUID) ([]uuid.UUID, error)
+	Assign(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) error
+	Unassign(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) (bool, error)
+}
+
+type CareTeamRepo struct {
+	q *careteamdb.Queries
+}
+
+func NewCareTeamRepo(q *careteamdb.Queries) ICareTeamRepo {
+	return &CareTeamRepo{q: q}
+}
+
+// Repository method for listing the staff on a patient's care team.
+func (r *CareTeamRepo) ListStaff(ctx context.Context, patientID uuid.UUID) ([]uuid.UUID, error) {
+	staff, err := r.q.ListCareTeam(ctx, patientID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing care team", "error", err, "patient_id", patientID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return staff, nil
+}
+
+// Repository method for adding a staff member to a patient's care team on behalf of the actor of ctx.
+// Assigning someone already on the team is a no-op.
+func (r *CareTeamRepo) Assign(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) error {
+	actor := audit.ActorFromContext(ctx)
+	if err := r.q.AddCareTeamMember(ctx, patientID, staffID, actor.UserID); err != nil {
+		slog.ErrorContext(ctx, "Error assigning care team member", "error", err, "patient_id", patientID, "staff_id", staffID)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for removing a staff member from a patient's care team;
+// returns false when they were not on it.
+func (r *CareTeamRepo) Unassign(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) (bool, error) {
+	removed, err := r.q.RemoveCareTeamMember(ctx, patientID, staffID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error removing care team member", "error", err, "patient_id", patientID, "staff_id", staffID)
+		return false, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return removed > 0, nil
+}
@@ -14,7 +14,8 @@ import (
 
 type ICategoryRepo interface {
 	Create(ctx context.Context, name_category string) (*categorydb.Category, error)
-	Update(ctx context.Context, id uuid.UUID, name_category string) (*categorydb.Category, error)
+	Update(ctx context.Context, id uuid.UUID, name_category string, version int32) (*categorydb.Category, error)
+	Patch(ctx context.Context, id uuid.UUID, name_category *string, version int32) (*categorydb.Category, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindAll(ctx context.Context) ([]categorydb.Category, error)
 	FindById(ctx context.Context, id uuid.UUID) (*categorydb.Category, error)
@@ -34,7 +35,7 @@ func (r *CategoryRepo) Create(ctx context.Context, nameCategory string) (*catego
 	category, err := r.cq.CreateCategory(ctx, nameCategory)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			slog.Error("Error finding name_category", "error", err, "name_category", nameCategory)
+			slog.ErrorContext(ctx, "Error finding name_category", "error", err, "name_category", nameCategory)
 			return nil, nil
 		}
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
@@ -42,12 +43,27 @@ func (r *CategoryRepo) Create(ctx context.Context, nameCategory string) (*catego
 	return &category, nil
 }
 
-// Repository method for updating a category.
-func (r *CategoryRepo) Update(ctx context.Context, id uuid.UUID, nameCategory string) (*categorydb.Category, error) {
-	category, err := r.cq.UpdateCategory(ctx, id, nameCategory)
+// Repository method for updating a category; returns nil when version is no longer
+// the current version, i.e. another update won.
+func (r *CategoryRepo) Update(ctx context.Context, id uuid.UUID, nameCategory string, version int32) (*categorydb.Category, error) {
+	category, err := r.cq.UpdateCategory(ctx, id, nameCategory, version)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			slog.Error("Error finding name_category", "error", err, "name_category", nameCategory)
+			slog.WarnContext(ctx, "Category version conflict", "category_id", id, "version", version)
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &category, nil
+}
+
+// Repository method for partially updating a category; a nil name is left unchanged.
+// Returns nil when version is no longer the current version.
+func (r *CategoryRepo) Patch(ctx context.Context, id uuid.UUID, nameCategory *string, version int32) (*categorydb.Category, error) {
+	category, err := r.cq.PatchCategory(ctx, nameCategory, id, version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.WarnContext(ctx, "Category version conflict", "category_id", id, "version", version)
 			return nil, nil
 		}
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
@@ -60,7 +76,7 @@ func (r *CategoryRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	err := r.cq.DeleteCategory(ctx, id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			slog.Error("Error finding name_category", "error", err, "name_category", id)
+			slog.ErrorContext(ctx, "Error finding name_category", "error", err, "name_category", id)
 			return errWrap.WrapError(errConsts.ErrSQLError)
 		}
 		return errWrap.WrapError(errConsts.ErrSQLError)
@@ -73,7 +89,7 @@ func (r *CategoryRepo) FindAll(ctx context.Context) ([]categorydb.Category, erro
 	categories, err := r.cq.FindCategories(ctx)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			slog.Error("Error finding name_category", "error", err)
+			slog.ErrorContext(ctx, "Error finding name_category", "error", err)
 			return nil, nil
 		}
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
@@ -86,7 +102,7 @@ func (r *CategoryRepo) FindById(ctx context.Context, id uuid.UUID) (*categorydb.
 	category, err := r.cq.FindCategoryById(ctx, id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			slog.Error("Error finding name_category", "error", err)
+			slog.ErrorContext(ctx, "Error finding name_category", "error", err)
 			return nil, nil
 		}
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
@@ -99,7 +115,7 @@ func (r *CategoryRepo) FindCategoryByName(ctx context.Context, nameCategory stri
 	category, err := r.cq.FindCategoryByName(ctx, nameCategory)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			slog.Error("Error finding name_category", "error", err)
+			slog.ErrorContext(ctx, "Error finding name_category", "error", err)
 			return nil, nil
 		}
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
@@ -3,15 +3,21 @@ package repo
 import (
 	"context"
 
+	auditdb "medisuite-api/pkg/db/audit_events"
 	categorydb "medisuite-api/pkg/db/categories"
+	idempotencydb "medisuite-api/pkg/db/idempotency_keys"
+	outboxdb "medisuite-api/pkg/db/outbox_messages"
+	accessdb "medisuite-api/pkg/db/patient_access_logs"
+	careteamdb "medisuite-api/pkg/db/patient_care_team"
 	permissiondb "medisuite-api/pkg/db/permissions"
 	rolepermissiondb "medisuite-api/pkg/db/role_permissions"
 	roledb "medisuite-api/pkg/db/roles"
+	invitedb "medisuite-api/pkg/db/staff_invites"
 	treatmentdb "medisuite-api/pkg/db/treatments"
 	sessiondb "medisuite-api/pkg/db/user_sessions"
 	userdb "medisuite-api/pkg/db/users"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Queries groups all sqlc-generated query sets
@@ -24,6 +30,12 @@ type Queries struct {
 	Sessions        *sessiondb.Queries
 	Categories      *categorydb.Queries
 	Treatments      *treatmentdb.Queries
+	Invites         *invitedb.Queries
+	Idempotency     *idempotencydb.Queries
+	Audit           *auditdb.Queries
+	PatientAccess   *accessdb.Queries
+	Outbox          *outboxdb.Queries
+	CareTeam        *careteamdb.Queries
 }
 
 // Store is the common abstraction for database access at the repository layer.
@@ -32,27 +44,40 @@ type Store interface {
 	Queries() *Queries
 	// ExecTx runs fn within a single database transaction.
 	ExecTx(ctx context.Context, fn func(*Queries) error) error
+	// Ping checks that the database connection is alive.
+	Ping(ctx context.Context) error
+	// MigrationVersion returns the schema version recorded by goose.
+	MigrationVersion(ctx context.Context) (int64, error)
 }
 
-// SQLStore is a Store implementation backed by a pgx.Conn.
+// migrationTable is the goose version table, see goose.TableName
+const migrationTable = "goose_db_version"
+
+// SQLStore is a Store implementation backed by a pgx connection pool.
 type SQLStore struct {
 	queries *Queries
-	conn    *pgx.Conn
+	pool    *pgxpool.Pool
 }
 
-// NewStore creates a new Store instance from a pgx connection.
-func NewStore(conn *pgx.Conn) Store {
+// NewStore creates a new Store instance from a pgx connection pool.
+func NewStore(pool *pgxpool.Pool) Store {
 	return &SQLStore{
 		queries: &Queries{
-			Permissions:     permissiondb.New(conn),
-			Roles:           roledb.New(conn),
-			RolePermissions: rolepermissiondb.New(conn),
-			Users:           userdb.New(conn),
-			Sessions:        sessiondb.New(conn),
-			Categories:      categorydb.New(conn),
-			Treatments:      treatmentdb.New(conn),
+			Permissions:     permissiondb.New(pool),
+			Roles:           roledb.New(pool),
+			RolePermissions: rolepermissiondb.New(pool),
+			Users:           userdb.New(pool),
+			Sessions:        sessiondb.New(pool),
+			Categories:      categorydb.New(pool),
+			Treatments:      treatmentdb.New(pool),
+			Invites:         invitedb.New(pool),
+			Idempotency:     idempotencydb.New(pool),
+			Audit:           auditdb.New(pool),
+			PatientAccess:   accessdb.New(pool),
+			Outbox:          outboxdb.New(pool),
+			CareTeam:        careteamdb.New(pool),
 		},
-		conn: conn,
+		pool: pool,
 	}
 }
 
@@ -64,7 +89,7 @@ func (s *SQLStore) Queries() *Queries {
 // ExecTx runs the provided callback inside a database transaction.
 // Inside fn, the received Queries are already bound to the transaction.
 func (s *SQLStore) ExecTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := s.conn.Begin(ctx)
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -77,6 +102,12 @@ func (s *SQLStore) ExecTx(ctx context.Context, fn func(*Queries) error) error {
 		Sessions:        s.queries.Sessions.WithTx(tx),
 		Categories:      s.queries.Categories.WithTx(tx),
 		Treatments:      s.queries.Treatments.WithTx(tx),
+		Invites:         s.queries.Invites.WithTx(tx),
+		Idempotency:     s.queries.Idempotency.WithTx(tx),
+		Audit:           s.queries.Audit.WithTx(tx),
+		PatientAccess:   s.queries.PatientAccess.WithTx(tx),
+		Outbox:          s.queries.Outbox.WithTx(tx),
+		CareTeam:        s.queries.CareTeam.WithTx(tx),
 	}
 
 	if err := fn(q); err != nil {
@@ -86,3 +117,35 @@ func (s *SQLStore) ExecTx(ctx context.Context, fn func(*Queries) error) error {
 
 	return tx.Commit(ctx)
 }
+
+// Ping checks that the database connection is alive.
+func (s *SQLStore) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+// MigrationVersion returns the current goose schema version: the newest version whose
+// latest row is applied, skipping versions that were later rolled back.
+func (s *SQLStore) MigrationVersion(ctx context.Context) (int64, error) {
+	rows, err := s.pool.Query(ctx, "SELECT version_id, is_applied FROM "+migrationTable+" ORDER BY id DESC")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	rolledBack := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		var applied bool
+		if err := rows.Scan(&version, &applied); err != nil {
+			return 0, err
+		}
+		if rolledBack[version] {
+			continue
+		}
+		if applied {
+			return version, nil
+		}
+		rolledBack[version] = true
+	}
+	return 0, rows.Err()
+}
@@ -0,0 +1,83 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	idempotencydb "medisuite-api/pkg/db/idempotency_keys"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type IIdempotencyRepo interface {
+	Claim(ctx context.Context, req idempotencydb.ClaimIdempotencyKeyParams) (*idempotencydb.IdempotencyKey, error)
+	Find(ctx context.Context, userID uuid.UUID, key string) (*idempotencydb.IdempotencyKey, error)
+	Complete(ctx context.Context, req idempotencydb.CompleteIdempotencyKeyParams) error
+	Release(ctx context.Context, userID uuid.UUID, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type IdempotencyRepo struct {
+	q *idempotencydb.Queries
+}
+
+func NewIdempotencyRepo(q *idempotencydb.Queries) IIdempotencyRepo {
+	return &IdempotencyRepo{q: q}
+}
+
+// Repository method for claiming an idempotency key; returns nil when another request holds it.
+func (r *IdempotencyRepo) Claim(ctx context.Context, req idempotencydb.ClaimIdempotencyKeyParams) (*idempotencydb.IdempotencyKey, error) {
+	record, err := r.q.ClaimIdempotencyKey(ctx, req)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error claiming idempotency key", "error", err, "user_id", req.UserID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &record, nil
+}
+
+// Repository method for finding an unexpired idempotency key; returns nil when it does not exist.
+func (r *IdempotencyRepo) Find(ctx context.Context, userID uuid.UUID, key string) (*idempotencydb.IdempotencyKey, error) {
+	record, err := r.q.FindIdempotencyKey(ctx, userID, key)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error finding idempotency key", "error", err, "user_id", userID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &record, nil
+}
+
+// Repository method for storing the response of the request holding an idempotency key.
+func (r *IdempotencyRepo) Complete(ctx context.Context, req idempotencydb.CompleteIdempotencyKeyParams) error {
+	if err := r.q.CompleteIdempotencyKey(ctx, req); err != nil {
+		slog.ErrorContext(ctx, "Error completing idempotency key", "error", err, "user_id", req.UserID)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for releasing an idempotency key so the request can be retried.
+func (r *IdempotencyRepo) Release(ctx context.Context, userID uuid.UUID, key string) error {
+	if err := r.q.DeleteIdempotencyKey(ctx, userID, key); err != nil {
+		slog.ErrorContext(ctx, "Error releasing idempotency key", "error", err, "user_id", userID)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for deleting expired idempotency keys; returns how many were deleted.
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	deleted, err := r.q.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error deleting expired idempotency keys", "error", err)
+		return 0, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return deleted, nil
+}
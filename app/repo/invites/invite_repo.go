@@ -0,0 +1,137 @@
+package invites
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	invitedb "medisuite-api/pkg/db/staff_invites"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type IInviteRepo interface {
+	Create(ctx context.Context, req invitedb.CreateInviteParams) (*invitedb.StaffInvite, error)
+	FindInviteById(ctx context.Context, id uuid.UUID) (*invitedb.StaffInvite, error)
+	FindPendingByEmail(ctx context.Context, email string) (*invitedb.StaffInvite, error)
+	FindPendingByTokenHash(ctx context.Context, tokenHash string) (*invitedb.StaffInvite, error)
+	FindPendingInvites(ctx context.Context) ([]invitedb.StaffInvite, error)
+	MarkAccepted(ctx context.Context, id uuid.UUID) (*invitedb.StaffInvite, error)
+	RefreshToken(ctx context.Context, id uuid.UUID, tokenHash string, expiresAt time.Time) (*invitedb.StaffInvite, error)
+	Revoke(ctx context.Context, id uuid.UUID) (*invitedb.StaffInvite, error)
+}
+
+type InviteRepo struct {
+	iq *invitedb.Queries
+}
+
+func NewInviteRepo(iq *invitedb.Queries) IInviteRepo {
+	return &InviteRepo{iq: iq}
+}
+
+// Repository method for creating a new staff invite.
+func (r *InviteRepo) Create(ctx context.Context, req invitedb.CreateInviteParams) (*invitedb.StaffInvite, error) {
+	invite, err := r.iq.CreateInvite(ctx, req)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			slog.ErrorContext(ctx, "FK violation on staff_invites", "role_id", req.RoleID, "invited_by", req.InvitedBy, "error", pgErr)
+			return nil, errWrap.WrapError(errConsts.ErrRoleNotFound)
+		}
+		slog.ErrorContext(ctx, "Error creating invite", "error", err, "email", req.Email)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &invite, nil
+}
+
+// Repository method for finding an invite by ID; returns nil when it does not exist.
+func (r *InviteRepo) FindInviteById(ctx context.Context, id uuid.UUID) (*invitedb.StaffInvite, error) {
+	invite, err := r.iq.FindInviteById(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error finding invite by id", "error", err, "invite_id", id)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &invite, nil
+}
+
+// Repository method for finding the latest pending invite for an email.
+func (r *InviteRepo) FindPendingByEmail(ctx context.Context, email string) (*invitedb.StaffInvite, error) {
+	invite, err := r.iq.FindPendingInviteByEmail(ctx, email)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error finding pending invite by email", "error", err, "email", email)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &invite, nil
+}
+
+// Repository method for finding a pending invite by the hash of its token.
+func (r *InviteRepo) FindPendingByTokenHash(ctx context.Context, tokenHash string) (*invitedb.StaffInvite, error) {
+	invite, err := r.iq.FindPendingInviteByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error finding pending invite by token", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &invite, nil
+}
+
+// Repository method for listing invites that are neither accepted nor revoked.
+func (r *InviteRepo) FindPendingInvites(ctx context.Context) ([]invitedb.StaffInvite, error) {
+	invites, err := r.iq.ListPendingInvites(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing pending invites", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return invites, nil
+}
+
+// Repository method for consuming an invite. The update only matches a pending,
+// unexpired invite, so concurrent acceptances of the same link cannot both succeed.
+func (r *InviteRepo) MarkAccepted(ctx context.Context, id uuid.UUID) (*invitedb.StaffInvite, error) {
+	invite, err := r.iq.MarkInviteAccepted(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errWrap.WrapError(errConsts.ErrInviteInvalid)
+		}
+		slog.ErrorContext(ctx, "Error accepting invite", "error", err, "invite_id", id)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &invite, nil
+}
+
+// Repository method for rotating the token and expiry of a pending invite.
+func (r *InviteRepo) RefreshToken(ctx context.Context, id uuid.UUID, tokenHash string, expiresAt time.Time) (*invitedb.StaffInvite, error) {
+	invite, err := r.iq.RefreshInviteToken(ctx, id, tokenHash, expiresAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errWrap.WrapError(errConsts.ErrInviteInvalid)
+		}
+		slog.ErrorContext(ctx, "Error refreshing invite token", "error", err, "invite_id", id)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &invite, nil
+}
+
+// Repository method for revoking a pending invite.
+func (r *InviteRepo) Revoke(ctx context.Context, id uuid.UUID) (*invitedb.StaffInvite, error) {
+	invite, err := r.iq.RevokeInvite(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errWrap.WrapError(errConsts.ErrInviteInvalid)
+		}
+		slog.ErrorContext(ctx, "Error revoking invite", "error", err, "invite_id", id)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &invite, nil
+}
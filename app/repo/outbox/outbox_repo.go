@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	outboxdb "medisuite-api/pkg/db/outbox_messages"
+	"medisuite-api/pkg/logs"
+	"medisuite-api/pkg/outbox"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type IOutboxRepo interface {
+	Enqueue(ctx context.Context, msg outbox.Message) error
+	Claim(ctx context.Context, lease time.Duration, limit int32) ([]outboxdb.OutboxMessage, error)
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	Retry(ctx context.Context, id uuid.UUID, delay time.Duration, lastError string) error
+	DeadLetter(ctx context.Context, id uuid.UUID, lastError string) error
+	Requeue(ctx context.Context, id uuid.UUID) (*outboxdb.OutboxMessage, error)
+	List(ctx context.Context, status string, limit int32, offset int32) ([]outboxdb.OutboxMessage, error)
+}
+
+type OutboxRepo struct {
+	q *outboxdb.Queries
+}
+
+func NewOutboxRepo(q *outboxdb.Queries) IOutboxRepo {
+	return &OutboxRepo{q: q}
+}
+
+// Repository method for enqueueing a message, tagged with the request id of ctx.
+// Call it with the repository of the ExecTx that makes the change, so both commit together.
+func (r *OutboxRepo) Enqueue(ctx context.Context, msg outbox.Message) error {
+	payload, err := json.Marshal(msg.Payload)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error encoding outbox payload", "error", err, "topic", msg.Topic)
+		return errWrap.WrapError(errConsts.ErrInternalServerError)
+	}
+
+	if err := r.q.CreateOutboxMessage(ctx, msg.Topic, payload, logs.RequestIDFromContext(ctx)); err != nil {
+		slog.ErrorContext(ctx, "Error enqueueing outbox message", "error", err, "topic", msg.Topic)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for leasing up to limit due messages, oldest first. Leased messages are
+// not claimed again until lease has passed, so finish or reschedule them before then.
+func (r *OutboxRepo) Claim(ctx context.Context, lease time.Duration, limit int32) ([]outboxdb.OutboxMessage, error) {
+	messages, err := r.q.ClaimOutboxMessages(ctx, lease.Seconds(), limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error claiming outbox messages", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return messages, nil
+}
+
+// Repository method for marking a message delivered.
+func (r *OutboxRepo) MarkSent(ctx context.Context, id uuid.UUID) error {
+	if err := r.q.MarkOutboxMessageSent(ctx, id); err != nil {
+		slog.ErrorContext(ctx, "Error marking outbox message sent", "error", err, "message_id", id)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for rescheduling a failed message after delay.
+func (r *OutboxRepo) Retry(ctx context.Context, id uuid.UUID, delay time.Duration, lastError string) error {
+	if err := r.q.RetryOutboxMessage(ctx, delay.Seconds(), lastError, id); err != nil {
+		slog.ErrorContext(ctx, "Error rescheduling outbox message", "error", err, "message_id", id)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for dead-lettering a message that will not be retried.
+func (r *OutboxRepo) DeadLetter(ctx context.Context, id uuid.UUID, lastError string) error {
+	if err := r.q.DeadLetterOutboxMessage(ctx, id, lastError); err != nil {
+		slog.ErrorContext(ctx, "Error dead-lettering outbox message", "error", err, "message_id", id)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for putting a dead message back in the queue with fresh attempts;
+// returns nil when no dead message has the id.
+func (r *OutboxRepo) Requeue(ctx context.Context, id uuid.UUID) (*outboxdb.OutboxMessage, error) {
+	message, err := r.q.RequeueOutboxMessage(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error requeueing outbox message", "error", err, "message_id", id)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &message, nil
+}
+
+// Repository method for listing messages with a status, newest first.
+func (r *OutboxRepo) List(ctx context.Context, status string, limit int32, offset int32) ([]outboxdb.OutboxMessage, error) {
+	messages, err := r.q.ListOutboxMessages(ctx, status, limit, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing outbox messages", "error", err, "status", status)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return messages, nil
+}
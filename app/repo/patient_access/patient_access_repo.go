@@ -0,0 +1,58 @@
+package patient_access
+
+import (
+	"context"
+	"log/slog"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/pkg/audit"
+	accessdb "medisuite-api/pkg/db/patient_access_logs"
+	"medisuite-api/pkg/logs"
+
+	"github.com/google/uuid"
+)
+
+type IPatientAccessRepo interface {
+	Record(ctx context.Context, access audit.Access) error
+	ListByPatient(ctx context.Context, patientID uuid.UUID, limit int32, offset int32) ([]accessdb.ListPatientAccessLogsRow, error)
+}
+
+type PatientAccessRepo struct {
+	q *accessdb.Queries
+}
+
+func NewPatientAccessRepo(q *accessdb.Queries) IPatientAccessRepo {
+	return &PatientAccessRepo{q: q}
+}
+
+// Repository method for recording a read of patient data on behalf of the actor of ctx.
+func (r *PatientAccessRepo) Record(ctx context.Context, access audit.Access) error {
+	actor := audit.ActorFromContext(ctx)
+	err := r.q.CreatePatientAccessLog(ctx, accessdb.CreatePatientAccessLogParams{
+		PatientID:   access.PatientID,
+		ActorUserID: actor.UserID,
+		ActorRole:   actor.Role,
+		ActorIp:     actor.IP,
+		RequestID:   logs.RequestIDFromContext(ctx),
+		Resource:    access.Resource,
+		Purpose:     access.Purpose,
+		BreakGlass:  access.BreakGlass,
+		Reason:      access.Reason,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error recording patient access", "error", err, "patient_id", access.PatientID, "resource", access.Resource)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for listing the reads of a patient's data, newest first.
+func (r *PatientAccessRepo) ListByPatient(ctx context.Context, patientID uuid.UUID, limit int32, offset int32) ([]accessdb.ListPatientAccessLogsRow, error) {
+	accessLogs, err := r.q.ListPatientAccessLogs(ctx, patientID, limit, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing patient access logs", "error", err, "patient_id", patientID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return accessLogs, nil
+}
@@ -0,0 +1,32 @@
+package permissions
+
+import (
+	"context"
+	"log/slog"
+
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	permissiondb "medisuite-api/pkg/db/permissions"
+)
+
+type IPermissionRepo interface {
+	FindActivePermissions(ctx context.Context) ([]permissiondb.GetActivePermissionsRow, error)
+}
+
+type PermissionRepo struct {
+	pq *permissiondb.Queries
+}
+
+func NewPermissionRepo(pq *permissiondb.Queries) IPermissionRepo {
+	return &PermissionRepo{pq: pq}
+}
+
+// Repository method for finding every active module/action permission
+func (r *PermissionRepo) FindActivePermissions(ctx context.Context) ([]permissiondb.GetActivePermissionsRow, error) {
+	permissions, err := r.pq.GetActivePermissions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting active permissions", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return permissions, nil
+}
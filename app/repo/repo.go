@@ -1,11 +1,22 @@
 package repo
 
 import (
+	"context"
+	"time"
+
+	auditRepo "medisuite-api/app/repo/audit"
+	careTeamRepo "medisuite-api/app/repo/care_team"
 	categoryRepo "medisuite-api/app/repo/categories"
+	idempotencyRepo "medisuite-api/app/repo/idempotency"
+	inviteRepo "medisuite-api/app/repo/invites"
+	outboxRepo "medisuite-api/app/repo/outbox"
+	patientAccessRepo "medisuite-api/app/repo/patient_access"
+	permissionRepo "medisuite-api/app/repo/permissions"
 	rolePermissionRepo "medisuite-api/app/repo/role_permissions"
 	roleRepo "medisuite-api/app/repo/roles"
 	treatmentRepo "medisuite-api/app/repo/treatments"
 	userRepo "medisuite-api/app/repo/users"
+	"medisuite-api/pkg/fieldcrypt"
 )
 
 type IRepo interface {
@@ -14,37 +25,127 @@ type IRepo interface {
 	RolePermissionRepo() rolePermissionRepo.IRolePermissionRepo
 	CategoryRepo() categoryRepo.ICategoryRepo
 	TreatmentRepo() treatmentRepo.ITreatmentRepo
+	PermissionRepo() permissionRepo.IPermissionRepo
+	InviteRepo() inviteRepo.IInviteRepo
+	IdempotencyRepo() idempotencyRepo.IIdempotencyRepo
+	// AuditRepo records audit events; use the tx repository of ExecTx so they commit with the change.
+	AuditRepo() auditRepo.IAuditRepo
+	// PatientAccessRepo records reads of patient data.
+	PatientAccessRepo() patientAccessRepo.IPatientAccessRepo
+	// CareTeamRepo manages the staff assigned to each patient.
+	CareTeamRepo() careTeamRepo.ICareTeamRepo
+	// OutboxRepo enqueues side effects; use the tx repository of ExecTx so they commit with the change.
+	OutboxRepo() outboxRepo.IOutboxRepo
+	// ExecTx runs fn with a repository whose queries share one database transaction.
+	ExecTx(ctx context.Context, fn func(tx IRepo) error) error
+	// Ping checks that the database connection is alive.
+	Ping(ctx context.Context) error
+	// MigrationVersion returns the schema version recorded by goose.
+	MigrationVersion(ctx context.Context) (int64, error)
 }
 
 type Repo struct {
-	store Store
+	store           Store
+	tx              *Queries // set on repositories handed out by ExecTx
+	roleCache       *roleRepo.RoleCache
+	permissionCache *rolePermissionRepo.RolePermissionCache
+	userRoleCache   *userRepo.UserRoleCache
+	crypt           *fieldcrypt.Keyring
+}
+
+// NewRepo creates the repository; cacheTTL is how long roles, role permissions and
+// the role of each user stay cached in-process, and 0 disables caching. crypt encrypts sensitive columns.
+func NewRepo(store Store, cacheTTL time.Duration, crypt *fieldcrypt.Keyring) IRepo {
+	return &Repo{
+		store:           store,
+		roleCache:       roleRepo.NewRoleCache(cacheTTL),
+		permissionCache: rolePermissionRepo.NewRolePermissionCache(cacheTTL),
+		userRoleCache:   userRepo.NewUserRoleCache(cacheTTL),
+		crypt:           crypt,
+	}
+}
+
+// ExecTx runs fn inside a single transaction; caches are shared with the parent repository.
+func (r *Repo) ExecTx(ctx context.Context, fn func(tx IRepo) error) error {
+	return r.store.ExecTx(ctx, func(q *Queries) error {
+		txRepo := *r
+		txRepo.tx = q
+		return fn(&txRepo)
+	})
+}
+
+func (r *Repo) Ping(ctx context.Context) error {
+	return r.store.Ping(ctx)
+}
+
+func (r *Repo) MigrationVersion(ctx context.Context) (int64, error) {
+	return r.store.MigrationVersion(ctx)
 }
 
-func NewRepo(store Store) IRepo {
-	return &Repo{store: store}
+// queries returns the transaction-bound queries inside ExecTx, otherwise the store's.
+func (r *Repo) queries() *Queries {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.store.Queries()
 }
 
 func (r *Repo) UserRepo() userRepo.IUserRepo {
-	q := r.store.Queries()
-	return userRepo.NewUserRepo(q.Users, q.Sessions)
+	q := r.queries()
+	return userRepo.NewUserRepo(q.Users, q.Sessions, r.crypt, r.userRoleCache)
 }
 
 func (r *Repo) RoleRepo() roleRepo.IRoleRepo {
-	q := r.store.Queries()
-	return roleRepo.NewRoleRepo(q.Roles)
+	q := r.queries()
+	return roleRepo.NewRoleRepo(q.Roles, r.roleCache)
 }
 
 func (r *Repo) RolePermissionRepo() rolePermissionRepo.IRolePermissionRepo {
-	q := r.store.Queries()
-	return rolePermissionRepo.NewRolePermissionRepo(q.RolePermissions)
+	q := r.queries()
+	return rolePermissionRepo.NewRolePermissionRepo(q.RolePermissions, r.permissionCache)
 }
 
 func (r *Repo) CategoryRepo() categoryRepo.ICategoryRepo {
-	q := r.store.Queries()
+	q := r.queries()
 	return categoryRepo.NewCategoryRepo(q.Categories)
 }
 
 func (r *Repo) TreatmentRepo() treatmentRepo.ITreatmentRepo {
-	q := r.store.Queries()
+	q := r.queries()
 	return treatmentRepo.NewTreatmentRepo(q.Treatments)
 }
+
+func (r *Repo) PermissionRepo() permissionRepo.IPermissionRepo {
+	q := r.queries()
+	return permissionRepo.NewPermissionRepo(q.Permissions)
+}
+
+func (r *Repo) InviteRepo() inviteRepo.IInviteRepo {
+	q := r.queries()
+	return inviteRepo.NewInviteRepo(q.Invites)
+}
+
+func (r *Repo) IdempotencyRepo() idempotencyRepo.IIdempotencyRepo {
+	q := r.queries()
+	return idempotencyRepo.NewIdempotencyRepo(q.Idempotency)
+}
+
+func (r *Repo) AuditRepo() auditRepo.IAuditRepo {
+	q := r.queries()
+	return auditRepo.NewAuditRepo(q.Audit)
+}
+
+func (r *Repo) PatientAccessRepo() patientAccessRepo.IPatientAccessRepo {
+	q := r.queries()
+	return patientAccessRepo.NewPatientAccessRepo(q.PatientAccess)
+}
+
+func (r *Repo) OutboxRepo() outboxRepo.IOutboxRepo {
+	q := r.queries()
+	return outboxRepo.NewOutboxRepo(q.Outbox)
+}
+
+func (r *Repo) CareTeamRepo() careTeamRepo.ICareTeamRepo {
+	q := r.queries()
+	return careTeamRepo.NewCareTeamRepo(q.CareTeam)
+}
@@ -2,10 +2,15 @@ package role_permissions
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"log/slog"
+	"sort"
+	"time"
 
 	errWrap "medisuite-api/common/errors"
 	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/pkg/cache"
 	rolepermissiondb "medisuite-api/pkg/db/role_permissions"
 
 	"github.com/google/uuid"
@@ -13,22 +18,134 @@ import (
 
 type IRolePermissionRepo interface {
 	GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]rolepermissiondb.GetRolePermissionsRow, error)
+	GetRolePermissionsForVersion(ctx context.Context, roleID uuid.UUID, version string) ([]rolepermissiondb.GetRolePermissionsRow, error)
+	GetPermissionsVersion(ctx context.Context, roleID uuid.UUID) (string, error)
+	InvalidateRolePermissions(roleID uuid.UUID)
+	InvalidateAllRolePermissions()
+}
+
+// RolePermissionEntry is a cached permission set for a single role
+type RolePermissionEntry struct {
+	Permissions []rolepermissiondb.GetRolePermissionsRow
+	Version     string
+}
+
+// RolePermissionCache keeps resolved role permissions in memory, keyed by role ID.
+// It is shared by every RolePermissionRepo created from the same Repo.
+type RolePermissionCache = cache.TTLCache[uuid.UUID, RolePermissionEntry]
+
+// NewRolePermissionCache creates a permission cache whose entries expire after ttl
+func NewRolePermissionCache(ttl time.Duration) *RolePermissionCache {
+	return cache.NewTTLCache[uuid.UUID, RolePermissionEntry](ttl)
 }
 
 type RolePermissionRepo struct {
-	rpq *rolepermissiondb.Queries
+	rpq   *rolepermissiondb.Queries
+	cache *RolePermissionCache
 }
 
-func NewRolePermissionRepo(rpq *rolepermissiondb.Queries) IRolePermissionRepo {
-	return &RolePermissionRepo{rpq: rpq}
+func NewRolePermissionRepo(rpq *rolepermissiondb.Queries, cache *RolePermissionCache) IRolePermissionRepo {
+	return &RolePermissionRepo{rpq: rpq, cache: cache}
 }
 
 // Repository method for getting all permissions for a specific role
 func (r *RolePermissionRepo) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]rolepermissiondb.GetRolePermissionsRow, error) {
-	permissions, err := r.rpq.GetRolePermissions(ctx, roleID)
+	entry, err := r.resolve(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Permissions, nil
+}
+
+// Repository method for getting the permissions of a role for a request whose access token carries
+// version. A matching version is served from the cache. On a mismatch the role is reloaded, since it
+// may have changed on another instance; a token that still does not match was issued before the
+// permissions or the user's role changed and is rejected with ErrTokenStale, so the client refreshes it.
+func (r *RolePermissionRepo) GetRolePermissionsForVersion(ctx context.Context, roleID uuid.UUID, version string) ([]rolepermissiondb.GetRolePermissionsRow, error) {
+	entry, err := r.resolve(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" || version == entry.Version {
+		return entry.Permissions, nil
+	}
+
+	entry, err = r.load(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if version != entry.Version {
+		slog.InfoContext(ctx, "Access token carries stale permissions version", "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrTokenStale)
+	}
+	return entry.Permissions, nil
+}
+
+// Repository method for getting the current permissions version of a role
+func (r *RolePermissionRepo) GetPermissionsVersion(ctx context.Context, roleID uuid.UUID) (string, error) {
+	entry, err := r.resolve(ctx, roleID)
+	if err != nil {
+		return "", err
+	}
+	return entry.Version, nil
+}
+
+// InvalidateRolePermissions drops the cached permissions of a role.
+// Roles inheriting from it keep their cached entries, so prefer InvalidateAllRolePermissions
+// when the changed role may be inherited.
+func (r *RolePermissionRepo) InvalidateRolePermissions(roleID uuid.UUID) {
+	r.cache.Delete(roleID)
+}
+
+// InvalidateAllRolePermissions drops every cached role permission set.
+// Call it whenever permissions are granted or revoked, or role levels change.
+func (r *RolePermissionRepo) InvalidateAllRolePermissions() {
+	r.cache.Purge()
+}
+
+// resolve returns the role permissions from cache, loading them from the database on a miss
+func (r *RolePermissionRepo) resolve(ctx context.Context, roleID uuid.UUID) (RolePermissionEntry, error) {
+	if cached, ok := r.cache.Get(roleID); ok {
+		return cached, nil
+	}
+	return r.load(ctx, roleID)
+}
+
+// load reads the role permissions from the database and caches them
+func (r *RolePermissionRepo) load(ctx context.Context, roleID uuid.UUID) (RolePermissionEntry, error) {
+	// effective permissions include those inherited from lower-level roles
+	rows, err := r.rpq.GetEffectiveRolePermissions(ctx, roleID)
 	if err != nil {
-		slog.Error("Error getting role permissions", "error", err, "role_id", roleID)
-		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+		slog.ErrorContext(ctx, "Error getting role permissions", "error", err, "role_id", roleID)
+		return RolePermissionEntry{}, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+
+	permissions := make([]rolepermissiondb.GetRolePermissionsRow, len(rows))
+	for i, row := range rows {
+		permissions[i] = rolepermissiondb.GetRolePermissionsRow(row)
+	}
+
+	entry := RolePermissionEntry{
+		Permissions: permissions,
+		Version:     PermissionsVersion(permissions),
+	}
+	r.cache.Set(roleID, entry)
+	return entry, nil
+}
+
+// PermissionsVersion returns a short, order-independent fingerprint of a permission set.
+// It is embedded in access tokens so requests made with stale claims can be told apart.
+func PermissionsVersion(permissions []rolepermissiondb.GetRolePermissionsRow) string {
+	keys := make([]string, len(permissions))
+	for i, perm := range permissions {
+		keys[i] = perm.Module + ":" + perm.Action
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{'\n'})
 	}
-	return permissions, nil
+	return hex.EncodeToString(h.Sum(nil)[:8])
 }
@@ -0,0 +1,190 @@
+package role_permissions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	errConsts "medisuite-api/constants/errors"
+	rolepermissiondb "medisuite-api/pkg/db/role_permissions"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// countingDB answers every query with the same permission rows and counts the round trips
+type countingDB struct {
+	queries int
+	rows    [][]string
+}
+
+func (db *countingDB) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	db.queries++
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *countingDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	db.queries++
+	return &staticRows{rows: db.rows, next: -1}, nil
+}
+
+func (db *countingDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	db.queries++
+	return &staticRows{rows: db.rows, next: 0}
+}
+
+// staticRows iterates over rows of string columns
+type staticRows struct {
+	rows [][]string
+	next int
+}
+
+func (r *staticRows) Close()                                       {}
+func (r *staticRows) Err() error                                   { return nil }
+func (r *staticRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *staticRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *staticRows) Values() ([]any, error)                       { return nil, nil }
+func (r *staticRows) RawValues() [][]byte                          { return nil }
+func (r *staticRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *staticRows) Next() bool {
+	r.next++
+	return r.next < len(r.rows)
+}
+
+func (r *staticRows) Scan(dest ...any) error {
+	if r.next >= len(r.rows) {
+		return pgx.ErrNoRows
+	}
+	for i, d := range dest {
+		*d.(*string) = r.rows[r.next][i]
+	}
+	return nil
+}
+
+func newTestRepo(ttl time.Duration) (IRolePermissionRepo, *countingDB) {
+	db := &countingDB{rows: [][]string{
+		{"patient", "read", "Read patients"},
+		{"patient", "update", "Update patients"},
+		{"treatment", "read", "Read treatments"},
+	}}
+	return NewRolePermissionRepo(rolepermissiondb.New(db), NewRolePermissionCache(ttl)), db
+}
+
+func TestGetRolePermissionsForVersionServesCurrentTokensFromCache(t *testing.T) {
+	ctx := context.Background()
+	repo, db := newTestRepo(time.Minute)
+	roleID := uuid.New()
+
+	current, err := repo.GetPermissionsVersion(ctx, roleID)
+	if err != nil {
+		t.Fatalf("GetPermissionsVersion: %v", err)
+	}
+
+	for _, version := range []string{current, "", current} {
+		permissions, err := repo.GetRolePermissionsForVersion(ctx, roleID, version)
+		if err != nil {
+			t.Fatalf("GetRolePermissionsForVersion(%q): %v", version, err)
+		}
+		if len(permissions) != 3 {
+			t.Fatalf("GetRolePermissionsForVersion(%q) returned %d permissions, want 3", version, len(permissions))
+		}
+	}
+	if db.queries != 1 {
+		t.Errorf("queries = %d, want 1", db.queries)
+	}
+}
+
+func TestGetRolePermissionsForVersionRejectsStaleTokens(t *testing.T) {
+	ctx := context.Background()
+	repo, db := newTestRepo(time.Minute)
+	roleID := uuid.New()
+
+	if _, err := repo.GetPermissionsVersion(ctx, roleID); err != nil {
+		t.Fatalf("GetPermissionsVersion: %v", err)
+	}
+
+	_, err := repo.GetRolePermissionsForVersion(ctx, roleID, "stale")
+	if !errors.Is(err, errConsts.ErrTokenStale) {
+		t.Fatalf("err = %v, want ErrTokenStale", err)
+	}
+	// the mismatch reloads the role once before rejecting the token
+	if db.queries != 2 {
+		t.Errorf("queries = %d, want 2", db.queries)
+	}
+}
+
+func TestGetRolePermissionsForVersionReloadsWhenTheTokenIsNewer(t *testing.T) {
+	ctx := context.Background()
+	repo, db := newTestRepo(time.Minute)
+	roleID := uuid.New()
+
+	if _, err := repo.GetPermissionsVersion(ctx, roleID); err != nil {
+		t.Fatalf("GetPermissionsVersion: %v", err)
+	}
+
+	// another instance granted a permission and issued a token for the new set
+	db.rows = append(db.rows, []string{"treatment", "create", "Create treatments"})
+	newer := PermissionsVersion([]rolepermissiondb.GetRolePermissionsRow{
+		{Module: "patient", Action: "read"},
+		{Module: "patient", Action: "update"},
+		{Module: "treatment", Action: "read"},
+		{Module: "treatment", Action: "create"},
+	})
+
+	permissions, err := repo.GetRolePermissionsForVersion(ctx, roleID, newer)
+	if err != nil {
+		t.Fatalf("GetRolePermissionsForVersion: %v", err)
+	}
+	if len(permissions) != 4 {
+		t.Fatalf("returned %d permissions, want 4", len(permissions))
+	}
+
+	// the reloaded set is cached for the following requests
+	if _, err := repo.GetRolePermissionsForVersion(ctx, roleID, newer); err != nil {
+		t.Fatalf("GetRolePermissionsForVersion: %v", err)
+	}
+	if db.queries != 2 {
+		t.Errorf("queries = %d, want 2", db.queries)
+	}
+}
+
+// BenchmarkGetRolePermissionsForVersion reports the database round trips per authorized request.
+// Current tokens are served from the cache; a stale token costs one reload before it is rejected.
+func BenchmarkGetRolePermissionsForVersion(b *testing.B) {
+	cases := []struct {
+		name    string
+		ttl     time.Duration
+		version func(current string) string
+	}{
+		{"uncached", 0, func(current string) string { return current }},
+		{"current_token", time.Minute, func(current string) string { return current }},
+		{"stale_token", time.Minute, func(string) string { return "stale" }},
+	}
+
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			ctx := context.Background()
+			repo, db := newTestRepo(tc.ttl)
+			roleID := uuid.New()
+
+			current, err := repo.GetPermissionsVersion(ctx, roleID)
+			if err != nil {
+				b.Fatalf("GetPermissionsVersion: %v", err)
+			}
+			version := tc.version(current)
+
+			db.queries = 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := repo.GetRolePermissionsForVersion(ctx, roleID, version)
+				if err != nil && !errors.Is(err, errConsts.ErrTokenStale) {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(db.queries)/float64(b.N), "queries/op")
+		})
+	}
+}
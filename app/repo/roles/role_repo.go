@@ -3,51 +3,152 @@ package roles
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	errWrap "medisuite-api/common/errors"
 	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/pkg/cache"
 	roledb "medisuite-api/pkg/db/roles"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type IRoleRepo interface {
 	FindRoleById(ctx context.Context, id uuid.UUID) (*roledb.Role, error)
+	FindRoleByCode(ctx context.Context, code string) (*roledb.Role, error)
+	FindAllRoles(ctx context.Context) ([]roledb.Role, error)
+	CreateRole(ctx context.Context, req roledb.CreateRoleParams) (*roledb.Role, error)
+	UpdateRole(ctx context.Context, req roledb.UpdateRoleParams) (*roledb.Role, error)
+	InvalidateRoles()
+}
+
+// RoleCache keeps roles in memory, keyed by role ID and by role code.
+// It is shared by every RoleRepo created from the same Repo.
+type RoleCache struct {
+	byID   *cache.TTLCache[uuid.UUID, roledb.Role]
+	byCode *cache.TTLCache[string, roledb.Role]
+}
+
+// NewRoleCache creates a role cache whose entries expire after ttl
+func NewRoleCache(ttl time.Duration) *RoleCache {
+	return &RoleCache{
+		byID:   cache.NewTTLCache[uuid.UUID, roledb.Role](ttl),
+		byCode: cache.NewTTLCache[string, roledb.Role](ttl),
+	}
+}
+
+// set stores a role under both of its keys
+func (c *RoleCache) set(role roledb.Role) {
+	c.byID.Set(role.ID, role)
+	c.byCode.Set(role.Code, role)
 }
 
 type RoleRepo struct {
-	rq *roledb.Queries
+	rq    *roledb.Queries
+	cache *RoleCache
 }
 
-func NewRoleRepo(rq *roledb.Queries) IRoleRepo {
-	return &RoleRepo{rq: rq}
+func NewRoleRepo(rq *roledb.Queries, cache *RoleCache) IRoleRepo {
+	return &RoleRepo{rq: rq, cache: cache}
 }
 
 func (r *RoleRepo) FindRoleById(ctx context.Context, roleID uuid.UUID) (*roledb.Role, error) {
-	slog.Info("FindRoleById called", "role_id", roleID)
+	if cached, ok := r.cache.byID.Get(roleID); ok {
+		return &cached, nil
+	}
+
+	slog.InfoContext(ctx, "FindRoleById called", "role_id", roleID)
 
 	row, err := r.rq.GetRoleByID(ctx, roleID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			slog.Warn("Role not found in DB", "role_id", roleID)
+			slog.WarnContext(ctx, "Role not found in DB", "role_id", roleID)
 			return nil, nil
 		}
-		slog.Error("GetRoleByID error", "role_id", roleID, "err", err)
+		slog.ErrorContext(ctx, "GetRoleByID error", "role_id", roleID, "err", err)
 		return nil, errWrap.WrapError(errConsts.ErrRoleNotFound)
 	}
 
 	role := &roledb.Role{
-		ID:              row.ID,
-		Name:            row.Name,
-		Code:            row.Code,
-		Level:           row.Level,
-		Description:     row.Description,
-		CanSelfRegister: row.CanSelfRegister,
-		CreatedAt:       row.CreatedAt,
-		UpdatedAt:       row.UpdatedAt,
+		ID:                 row.ID,
+		Name:               row.Name,
+		Code:               row.Code,
+		Level:              row.Level,
+		Description:        row.Description,
+		CanSelfRegister:    row.CanSelfRegister,
+		InheritPermissions: row.InheritPermissions,
+		CreatedAt:          row.CreatedAt,
+		UpdatedAt:          row.UpdatedAt,
 	}
 
-	slog.Info("Role found", "role_id", role.ID, "code", role.Code, "can_self_register", role.CanSelfRegister)
+	slog.InfoContext(ctx, "Role found", "role_id", role.ID, "code", role.Code, "can_self_register", role.CanSelfRegister)
+	r.cache.set(*role)
 	return role, nil
 }
+
+// Repository method for finding a role by its code (e.g. "admin").
+func (r *RoleRepo) FindRoleByCode(ctx context.Context, code string) (*roledb.Role, error) {
+	if cached, ok := r.cache.byCode.Get(code); ok {
+		return &cached, nil
+	}
+
+	role, err := r.rq.GetRoleByCode(ctx, code)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			slog.WarnContext(ctx, "Role not found in DB", "code", code)
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "GetRoleByCode error", "code", code, "err", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+
+	r.cache.set(role)
+	return &role, nil
+}
+
+// Repository method for finding all roles, highest level first.
+func (r *RoleRepo) FindAllRoles(ctx context.Context) ([]roledb.Role, error) {
+	roles, err := r.rq.GetAllRoles(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "GetAllRoles error", "err", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return roles, nil
+}
+
+// Repository method for creating a new role.
+func (r *RoleRepo) CreateRole(ctx context.Context, req roledb.CreateRoleParams) (*roledb.Role, error) {
+	role, err := r.rq.CreateRole(ctx, req)
+	if err != nil {
+		// unique violation on roles.code
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			slog.ErrorContext(ctx, "Unique violation on roles.code", "code", req.Code, "error", pgErr)
+			return nil, errWrap.WrapError(errConsts.ErrRoleAlreadyExists)
+		}
+		slog.ErrorContext(ctx, "CreateRole error", "code", req.Code, "err", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &role, nil
+}
+
+// Repository method for updating a role.
+func (r *RoleRepo) UpdateRole(ctx context.Context, req roledb.UpdateRoleParams) (*roledb.Role, error) {
+	role, err := r.rq.UpdateRole(ctx, req)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errWrap.WrapError(errConsts.ErrRoleNotFound)
+		}
+		slog.ErrorContext(ctx, "UpdateRole error", "role_id", req.ID, "err", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &role, nil
+}
+
+// InvalidateRoles drops every cached role so the next lookups read from the database.
+// Call it after the transaction that changed a role commits, never inside it.
+func (r *RoleRepo) InvalidateRoles() {
+	r.cache.byID.Purge()
+	r.cache.byCode.Purge()
+}
@@ -14,6 +14,7 @@ import (
 type ITreatmentRepo interface {
 	Create(ctx context.Context, req treatmentdb.CreateTreatmentParams) (*treatmentdb.Treatment, error)
 	Update(ctx context.Context, req treatmentdb.UpdateTreatmentParams) (*treatmentdb.Treatment, error)
+	Patch(ctx context.Context, req treatmentdb.PatchTreatmentParams) (*treatmentdb.Treatment, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindAll(ctx context.Context) ([]treatmentdb.Treatment, error)
 	FindById(ctx context.Context, id uuid.UUID) (*treatmentdb.Treatment, error)
@@ -37,10 +38,27 @@ func (r *TreatmentRepo) Create(ctx context.Context, req treatmentdb.CreateTreatm
 	return &treatment, nil
 }
 
-// Repository method for updating a service.
+// Repository method for updating a service; returns nil when req.Version is no longer
+// the current version, i.e. another update won.
 func (r *TreatmentRepo) Update(ctx context.Context, req treatmentdb.UpdateTreatmentParams) (*treatmentdb.Treatment, error) {
 	treatment, err := r.tq.UpdateTreatment(ctx, req)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return &treatment, nil
+}
+
+// Repository method for partially updating a service; nil fields of req are left unchanged.
+// Returns nil when req.Version is no longer the current version.
+func (r *TreatmentRepo) Patch(ctx context.Context, req treatmentdb.PatchTreatmentParams) (*treatmentdb.Treatment, error) {
+	treatment, err := r.tq.PatchTreatment(ctx, req)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	return &treatment, nil
@@ -3,12 +3,15 @@ package users
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"time"
 
 	errWrap "medisuite-api/common/errors"
 	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/pkg/cache"
 	sessiondb "medisuite-api/pkg/db/user_sessions"
 	userdb "medisuite-api/pkg/db/users"
+	"medisuite-api/pkg/fieldcrypt"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -19,28 +22,59 @@ type IUserRepo interface {
 	Create(ctx context.Context, req userdb.CreateUserParams) (*userdb.CreateUserRow, error)
 	FindUserByEmail(ctx context.Context, email string) (*userdb.FindUserByEmailRow, error)
 	FindUserById(ctx context.Context, id uuid.UUID) (*userdb.FindUserByIdRow, error)
+	// FindUserRoleID returns the current role of a user, served from the in-process cache when possible
+	FindUserRoleID(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	// InvalidateUserRole drops the cached role of a user; call it after the user's role changes
+	InvalidateUserRole(id uuid.UUID)
+	FindUserByPhone(ctx context.Context, phoneNumber string) (*userdb.FindUserByPhoneBidxRow, error)
 	FindAllUsers(ctx context.Context) ([]userdb.FindAllUsersRow, error)
 	UpdateUser(ctx context.Context, req userdb.UpdateUserParams) (*userdb.UpdateUserRow, error)
+	UpdateUserRole(ctx context.Context, id uuid.UUID, roleID uuid.UUID) (*userdb.UpdateUserRoleRow, error)
+	UpdateUserLocale(ctx context.Context, id uuid.UUID, locale string) error
+	PatchUser(ctx context.Context, id uuid.UUID, name *string, phoneNumber *string) (*userdb.PatchUserRow, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) (*userdb.User, error)
 	FindUserByVerify(ctx context.Context, token string) (*userdb.FindUserByVerifyCodeRow, error)
 	FindSessionByUserId(ctx context.Context, userID uuid.UUID) (*sessiondb.UserSession, error)
 	SaveSession(ctx context.Context, req sessiondb.CreateSessionParams) (*sessiondb.UserSession, error)
 	DeleteSession(ctx context.Context, token string) error
 	FindSessions(ctx context.Context, token string) (*sessiondb.UserSession, error)
+	// ReencryptPhoneNumbers moves up to limit phone numbers after afterID onto the active master key,
+	// encrypting legacy plaintext and filling missing blind indexes
+	ReencryptPhoneNumbers(ctx context.Context, afterID uuid.UUID, limit int32) (ReencryptResult, error)
+}
+
+// phoneNumberField is users.phone_number, stored encrypted with a blind index for lookups by phone.
+// Numbers are indexed in their local 08... form, so +62, 62 and 0 prefixes find the same user.
+var phoneNumberField = fieldcrypt.Field{Table: "users", Column: "phone_number", Normalize: normalizePhoneNumber}
+
+// UserRoleCache keeps the role ID of each user in memory, keyed by user ID.
+// It is shared by every UserRepo created from the same Repo.
+type UserRoleCache = cache.TTLCache[uuid.UUID, uuid.UUID]
+
+// NewUserRoleCache creates a user role cache whose entries expire after ttl
+func NewUserRoleCache(ttl time.Duration) *UserRoleCache {
+	return cache.NewTTLCache[uuid.UUID, uuid.UUID](ttl)
 }
 
 type UserRepo struct {
-	uq *userdb.Queries
-	sq *sessiondb.Queries
+	uq    *userdb.Queries
+	sq    *sessiondb.Queries
+	crypt *fieldcrypt.Keyring
+	roles *UserRoleCache
 }
 
-func NewUserRepo(uq *userdb.Queries, sq *sessiondb.Queries) IUserRepo {
-	return &UserRepo{uq: uq, sq: sq}
+// NewUserRepo creates the user repository; sensitive columns are encrypted and decrypted with crypt
+func NewUserRepo(uq *userdb.Queries, sq *sessiondb.Queries, crypt *fieldcrypt.Keyring, roles *UserRoleCache) IUserRepo {
+	return &UserRepo{uq: uq, sq: sq, crypt: crypt, roles: roles}
 }
 
 // Repository method for creating a new user.
 func (r *UserRepo) Create(ctx context.Context, req userdb.CreateUserParams) (*userdb.CreateUserRow, error) {
-	// Set verification code and expiry (24 hours from now)
+	// the verification code and expiry are set by the caller; only invited staff are created
+	// verified, since accepting the invite proves the email
+	if err := r.encrypt(ctx, &req); err != nil {
+		return nil, err
+	}
 
 	user, err := r.uq.CreateUser(ctx, userdb.CreateUserParams{
 		Name:            req.Name,
@@ -48,26 +82,31 @@ func (r *UserRepo) Create(ctx context.Context, req userdb.CreateUserParams) (*us
 		Password:        req.Password,
 		PhoneNumber:     req.PhoneNumber,
 		RoleID:          req.RoleID,
-		IsVerified:      false,
+		IsVerified:      req.IsVerified,
 		VerifyCode:      req.VerifyCode,
 		VerifyExpiresAt: req.VerifyExpiresAt,
+		Locale:          req.Locale,
+		PhoneNumberBidx: req.PhoneNumberBidx,
 	})
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			// foreign key violation
 			if pgErr.Code == "23503" { // foreign_key_violation
-				slog.Error("FK violation on users.role_id", "role_id", req.RoleID, "error", pgErr)
+				slog.ErrorContext(ctx, "FK violation on users.role_id", "role_id", req.RoleID, "error", pgErr)
 				return nil, errWrap.WrapError(errConsts.ErrRoleNotFound)
 			}
 			// unique violation email
 			if pgErr.Code == "23505" {
-				slog.Error("Unique violation on users.email", "email", req.Email, "error", pgErr)
+				slog.ErrorContext(ctx, "Unique violation on users.email", "email", req.Email, "error", pgErr)
 				return nil, errWrap.WrapError(errConsts.ErrUserEmailAlreadyExists)
 			}
 		}
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
@@ -78,12 +117,15 @@ func (r *UserRepo) FindUserByEmail(ctx context.Context, email string) (*userdb.F
 	if err != nil {
 		// no rows means user does not exist
 		if err == pgx.ErrNoRows {
-			slog.Debug("User not found by email", "email", email)
+			slog.DebugContext(ctx, "User not found by email", "email", email)
 			return nil, nil
 		}
-		slog.Error("Error finding user by email", "error", err, "email", email)
+		slog.ErrorContext(ctx, "Error finding user by email", "error", err, "email", email)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
@@ -92,8 +134,60 @@ func (r *UserRepo) FindUserById(ctx context.Context, id uuid.UUID) (*userdb.Find
 	// find user by id in database
 	user, err := r.uq.FindUserById(ctx, id)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "FindUserById error", "user_id", id, "err", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Repository method for finding the current role of a user; deleted users are not found.
+func (r *UserRepo) FindUserRoleID(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	if roleID, ok := r.roles.Get(id); ok {
+		return roleID, nil
+	}
+
+	roleID, err := r.uq.FindUserRoleID(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, errWrap.WrapError(errConsts.ErrUserNotFound)
+		}
+		slog.ErrorContext(ctx, "Error finding user role", "error", err, "user_id", id)
+		return uuid.Nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	r.roles.Set(id, roleID)
+	return roleID, nil
+}
+
+// InvalidateUserRole drops the cached role of a user so the next lookup reads from the database.
+func (r *UserRepo) InvalidateUserRole(id uuid.UUID) {
+	r.roles.Delete(id)
+}
+
+// Repository method for finding a user by phone number through its blind index.
+func (r *UserRepo) FindUserByPhone(ctx context.Context, phoneNumber string) (*userdb.FindUserByPhoneBidxRow, error) {
+	bidx := r.crypt.BlindIndex(phoneNumberField, phoneNumber)
+	if bidx == "" {
+		return nil, nil
+	}
+
+	user, err := r.uq.FindUserByPhoneBidx(ctx, bidx)
+	if err != nil {
+		// no rows means user does not exist
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error finding user by phone", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
@@ -104,16 +198,74 @@ func (r *UserRepo) FindAllUsers(ctx context.Context) ([]userdb.FindAllUsersRow,
 	if err != nil {
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
+	for i := range users {
+		if err := r.decrypt(ctx, &users[i]); err != nil {
+			return nil, err
+		}
+	}
 	return users, nil
 }
 
 // Repository method for updating a user.
 func (r *UserRepo) UpdateUser(ctx context.Context, req userdb.UpdateUserParams) (*userdb.UpdateUserRow, error) {
+	if err := r.encrypt(ctx, &req); err != nil {
+		return nil, err
+	}
+
 	// update user in database
 	user, err := r.uq.UpdateUser(ctx, req)
 	if err != nil {
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Repository method for assigning a new role to a user.
+func (r *UserRepo) UpdateUserRole(ctx context.Context, id uuid.UUID, roleID uuid.UUID) (*userdb.UpdateUserRoleRow, error) {
+	user, err := r.uq.UpdateUserRole(ctx, id, roleID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
+		}
+		slog.ErrorContext(ctx, "Error updating user role", "error", err, "user_id", id, "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Repository method for storing a user's preferred locale; an empty locale clears it.
+func (r *UserRepo) UpdateUserLocale(ctx context.Context, id uuid.UUID, locale string) error {
+	if err := r.uq.UpdateUserLocale(ctx, id, locale); err != nil {
+		slog.ErrorContext(ctx, "Error updating user locale", "error", err, "user_id", id)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	return nil
+}
+
+// Repository method for partially updating a user's profile; nil fields are left unchanged.
+func (r *UserRepo) PatchUser(ctx context.Context, id uuid.UUID, name *string, phoneNumber *string) (*userdb.PatchUserRow, error) {
+	params := userdb.PatchUserParams{Name: name, PhoneNumber: phoneNumber, ID: id}
+	if err := r.encrypt(ctx, &params); err != nil {
+		return nil, err
+	}
+
+	user, err := r.uq.PatchUser(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
+		}
+		slog.ErrorContext(ctx, "Error patching user", "error", err, "user_id", id)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
@@ -124,6 +276,10 @@ func (r *UserRepo) DeleteUser(ctx context.Context, id uuid.UUID) (*userdb.User,
 	if err != nil {
 		return nil, errWrap.WrapError(errConsts.ErrUserDeleted)
 	}
+	r.roles.Delete(id)
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
@@ -150,6 +306,9 @@ func (r *UserRepo) FindUserByVerify(ctx context.Context, verify_token string) (*
 	}
 
 	// verification code is still valid
+	if err := r.decrypt(ctx, &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
@@ -176,7 +335,7 @@ func (r *UserRepo) SaveSession(ctx context.Context, req sessiondb.CreateSessionP
 	// Create session in database
 	session, err := r.sq.CreateSession(ctx, req)
 	if err != nil {
-		slog.Error("Failed to create user session", "error", err, "user_id", req.UserID, "ref_token", req.RefToken)
+		slog.ErrorContext(ctx, "Failed to create user session", "error", err, "user_id", req.UserID)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	return &session, nil
@@ -190,3 +349,105 @@ func (r *UserRepo) DeleteSession(ctx context.Context, token string) error {
 	}
 	return nil
 }
+
+// ReencryptResult counts the rows a ReencryptPhoneNumbers batch looked at, rewrote, and skipped
+// because their phone number changed after it was read
+type ReencryptResult struct {
+	Scanned int
+	Updated int
+	Skipped int
+	// LastID is the id to continue after; uuid.Nil once every row has been scanned
+	LastID uuid.UUID
+}
+
+// Repository method for moving a batch of phone numbers onto the active master key.
+// Encrypted values only have their data key re-wrapped; plaintext is encrypted, and blind
+// indexes are recomputed. Rows are walked in id order, so batches can resume after LastID.
+// Each row is only written if its phone number is still the value that was read, so numbers
+// users change meanwhile are skipped rather than overwritten, and left to the next run.
+func (r *UserRepo) ReencryptPhoneNumbers(ctx context.Context, afterID uuid.UUID, limit int32) (ReencryptResult, error) {
+	rows, err := r.uq.ListUserPhoneNumbers(ctx, afterID, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing user phone numbers", "error", err)
+		return ReencryptResult{}, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+
+	result := ReencryptResult{Scanned: len(rows)}
+	for _, row := range rows {
+		plaintext, err := r.crypt.Decrypt(phoneNumberField, row.PhoneNumber)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error decrypting phone number", "error", err, "user_id", row.ID)
+			return result, errWrap.WrapError(errConsts.ErrInternalServerError)
+		}
+
+		value, changed := row.PhoneNumber, false
+		if fieldcrypt.IsEncrypted(value) {
+			value, changed, err = r.crypt.Rewrap(value)
+		} else if value != "" {
+			value, err = r.crypt.Encrypt(phoneNumberField, value)
+			changed = true
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Error re-encrypting phone number", "error", err, "user_id", row.ID)
+			return result, errWrap.WrapError(errConsts.ErrInternalServerError)
+		}
+
+		bidx := r.crypt.BlindIndex(phoneNumberField, plaintext)
+		if changed || bidx != row.PhoneNumberBidx {
+			updated, err := r.uq.UpdateUserPhoneNumber(ctx, userdb.UpdateUserPhoneNumberParams{
+				PhoneNumber:     value,
+				PhoneNumberBidx: bidx,
+				ID:              row.ID,
+				OldPhoneNumber:  row.PhoneNumber,
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "Error updating phone number", "error", err, "user_id", row.ID)
+				return result, errWrap.WrapError(errConsts.ErrSQLError)
+			}
+			if updated == 0 {
+				result.Skipped++
+				continue
+			}
+			result.Updated++
+		}
+	}
+
+	if len(rows) == int(limit) {
+		result.LastID = rows[len(rows)-1].ID
+	}
+	return result, nil
+}
+
+// encrypt encrypts the sensitive columns of the params row points to and fills their blind indexes
+func (r *UserRepo) encrypt(ctx context.Context, row any) error {
+	if err := r.crypt.EncryptRow(row, phoneNumberField); err != nil {
+		slog.ErrorContext(ctx, "Error encrypting user fields", "error", err)
+		return errWrap.WrapError(errConsts.ErrInternalServerError)
+	}
+	return nil
+}
+
+// decrypt decrypts the sensitive columns of the result row points to
+func (r *UserRepo) decrypt(ctx context.Context, row any) error {
+	if err := r.crypt.DecryptRow(row, phoneNumberField); err != nil {
+		slog.ErrorContext(ctx, "Error decrypting user fields", "error", err)
+		return errWrap.WrapError(errConsts.ErrInternalServerError)
+	}
+	return nil
+}
+
+// normalizePhoneNumber strips separators and rewrites the +62 and 62 country prefixes to 0
+func normalizePhoneNumber(phoneNumber string) string {
+	phoneNumber = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '(' || r == ')' || r == '.' {
+			return -1
+		}
+		return r
+	}, phoneNumber)
+	for _, prefix := range []string{"+62", "62"} {
+		if strings.HasPrefix(phoneNumber, prefix) {
+			return "0" + strings.TrimPrefix(phoneNumber, prefix)
+		}
+	}
+	return phoneNumber
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+
+	auditDTO "medisuite-api/app/dto/audit"
+	"medisuite-api/app/repo"
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/locales"
+	auditdb "medisuite-api/pkg/db/audit_events"
+	"medisuite-api/pkg/i18n"
+	"medisuite-api/pkg/tracing"
+
+	"github.com/google/uuid"
+)
+
+// defaultLimit is the page size used when the query does not set one
+const defaultLimit = 50
+
+type IAuditService interface {
+	FindAuditEvents(ctx context.Context, req auditDTO.AuditEventQueryDTO) ([]auditDTO.AuditEventResponse, error)
+}
+
+type AuditService struct {
+	r repo.IRepo
+}
+
+func NewAuditService(r repo.IRepo) IAuditService {
+	return &AuditService{r: r}
+}
+
+// Service method for listing audit events, newest first.
+func (s *AuditService) FindAuditEvents(ctx context.Context, req auditDTO.AuditEventQueryDTO) ([]auditDTO.AuditEventResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.FindAuditEvents")
+	defer span.End()
+
+	if req.From != nil && req.To != nil && !req.To.After(*req.From) {
+		return nil, errConsts.ErrValidationFailed.WithDetails([]errWrap.ValidationError{{
+			Field:   "to",
+			Message: i18n.T(ctx, locales.ValidationAfter, "to", "from"),
+		}})
+	}
+
+	params := auditdb.ListAuditEventsParams{
+		OccurredFrom: req.From,
+		OccurredTo:   req.To,
+		RowLimit:     req.Limit,
+		RowOffset:    req.Offset,
+	}
+	if params.RowLimit == 0 {
+		params.RowLimit = defaultLimit
+	}
+	if req.ActorID != "" {
+		// already validated as a uuid
+		actorID := uuid.MustParse(req.ActorID)
+		params.ActorUserID = &actorID
+	}
+	if req.EntityType != "" {
+		params.EntityType = &req.EntityType
+	}
+	if req.EntityID != "" {
+		params.EntityID = &req.EntityID
+	}
+
+	events, err := s.r.AuditRepo().List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]auditDTO.AuditEventResponse, len(events))
+	for i, event := range events {
+		response[i] = auditDTO.AuditEventResponse{
+			ID:          event.ID,
+			OccurredAt:  event.OccurredAt,
+			ActorUserID: event.ActorUserID,
+			ActorRole:   event.ActorRole,
+			ActorIP:     event.ActorIp,
+			RequestID:   event.RequestID,
+			Action:      event.Action,
+			EntityType:  event.EntityType,
+			EntityID:    event.EntityID,
+			Diff:        event.Diff,
+		}
+	}
+	return response, nil
+}
@@ -9,13 +9,17 @@ import (
 	errWrap "medisuite-api/common/errors"
 	errConsts "medisuite-api/constants/errors"
 	"medisuite-api/constants/success"
+	"medisuite-api/pkg/audit"
+	categorydb "medisuite-api/pkg/db/categories"
+	"medisuite-api/pkg/tracing"
 
 	"github.com/google/uuid"
 )
 
 type ICategoryService interface {
 	Create(ctx context.Context, name_category string) (*categoryDTO.CategoryResponse, error)
-	Update(ctx context.Context, categoryID uuid.UUID, name_category string) (*categoryDTO.CategoryResponse, error)
+	Update(ctx context.Context, categoryID uuid.UUID, version int32, name_category string) (*categoryDTO.CategoryResponse, error)
+	Patch(ctx context.Context, categoryID uuid.UUID, version int32, req categoryDTO.CategoryPatchDTO) (*categoryDTO.CategoryResponse, error)
 	Delete(ctx context.Context, categoryID uuid.UUID) error
 	FindAll(ctx context.Context) ([]categoryDTO.CategoryResponse, error)
 	FindById(ctx context.Context, categoryID uuid.UUID) (*categoryDTO.CategoryResponse, error)
@@ -31,63 +35,162 @@ func NewCategoryService(r repo.IRepo) ICategoryService {
 
 // Services method for creating a new category.
 func (s *CategoryService) Create(ctx context.Context, name_category string) (*categoryDTO.CategoryResponse, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Create")
+	defer span.End()
+
 	// find category if exist
 	findCategory, err := s.r.CategoryRepo().FindCategoryByName(ctx, name_category)
 	if err != nil {
-		slog.Error("Error finding name_category", "error", err, "name_category", name_category)
+		slog.ErrorContext(ctx, "Error finding name_category", "error", err, "name_category", name_category)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	if findCategory != nil {
-		slog.Error("Category already exist", "error", err, "name_category", name_category)
+		slog.ErrorContext(ctx, "Category already exist", "error", err, "name_category", name_category)
 		return nil, errWrap.WrapError(errConsts.ErrCategoryExist)
 	}
 
-	// create category
-	category, err := s.r.CategoryRepo().Create(ctx, name_category)
+	// create category, audited in the same transaction
+	var category *categorydb.Category
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		created, err := tx.CategoryRepo().Create(ctx, name_category)
+		if err != nil {
+			return err
+		}
+		category = created
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityCategory,
+			EntityID:   category.ID.String(),
+			After:      category,
+		})
+	})
 	if err != nil {
-		slog.Error("Error creating category", "error", err, "name_category", name_category)
+		slog.ErrorContext(ctx, "Error creating category", "error", err, "name_category", name_category)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
-	slog.Info(success.SuccessCreateCategory)
+	slog.InfoContext(ctx, success.SuccessCreateCategory)
 
 	response := &categoryDTO.CategoryResponse{
 		ID:           category.ID,
 		NameCategory: category.NameCategory,
 		CreatedAt:    category.CreatedAt,
 		UpdatedAt:    category.UpdatedAt,
+		Version:      category.Version,
 	}
 
 	return response, nil
 }
 
-// Services method for updating a category.
-func (s *CategoryService) Update(ctx context.Context, categoryID uuid.UUID, name_category string) (*categoryDTO.CategoryResponse, error) {
+// Services method for updating a category; version is the one the client's changes are based on.
+func (s *CategoryService) Update(ctx context.Context, categoryID uuid.UUID, version int32, name_category string) (*categoryDTO.CategoryResponse, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Update")
+	defer span.End()
+
 	// find category if exist
 	findCategory, err := s.r.CategoryRepo().FindById(ctx, categoryID)
 	if err != nil {
-		slog.Error("Error finding name_category", "error", err, "name_category", name_category)
+		slog.ErrorContext(ctx, "Error finding name_category", "error", err, "name_category", name_category)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	if findCategory == nil {
-		slog.Error("Category not found", "error", err, "name_category", name_category)
+		slog.ErrorContext(ctx, "Category not found", "error", err, "name_category", name_category)
 		return nil, errWrap.WrapError(errConsts.ErrFindCategoryId)
 	}
+	if findCategory.Version != version {
+		slog.WarnContext(ctx, "Category version conflict", "category_id", categoryID, "version", version, "current_version", findCategory.Version)
+		return nil, errWrap.WrapError(errConsts.ErrPreconditionFailed)
+	}
+
+	// update category, only if no other update happened since it was read, audited in the same transaction
+	var category *categorydb.Category
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		updated, err := tx.CategoryRepo().Update(ctx, categoryID, name_category, version)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return errWrap.WrapError(errConsts.ErrPreconditionFailed)
+		}
+		category = updated
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityCategory,
+			EntityID:   categoryID.String(),
+			Before:     findCategory,
+			After:      category,
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error updating category", "error", err, "name_category", name_category)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, success.SuccessUpdateCategory)
+
+	response := &categoryDTO.CategoryResponse{
+		ID:           category.ID,
+		NameCategory: category.NameCategory,
+		CreatedAt:    category.CreatedAt,
+		UpdatedAt:    category.UpdatedAt,
+		Version:      category.Version,
+	}
+
+	return response, nil
+}
+
+// Services method for partially updating a category; fields absent from req are left unchanged.
+func (s *CategoryService) Patch(ctx context.Context, categoryID uuid.UUID, version int32, req categoryDTO.CategoryPatchDTO) (*categoryDTO.CategoryResponse, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Patch")
+	defer span.End()
 
-	// update category
-	category, err := s.r.CategoryRepo().Update(ctx, categoryID, name_category)
+	// find category if exist
+	findCategory, err := s.r.CategoryRepo().FindById(ctx, categoryID)
 	if err != nil {
-		slog.Error("Error updating category", "error", err, "name_category", name_category)
+		slog.ErrorContext(ctx, "Error finding category", "error", err, "category_id", categoryID)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
+	if findCategory == nil {
+		slog.ErrorContext(ctx, "Category not found", "category_id", categoryID)
+		return nil, errWrap.WrapError(errConsts.ErrFindCategoryId)
+	}
+	if findCategory.Version != version {
+		slog.WarnContext(ctx, "Category version conflict", "category_id", categoryID, "version", version, "current_version", findCategory.Version)
+		return nil, errWrap.WrapError(errConsts.ErrPreconditionFailed)
+	}
 
-	slog.Info(success.SuccessUpdateCategory)
+	// patch category, only if no other update happened since it was read, audited in the same transaction
+	var category *categorydb.Category
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		patched, err := tx.CategoryRepo().Patch(ctx, categoryID, req.NameCategory, version)
+		if err != nil {
+			return err
+		}
+		if patched == nil {
+			return errWrap.WrapError(errConsts.ErrPreconditionFailed)
+		}
+		category = patched
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityCategory,
+			EntityID:   categoryID.String(),
+			Before:     findCategory,
+			After:      category,
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error patching category", "error", err, "category_id", categoryID)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, success.SuccessUpdateCategory)
 
 	response := &categoryDTO.CategoryResponse{
 		ID:           category.ID,
 		NameCategory: category.NameCategory,
 		CreatedAt:    category.CreatedAt,
 		UpdatedAt:    category.UpdatedAt,
+		Version:      category.Version,
 	}
 
 	return response, nil
@@ -95,34 +198,51 @@ func (s *CategoryService) Update(ctx context.Context, categoryID uuid.UUID, name
 
 // Services method for deleting a category.
 func (s *CategoryService) Delete(ctx context.Context, categoryID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "CategoryService.Delete")
+	defer span.End()
+
 	// find category if exist
 	findCategory, err := s.r.CategoryRepo().FindById(ctx, categoryID)
 	if err != nil {
-		slog.Error("Error finding name_category", "error", err)
+		slog.ErrorContext(ctx, "Error finding name_category", "error", err)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	if findCategory == nil {
-		slog.Error("Category not found", "error", err)
+		slog.ErrorContext(ctx, "Category not found", "error", err)
 		return errWrap.WrapError(errConsts.ErrFindCategoryId)
 	}
 
-	// delete category
-	err = s.r.CategoryRepo().Delete(ctx, categoryID)
+	// delete category, audited in the same transaction
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		if err := tx.CategoryRepo().Delete(ctx, categoryID); err != nil {
+			return err
+		}
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionDelete,
+			EntityType: audit.EntityCategory,
+			EntityID:   categoryID.String(),
+			Before:     findCategory,
+		})
+	})
 	if err != nil {
+		slog.ErrorContext(ctx, "Error deleting category", "error", err, "category_id", categoryID)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
-	slog.Info(success.SuccessDeleteCategory)
+	slog.InfoContext(ctx, success.SuccessDeleteCategory)
 
 	return nil
 }
 
 // Services method for finding all categories.
 func (s *CategoryService) FindAll(ctx context.Context) ([]categoryDTO.CategoryResponse, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.FindAll")
+	defer span.End()
+
 	// find category if exist
 	categories, err := s.r.CategoryRepo().FindAll(ctx)
 	if err != nil {
-		slog.Error("Error finding name_category", "error", err)
+		slog.ErrorContext(ctx, "Error finding name_category", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	response := make([]categoryDTO.CategoryResponse, len(categories))
@@ -132,6 +252,7 @@ func (s *CategoryService) FindAll(ctx context.Context) ([]categoryDTO.CategoryRe
 			NameCategory: c.NameCategory,
 			CreatedAt:    c.CreatedAt,
 			UpdatedAt:    c.UpdatedAt,
+			Version:      c.Version,
 		}
 	}
 	return response, nil
@@ -139,14 +260,17 @@ func (s *CategoryService) FindAll(ctx context.Context) ([]categoryDTO.CategoryRe
 
 // Services method for finding a category by ID.
 func (s *CategoryService) FindById(ctx context.Context, categoryID uuid.UUID) (*categoryDTO.CategoryResponse, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.FindById")
+	defer span.End()
+
 	// find category if exist
 	findCategory, err := s.r.CategoryRepo().FindById(ctx, categoryID)
 	if err != nil {
-		slog.Error("Error finding name_category", "error", err)
+		slog.ErrorContext(ctx, "Error finding name_category", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	if findCategory == nil {
-		slog.Error("Category not found", "error", err)
+		slog.ErrorContext(ctx, "Category not found", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrFindCategoryId)
 	}
 
@@ -155,6 +279,7 @@ func (s *CategoryService) FindById(ctx context.Context, categoryID uuid.UUID) (*
 		NameCategory: findCategory.NameCategory,
 		CreatedAt:    findCategory.CreatedAt,
 		UpdatedAt:    findCategory.UpdatedAt,
+		Version:      findCategory.Version,
 	}
 	return response, nil
 }
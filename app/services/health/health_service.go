@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	healthDTO "medisuite-api/app/dto/health"
+	"medisuite-api/app/repo"
+	"medisuite-api/common/emails"
+	"medisuite-api/config"
+
+	goose "github.com/pressly/goose/v3"
+)
+
+type IHealthService interface {
+	Liveness() healthDTO.Report
+	Readiness(ctx context.Context) healthDTO.Report
+	Details(ctx context.Context) healthDTO.Report
+}
+
+type HealthService struct {
+	r      repo.IRepo
+	cfg    *config.AppConfig
+	mailer *emails.Service
+}
+
+func NewHealthService(r repo.IRepo, cfg *config.AppConfig, mailer *emails.Service) IHealthService {
+	return &HealthService{r: r, cfg: cfg, mailer: mailer}
+}
+
+// check is a single dependency probe; a failing critical check makes the service not ready
+type check struct {
+	name     string
+	critical bool
+	run      func(ctx context.Context) (detail string, err error)
+}
+
+// Service method for liveness: the process is up and serving, no dependencies are touched.
+func (s *HealthService) Liveness() healthDTO.Report {
+	return healthDTO.Report{Status: healthDTO.StatusUp, CheckedAt: time.Now()}
+}
+
+// Service method for readiness: dependency statuses only, without latency or error details.
+func (s *HealthService) Readiness(ctx context.Context) healthDTO.Report {
+	report := s.runChecks(ctx)
+	for i := range report.Checks {
+		report.Checks[i].LatencyMS = 0
+		report.Checks[i].Detail = ""
+		report.Checks[i].Error = ""
+	}
+	return report
+}
+
+// Service method for the detailed report with per-dependency latency, detail and error.
+func (s *HealthService) Details(ctx context.Context) healthDTO.Report {
+	return s.runChecks(ctx)
+}
+
+// runChecks probes every dependency in turn, each bounded by the configured timeout.
+func (s *HealthService) runChecks(ctx context.Context) healthDTO.Report {
+	checks := []check{
+		{name: "database", critical: true, run: s.checkDatabase},
+		{name: "migrations", critical: true, run: s.checkMigrations},
+		// mail outages degrade sign-up and invites but should not pull the API out of rotation
+		{name: "smtp", critical: false, run: s.checkSMTP},
+	}
+
+	report := healthDTO.Report{Status: healthDTO.StatusUp, CheckedAt: time.Now()}
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, s.cfg.Health.CheckTimeout)
+		start := time.Now()
+		detail, err := c.run(checkCtx)
+		latency := time.Since(start)
+		cancel()
+
+		result := healthDTO.CheckResult{
+			Name:      c.name,
+			Status:    healthDTO.StatusUp,
+			Critical:  c.critical,
+			LatencyMS: float64(latency.Microseconds()) / 1000,
+			Detail:    detail,
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "Health check failed", "check", c.name, "error", err, "latency", latency)
+			result.Status = healthDTO.StatusDown
+			result.Error = err.Error()
+			switch {
+			case c.critical:
+				report.Status = healthDTO.StatusDown
+			case report.Status == healthDTO.StatusUp:
+				report.Status = healthDTO.StatusDegraded
+			}
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+// checkDatabase pings the database connection
+func (s *HealthService) checkDatabase(ctx context.Context) (string, error) {
+	return "", s.r.Ping(ctx)
+}
+
+// checkMigrations compares the applied schema version with the newest migration on disk
+func (s *HealthService) checkMigrations(ctx context.Context) (string, error) {
+	current, err := s.r.MigrationVersion(ctx)
+	if err != nil {
+		return "", fmt.Errorf("reading schema version: %w", err)
+	}
+
+	var expected int64
+	migrations, err := goose.CollectMigrations(s.cfg.Migration.Path, 0, goose.MaxVersion)
+	if err != nil {
+		return "", fmt.Errorf("reading migrations: %w", err)
+	}
+	if last, err := migrations.Last(); err == nil {
+		expected = last.Version
+	}
+
+	detail := fmt.Sprintf("current=%d expected=%d", current, expected)
+	if current < expected {
+		return detail, fmt.Errorf("schema version %d is behind latest migration %d", current, expected)
+	}
+	return detail, nil
+}
+
+// checkSMTP opens and closes a TLS connection to the mail server
+func (s *HealthService) checkSMTP(ctx context.Context) (string, error) {
+	return s.cfg.SMTP.Addr(), s.mailer.Ping(ctx)
+}
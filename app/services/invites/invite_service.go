@@ -0,0 +1,402 @@
+package invites
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	inviteDTO "medisuite-api/app/dto/invites"
+	userDTO "medisuite-api/app/dto/users"
+	"medisuite-api/app/repo"
+	roleService "medisuite-api/app/services/roles"
+	errWrap "medisuite-api/common/errors"
+	"medisuite-api/config"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/locales"
+	"medisuite-api/constants/success"
+	"medisuite-api/pkg/audit"
+	roledb "medisuite-api/pkg/db/roles"
+	invitedb "medisuite-api/pkg/db/staff_invites"
+	userdb "medisuite-api/pkg/db/users"
+	"medisuite-api/pkg/i18n"
+	"medisuite-api/pkg/outbox"
+	"medisuite-api/pkg/tracing"
+
+	"github.com/google/uuid"
+)
+
+// inviteTTL is how long an invite link stays valid after it is sent or resent
+const inviteTTL = 72 * time.Hour
+
+type IInviteService interface {
+	CreateInvite(ctx context.Context, actorID uuid.UUID, req inviteDTO.InviteDTO) (*inviteDTO.InviteResponse, error)
+	FindPendingInvites(ctx context.Context) ([]inviteDTO.InviteResponse, error)
+	ResendInvite(ctx context.Context, actorID uuid.UUID, inviteID uuid.UUID) (*inviteDTO.InviteResponse, error)
+	RevokeInvite(ctx context.Context, actorID uuid.UUID, inviteID uuid.UUID) error
+	AcceptInvite(ctx context.Context, req inviteDTO.AcceptInviteDTO) (*userDTO.AuthResponse, error)
+}
+
+type InviteService struct {
+	r   repo.IRepo
+	cfg *config.AppConfig
+}
+
+func NewInviteService(r repo.IRepo, cfg *config.AppConfig) IInviteService {
+	return &InviteService{r: r, cfg: cfg}
+}
+
+// Service method for inviting a staff member by email with a preassigned role.
+// The actor must outrank the invited role.
+func (s *InviteService) CreateInvite(ctx context.Context, actorID uuid.UUID, req inviteDTO.InviteDTO) (*inviteDTO.InviteResponse, error) {
+	ctx, span := tracing.Start(ctx, "InviteService.CreateInvite")
+	defer span.End()
+
+	role, err := s.authorizeRole(ctx, actorID, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// invited email must not belong to an existing account
+	findUser, err := s.r.UserRepo().FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding user by email", "error", err, "email", req.Email)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if findUser != nil {
+		slog.ErrorContext(ctx, "User already exists with this email", "email", req.Email)
+		return nil, errWrap.WrapError(errConsts.ErrUserEmailAlreadyExists)
+	}
+
+	// only one live invite per email; an expired one is revoked and replaced
+	pending, err := s.r.InviteRepo().FindPendingByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil && time.Now().Before(pending.ExpiresAt) {
+		slog.ErrorContext(ctx, "Pending invite already exists", "email", req.Email, "invite_id", pending.ID)
+		return nil, errWrap.WrapError(errConsts.ErrInviteAlreadyPending)
+	}
+
+	token := config.GenerateRandomToken(32)
+	var invite *invitedb.StaffInvite
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		if pending != nil {
+			revoked, err := tx.InviteRepo().Revoke(ctx, pending.ID)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error revoking expired invite", "error", err, "invite_id", pending.ID)
+				return err
+			}
+			err = tx.AuditRepo().Record(ctx, audit.Event{
+				Action:     audit.ActionRevoke,
+				EntityType: audit.EntityInvite,
+				EntityID:   pending.ID.String(),
+				Before:     pending,
+				After:      revoked,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		created, err := tx.InviteRepo().Create(ctx, invitedb.CreateInviteParams{
+			Email:     req.Email,
+			RoleID:    role.ID,
+			TokenHash: hashInviteToken(token),
+			InvitedBy: actorID,
+			ExpiresAt: time.Now().Add(inviteTTL),
+		})
+		if err != nil {
+			return err
+		}
+		invite = created
+		err = tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityInvite,
+			EntityID:   invite.ID.String(),
+			After:      invite,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.OutboxRepo().Enqueue(ctx, s.inviteEmail(ctx, invite.Email, role.Name, token))
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating invite", "error", err, "email", req.Email)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, success.SuccessCreateInvite, "invite_id", invite.ID, "role_id", role.ID, "actor_id", actorID)
+
+	response := toInviteResponse(invite)
+	return &response, nil
+}
+
+// Service method for listing invites that are neither accepted nor revoked.
+func (s *InviteService) FindPendingInvites(ctx context.Context) ([]inviteDTO.InviteResponse, error) {
+	ctx, span := tracing.Start(ctx, "InviteService.FindPendingInvites")
+	defer span.End()
+
+	invites, err := s.r.InviteRepo().FindPendingInvites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]inviteDTO.InviteResponse, len(invites))
+	for i, invite := range invites {
+		response[i] = toInviteResponse(&invite)
+	}
+	return response, nil
+}
+
+// Service method for resending an invite. A new token is issued, so earlier links stop working.
+func (s *InviteService) ResendInvite(ctx context.Context, actorID uuid.UUID, inviteID uuid.UUID) (*inviteDTO.InviteResponse, error) {
+	ctx, span := tracing.Start(ctx, "InviteService.ResendInvite")
+	defer span.End()
+
+	invite, err := s.findPendingInvite(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.authorizeRole(ctx, actorID, invite.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	token := config.GenerateRandomToken(32)
+	var updatedInvite *invitedb.StaffInvite
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		refreshed, err := tx.InviteRepo().RefreshToken(ctx, invite.ID, hashInviteToken(token), time.Now().Add(inviteTTL))
+		if err != nil {
+			return err
+		}
+		updatedInvite = refreshed
+		err = tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityInvite,
+			EntityID:   invite.ID.String(),
+			Before:     invite,
+			After:      updatedInvite,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.OutboxRepo().Enqueue(ctx, s.inviteEmail(ctx, updatedInvite.Email, role.Name, token))
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error refreshing invite", "error", err, "invite_id", invite.ID)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, success.SuccessResendInvite, "invite_id", invite.ID, "actor_id", actorID)
+
+	response := toInviteResponse(updatedInvite)
+	return &response, nil
+}
+
+// Service method for revoking a pending invite.
+func (s *InviteService) RevokeInvite(ctx context.Context, actorID uuid.UUID, inviteID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "InviteService.RevokeInvite")
+	defer span.End()
+
+	invite, err := s.findPendingInvite(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.authorizeRole(ctx, actorID, invite.RoleID); err != nil {
+		return err
+	}
+
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		revoked, err := tx.InviteRepo().Revoke(ctx, invite.ID)
+		if err != nil {
+			return err
+		}
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionRevoke,
+			EntityType: audit.EntityInvite,
+			EntityID:   invite.ID.String(),
+			Before:     invite,
+			After:      revoked,
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error revoking invite", "error", err, "invite_id", invite.ID)
+		return err
+	}
+
+	slog.InfoContext(ctx, success.SuccessRevokeInvite, "invite_id", invite.ID, "actor_id", actorID)
+	return nil
+}
+
+// Service method for accepting an invite. The invite is consumed and the verified
+// account is created in one transaction, so a link can only ever create one user.
+func (s *InviteService) AcceptInvite(ctx context.Context, req inviteDTO.AcceptInviteDTO) (*userDTO.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "InviteService.AcceptInvite")
+	defer span.End()
+
+	invite, err := s.r.InviteRepo().FindPendingByTokenHash(ctx, hashInviteToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil {
+		slog.ErrorContext(ctx, "Invite token not found or already used")
+		return nil, errWrap.WrapError(errConsts.ErrInviteInvalid)
+	}
+	if time.Now().After(invite.ExpiresAt) {
+		slog.ErrorContext(ctx, "Invite expired", "invite_id", invite.ID, "expires_at", invite.ExpiresAt)
+		return nil, errWrap.WrapError(errConsts.ErrInviteExpired)
+	}
+
+	// check password and retry password
+	if req.Password != req.RetryPassword {
+		slog.ErrorContext(ctx, "Password and retry password do not match", "invite_id", invite.ID)
+		return nil, errWrap.WrapError(errConsts.ErrUserPasswordNotMatch)
+	}
+
+	hashedPassword, err := config.HashPassword(req.Password)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error hashing password", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrUserPassword)
+	}
+
+	var newUser *userdb.CreateUserRow
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		accepted, err := tx.InviteRepo().MarkAccepted(ctx, invite.ID)
+		if err != nil {
+			return err
+		}
+
+		// accepting the emailed link proves ownership of the address
+		newUser, err = tx.UserRepo().Create(ctx, userdb.CreateUserParams{
+			Name:            req.Name,
+			Email:           invite.Email,
+			Password:        hashedPassword,
+			PhoneNumber:     req.PhoneNumber,
+			RoleID:          invite.RoleID,
+			IsVerified:      true,
+			VerifyExpiresAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		// the invitee has no session yet; the new account is the actor
+		actorCtx := audit.WithUser(ctx, newUser.ID)
+		err = tx.AuditRepo().Record(actorCtx, audit.Event{
+			Action:     audit.ActionAccept,
+			EntityType: audit.EntityInvite,
+			EntityID:   invite.ID.String(),
+			Before:     invite,
+			After:      accepted,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.AuditRepo().Record(actorCtx, audit.Event{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityUser,
+			EntityID:   newUser.ID.String(),
+			After:      newUser,
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error accepting invite", "error", err, "invite_id", invite.ID)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, success.SuccessAcceptInvite, "invite_id", invite.ID, "user_id", newUser.ID)
+
+	response := &userDTO.AuthResponse{
+		ID:          newUser.ID,
+		Name:        newUser.Name,
+		Email:       newUser.Email,
+		PhoneNumber: newUser.PhoneNumber,
+		IsVerified:  newUser.IsVerified,
+		RoleID:      newUser.RoleID,
+		CreatedAt:   newUser.CreatedAt,
+		UpdatedAt:   newUser.UpdatedAt,
+	}
+	return response, nil
+}
+
+// authorizeRole loads the invited role and checks that the actor outranks it
+func (s *InviteService) authorizeRole(ctx context.Context, actorID uuid.UUID, roleID uuid.UUID) (*roledb.Role, error) {
+	actor, err := s.r.UserRepo().FindUserById(ctx, actorID)
+	if err != nil || actor == nil {
+		slog.ErrorContext(ctx, "Failed to find acting user", "error", err, "user_id", actorID)
+		return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
+	}
+
+	role, err := s.r.RoleRepo().FindRoleById(ctx, roleID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding role", "error", err, "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if role == nil {
+		slog.ErrorContext(ctx, "Role not found", "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrRoleNotFound)
+	}
+
+	if !roleService.Outranks(actor.RoleLevel, role.Level) {
+		slog.ErrorContext(ctx, "Invite role not below actor level", "actor_id", actorID, "actor_level", actor.RoleLevel, "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrRoleLevelTooHigh)
+	}
+	return role, nil
+}
+
+// findPendingInvite loads an invite and rejects ones that were already accepted or revoked
+func (s *InviteService) findPendingInvite(ctx context.Context, inviteID uuid.UUID) (*invitedb.StaffInvite, error) {
+	invite, err := s.r.InviteRepo().FindInviteById(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil {
+		slog.ErrorContext(ctx, "Invite not found", "invite_id", inviteID)
+		return nil, errWrap.WrapError(errConsts.ErrInviteNotFound)
+	}
+	if invite.AcceptedAt != nil || invite.RevokedAt != nil {
+		slog.ErrorContext(ctx, "Invite no longer pending", "invite_id", inviteID)
+		return nil, errWrap.WrapError(errConsts.ErrInviteInvalid)
+	}
+	return invite, nil
+}
+
+// hashInviteToken returns the value stored in staff_invites.token_hash;
+// the raw token only ever exists in the emailed link.
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// inviteEmail returns the outbox message that emails the invite link; enqueue it in the
+// transaction that stores the token so the email is sent exactly when the invite exists.
+func (s *InviteService) inviteEmail(ctx context.Context, email string, roleName string, token string) outbox.Message {
+	site := s.cfg.ClientURL
+	inviteLink := fmt.Sprintf(site+"/accept-invite?invite_token=%s", token)
+	// the invitee has no stored locale yet, so the inviter's request locale is used
+	mailLocale := i18n.Locale(ctx)
+	emailBody := i18n.Translate(mailLocale, locales.MailInviteBody, roleName, inviteLink, int(inviteTTL.Hours()))
+
+	return outbox.NewEmail([]string{email},
+		i18n.Translate(mailLocale, locales.MailInviteSubject),
+		emailBody)
+}
+
+// toInviteResponse maps an invite row to its API response
+func toInviteResponse(invite *invitedb.StaffInvite) inviteDTO.InviteResponse {
+	return inviteDTO.InviteResponse{
+		ID:        invite.ID,
+		Email:     invite.Email,
+		RoleID:    invite.RoleID,
+		InvitedBy: invite.InvitedBy,
+		ExpiresAt: invite.ExpiresAt,
+		Expired:   time.Now().After(invite.ExpiresAt),
+		CreatedAt: invite.CreatedAt,
+		UpdatedAt: invite.UpdatedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	outboxDTO "medisuite-api/app/dto/outbox"
+	"medisuite-api/app/repo"
+	"medisuite-api/common/emails"
+	errWrap "medisuite-api/common/errors"
+	"medisuite-api/config"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/pkg/audit"
+	outboxdb "medisuite-api/pkg/db/outbox_messages"
+	"medisuite-api/pkg/logs"
+	"medisuite-api/pkg/outbox"
+	"medisuite-api/pkg/tracing"
+
+	"github.com/google/uuid"
+)
+
+// defaultLimit is the page size used when the query does not set one
+const defaultLimit = 50
+
+// maxErrorLength bounds the delivery error stored on a message
+const maxErrorLength = 1000
+
+// errPermanent marks delivery failures that retrying cannot fix; such messages are dead-lettered at once
+var errPermanent = errors.New("permanent failure")
+
+type IOutboxService interface {
+	Dispatch(ctx context.Context) (int, error)
+	FindMessages(ctx context.Context, req outboxDTO.OutboxQueryDTO) ([]outboxDTO.OutboxMessageResponse, error)
+	Requeue(ctx context.Context, id uuid.UUID) (*outboxDTO.OutboxMessageResponse, error)
+}
+
+type OutboxService struct {
+	r      repo.IRepo
+	cfg    *config.AppConfig
+	mailer *emails.Service
+}
+
+func NewOutboxService(r repo.IRepo, cfg *config.AppConfig, mailer *emails.Service) IOutboxService {
+	return &OutboxService{r: r, cfg: cfg, mailer: mailer}
+}
+
+// Service method for delivering one batch of due outbox messages; returns how many were claimed.
+// Each delivery is bounded by outbox.send_timeout, so a batch finishes within its lease.
+// Failed messages are retried with exponential backoff and dead-lettered after outbox.max_attempts.
+func (s *OutboxService) Dispatch(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "OutboxService.Dispatch")
+	defer span.End()
+
+	messages, err := s.r.OutboxRepo().Claim(ctx, s.cfg.Outbox.Lease, int32(s.cfg.Outbox.BatchSize))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		// log under the request that enqueued the message
+		msgCtx := logs.WithRequestID(ctx, message.RequestID, "outbox:"+message.Topic)
+		sendCtx, cancel := context.WithTimeout(msgCtx, s.cfg.Outbox.SendTimeout)
+		deliveryErr := s.deliver(sendCtx, message)
+		cancel()
+		if err := s.settle(msgCtx, message, deliveryErr); err != nil {
+			return len(messages), err
+		}
+	}
+	return len(messages), nil
+}
+
+// Service method for listing outbox messages by status, newest first.
+func (s *OutboxService) FindMessages(ctx context.Context, req outboxDTO.OutboxQueryDTO) ([]outboxDTO.OutboxMessageResponse, error) {
+	ctx, span := tracing.Start(ctx, "OutboxService.FindMessages")
+	defer span.End()
+
+	status := req.Status
+	if status == "" {
+		status = outbox.StatusDead
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	messages, err := s.r.OutboxRepo().List(ctx, status, limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]outboxDTO.OutboxMessageResponse, len(messages))
+	for i := range messages {
+		response[i] = toOutboxMessageResponse(&messages[i])
+	}
+	return response, nil
+}
+
+// Service method for putting a dead-lettered message back in the queue with fresh attempts.
+func (s *OutboxService) Requeue(ctx context.Context, id uuid.UUID) (*outboxDTO.OutboxMessageResponse, error) {
+	ctx, span := tracing.Start(ctx, "OutboxService.Requeue")
+	defer span.End()
+
+	// requeue, audited in the same transaction
+	var message *outboxdb.OutboxMessage
+	err := s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		requeued, err := tx.OutboxRepo().Requeue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if requeued == nil {
+			return errWrap.WrapError(errConsts.ErrOutboxMessageNotFound)
+		}
+		message = requeued
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionRequeue,
+			EntityType: audit.EntityOutbox,
+			EntityID:   id.String(),
+			Before:     map[string]any{"status": outbox.StatusDead},
+			After:      map[string]any{"status": requeued.Status, "attempts": requeued.Attempts},
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error requeueing outbox message", "error", err, "message_id", id)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Outbox message requeued", "message_id", id, "topic", message.Topic)
+	response := toOutboxMessageResponse(message)
+	return &response, nil
+}
+
+// deliver performs the side effect of message according to its topic
+func (s *OutboxService) deliver(ctx context.Context, message outboxdb.OutboxMessage) error {
+	switch message.Topic {
+	case outbox.TopicEmail:
+		var email outbox.Email
+		if err := json.Unmarshal(message.Payload, &email); err != nil {
+			return fmt.Errorf("%w: decode email: %v", errPermanent, err)
+		}
+		return s.mailer.SendEmail(ctx, email.To, email.Cc, email.Subject, email.Body)
+	default:
+		return fmt.Errorf("%w: no handler for topic %q", errPermanent, message.Topic)
+	}
+}
+
+// settle records the outcome of delivering message: sent, rescheduled with backoff,
+// or dead-lettered once it failed permanently or ran out of attempts
+func (s *OutboxService) settle(ctx context.Context, message outboxdb.OutboxMessage, deliveryErr error) error {
+	if deliveryErr == nil {
+		slog.DebugContext(ctx, "Outbox message delivered", "message_id", message.ID, "topic", message.Topic)
+		return s.r.OutboxRepo().MarkSent(ctx, message.ID)
+	}
+
+	lastError := deliveryErr.Error()
+	if len(lastError) > maxErrorLength {
+		lastError = strings.ToValidUTF8(lastError[:maxErrorLength], "")
+	}
+
+	attempts := int(message.Attempts) + 1
+	if errors.Is(deliveryErr, errPermanent) || attempts >= s.cfg.Outbox.MaxAttempts {
+		slog.ErrorContext(ctx, "Outbox message dead-lettered", "error", deliveryErr, "message_id", message.ID, "topic", message.Topic, "attempts", attempts)
+		return s.r.OutboxRepo().DeadLetter(ctx, message.ID, lastError)
+	}
+
+	delay := outbox.Backoff(attempts, s.cfg.Outbox.BackoffBase, s.cfg.Outbox.BackoffMax)
+	slog.WarnContext(ctx, "Outbox message delivery failed, retrying", "error", deliveryErr, "message_id", message.ID, "topic", message.Topic, "attempts", attempts, "retry_in", delay.Round(time.Second).String())
+	return s.r.OutboxRepo().Retry(ctx, message.ID, delay, lastError)
+}
+
+// toOutboxMessageResponse maps an outbox row to its API response
+func toOutboxMessageResponse(message *outboxdb.OutboxMessage) outboxDTO.OutboxMessageResponse {
+	return outboxDTO.OutboxMessageResponse{
+		ID:            message.ID,
+		Topic:         message.Topic,
+		Status:        message.Status,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		RequestID:     message.RequestID,
+		CreatedAt:     message.CreatedAt,
+		SentAt:        message.SentAt,
+	}
+}
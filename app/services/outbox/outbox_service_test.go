@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"medisuite-api/app/repo"
+	outboxRepo "medisuite-api/app/repo/outbox"
+	"medisuite-api/common/emails"
+	"medisuite-api/config"
+	outboxdb "medisuite-api/pkg/db/outbox_messages"
+	"medisuite-api/pkg/outbox"
+
+	"github.com/google/uuid"
+)
+
+// settlement is how the dispatcher settled one message
+type settlement struct {
+	status    string
+	delay     time.Duration
+	lastError string
+}
+
+// queue hands out its messages on the first claim and records how each one is settled
+type queue struct {
+	outboxRepo.IOutboxRepo
+	messages []outboxdb.OutboxMessage
+	settled  map[uuid.UUID]settlement
+}
+
+func newQueue(messages ...outboxdb.OutboxMessage) *queue {
+	return &queue{messages: messages, settled: map[uuid.UUID]settlement{}}
+}
+
+func (q *queue) Claim(context.Context, time.Duration, int32) ([]outboxdb.OutboxMessage, error) {
+	claimed := q.messages
+	q.messages = nil
+	return claimed, nil
+}
+
+func (q *queue) MarkSent(_ context.Context, id uuid.UUID) error {
+	q.settled[id] = settlement{status: outbox.StatusSent}
+	return nil
+}
+
+func (q *queue) Retry(_ context.Context, id uuid.UUID, delay time.Duration, lastError string) error {
+	q.settled[id] = settlement{status: outbox.StatusPending, delay: delay, lastError: lastError}
+	return nil
+}
+
+func (q *queue) DeadLetter(_ context.Context, id uuid.UUID, lastError string) error {
+	q.settled[id] = settlement{status: outbox.StatusDead, lastError: lastError}
+	return nil
+}
+
+type queueRepo struct {
+	repo.IRepo
+	queue *queue
+}
+
+func (r queueRepo) OutboxRepo() outboxRepo.IOutboxRepo { return r.queue }
+
+// sender fails every email with err, or hangs until its context ends when hang is set
+type sender struct {
+	err      error
+	hang     bool
+	sent     []config.Email
+	deadline bool
+}
+
+func (s *sender) Send(ctx context.Context, email config.Email) error {
+	_, s.deadline = ctx.Deadline()
+	if s.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, email)
+	return nil
+}
+
+func (s *sender) Ping(context.Context) error { return nil }
+
+var testOutboxConfig = config.OutboxConfig{
+	BatchSize:   10,
+	Lease:       time.Minute,
+	SendTimeout: 50 * time.Millisecond,
+	MaxAttempts: 5,
+	BackoffBase: time.Second,
+	BackoffMax:  time.Hour,
+}
+
+func emailMessage(t *testing.T, attempts int32) outboxdb.OutboxMessage {
+	t.Helper()
+	payload, err := json.Marshal(outbox.Email{To: []string{"patient@example.com"}, Subject: "Reminder", Body: "See you tomorrow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return outboxdb.OutboxMessage{ID: uuid.New(), Topic: outbox.TopicEmail, Payload: payload, Status: outbox.StatusPending, Attempts: attempts}
+}
+
+// dispatch runs one Dispatch over messages and returns how each was settled
+func dispatch(t *testing.T, s *sender, messages ...outboxdb.OutboxMessage) map[uuid.UUID]settlement {
+	t.Helper()
+	q := newQueue(messages...)
+	service := NewOutboxService(queueRepo{queue: q}, &config.AppConfig{Outbox: testOutboxConfig}, emails.NewService(s))
+
+	claimed, err := service.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if claimed != len(messages) {
+		t.Fatalf("Dispatch claimed %d messages, want %d", claimed, len(messages))
+	}
+	return q.settled
+}
+
+func TestDispatchMarksDeliveredMessagesSent(t *testing.T) {
+	s := &sender{}
+	message := emailMessage(t, 0)
+
+	settled := dispatch(t, s, message)
+	if got := settled[message.ID]; got.status != outbox.StatusSent {
+		t.Fatalf("message settled as %+v, want sent", got)
+	}
+	if len(s.sent) != 1 || s.sent[0].Subject != "Reminder" {
+		t.Errorf("sent = %+v, want the reminder", s.sent)
+	}
+}
+
+func TestDispatchRetriesFailuresWithGrowingDelays(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	for attempts := int32(0); attempts < 4; attempts++ {
+		message := emailMessage(t, attempts)
+		got := dispatch(t, &sender{err: failure}, message)[message.ID]
+
+		if got.status != outbox.StatusPending {
+			t.Fatalf("attempt %d settled as %+v, want a retry", attempts+1, got)
+		}
+		if got.lastError != failure.Error() {
+			t.Errorf("last error = %q, want %q", got.lastError, failure)
+		}
+		// base doubled per failed attempt, of which up to half is jitter
+		full := testOutboxConfig.BackoffBase << attempts
+		if got.delay < full/2 || got.delay > full {
+			t.Errorf("attempt %d retry delay = %v, want between %v and %v", attempts+1, got.delay, full/2, full)
+		}
+	}
+}
+
+func TestDispatchDeadLettersAfterMaxAttempts(t *testing.T) {
+	last := emailMessage(t, int32(testOutboxConfig.MaxAttempts-1))
+	undeliverable := outboxdb.OutboxMessage{ID: uuid.New(), Topic: "sms", Payload: json.RawMessage(`{}`)}
+
+	settled := dispatch(t, &sender{err: errors.New("mailbox unavailable")}, last, undeliverable)
+	if got := settled[last.ID]; got.status != outbox.StatusDead || got.lastError != "mailbox unavailable" {
+		t.Errorf("last attempt settled as %+v, want dead", got)
+	}
+	// failures retrying cannot fix are dead-lettered on the first attempt
+	if got := settled[undeliverable.ID]; got.status != outbox.StatusDead || !strings.Contains(got.lastError, "no handler") {
+		t.Errorf("unknown topic settled as %+v, want dead", got)
+	}
+}
+
+func TestDispatchBoundsEachDeliveryWithTheSendTimeout(t *testing.T) {
+	s := &sender{hang: true}
+	message := emailMessage(t, 0)
+
+	start := time.Now()
+	got := dispatch(t, s, message)[message.ID]
+	if elapsed := time.Since(start); elapsed > 10*testOutboxConfig.SendTimeout {
+		t.Errorf("Dispatch took %v with a hanging sender", elapsed)
+	}
+	if !s.deadline {
+		t.Error("the sender got no deadline")
+	}
+	if got.status != outbox.StatusPending || !strings.Contains(got.lastError, context.DeadlineExceeded.Error()) {
+		t.Errorf("timed out message settled as %+v, want a retry", got)
+	}
+}
@@ -0,0 +1,292 @@
+package patients
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	patientDTO "medisuite-api/app/dto/patients"
+	"medisuite-api/app/policy"
+	"medisuite-api/app/repo"
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/locales"
+	"medisuite-api/constants/roles"
+	"medisuite-api/constants/success"
+	"medisuite-api/pkg/audit"
+	userdb "medisuite-api/pkg/db/users"
+	"medisuite-api/pkg/i18n"
+	"medisuite-api/pkg/outbox"
+	"medisuite-api/pkg/tracing"
+
+	"github.com/google/uuid"
+)
+
+// defaultLimit is the page size used when the access log query does not set one
+const defaultLimit = 50
+
+// breakGlassPurpose is the purpose recorded for emergency access
+const breakGlassPurpose = "emergency"
+
+type IPatientService interface {
+	FindPatientRecord(ctx context.Context, patientID uuid.UUID, req patientDTO.PatientRecordQueryDTO) (*patientDTO.PatientRecordResponse, error)
+	BreakGlass(ctx context.Context, patientID uuid.UUID, req patientDTO.BreakGlassDTO) (*patientDTO.PatientRecordResponse, error)
+	FindAccessLog(ctx context.Context, userID uuid.UUID, req patientDTO.AccessLogQueryDTO) ([]patientDTO.AccessLogResponse, error)
+	RecordResource(ctx context.Context, patientID uuid.UUID) (*policy.Resource, error)
+	AssignCareTeam(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) error
+	UnassignCareTeam(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) error
+}
+
+type PatientService struct {
+	r repo.IRepo
+}
+
+func NewPatientService(r repo.IRepo) IPatientService {
+	return &PatientService{r: r}
+}
+
+// Service method for reading a patient record with the patient read permission.
+// The read is logged with its purpose before the record is returned.
+func (s *PatientService) FindPatientRecord(ctx context.Context, patientID uuid.UUID, req patientDTO.PatientRecordQueryDTO) (*patientDTO.PatientRecordResponse, error) {
+	ctx, span := tracing.Start(ctx, "PatientService.FindPatientRecord")
+	defer span.End()
+
+	patient, err := s.findPatient(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.r.PatientAccessRepo().Record(ctx, audit.Access{
+		PatientID: patient.ID,
+		Resource:  audit.ResourcePatientRecord,
+		Purpose:   req.Purpose,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPatientRecordResponse(patient)
+	return &response, nil
+}
+
+// Service method for emergency access to a patient record by staff the policy does not let read it.
+// The read is logged with its reason and the patient is alerted by email.
+func (s *PatientService) BreakGlass(ctx context.Context, patientID uuid.UUID, req patientDTO.BreakGlassDTO) (*patientDTO.PatientRecordResponse, error) {
+	ctx, span := tracing.Start(ctx, "PatientService.BreakGlass")
+	defer span.End()
+
+	patient, err := s.findPatient(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the access is logged and the patient's alert enqueued in the same transaction
+	actor := audit.ActorFromContext(ctx)
+	alert := s.breakGlassAlert(ctx, patient, actor.UserID, req.Reason)
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		err := tx.PatientAccessRepo().Record(ctx, audit.Access{
+			PatientID:  patient.ID,
+			Resource:   audit.ResourcePatientRecord,
+			Purpose:    breakGlassPurpose,
+			BreakGlass: true,
+			Reason:     req.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.OutboxRepo().Enqueue(ctx, alert)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.WarnContext(ctx, "Break-the-glass access to patient record", "patient_id", patient.ID, "actor_id", actor.UserID)
+
+	response := toPatientRecordResponse(patient)
+	return &response, nil
+}
+
+// Service method for listing who read the caller's record, newest first.
+func (s *PatientService) FindAccessLog(ctx context.Context, userID uuid.UUID, req patientDTO.AccessLogQueryDTO) ([]patientDTO.AccessLogResponse, error) {
+	ctx, span := tracing.Start(ctx, "PatientService.FindAccessLog")
+	defer span.End()
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	accessLogs, err := s.r.PatientAccessRepo().ListByPatient(ctx, userID, limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]patientDTO.AccessLogResponse, len(accessLogs))
+	for i, accessLog := range accessLogs {
+		response[i] = patientDTO.AccessLogResponse{
+			ID:         accessLog.ID,
+			AccessedAt: accessLog.AccessedAt,
+			ActorName:  accessLog.ActorName,
+			ActorRole:  accessLog.ActorRole,
+			Resource:   accessLog.Resource,
+			Purpose:    accessLog.Purpose,
+			BreakGlass: accessLog.BreakGlass,
+			Reason:     accessLog.Reason,
+		}
+	}
+	return response, nil
+}
+
+// Service method for describing a patient record to the policy engine: the patient owns it
+// and their care team is assigned to it. Returns nil when the patient does not exist.
+func (s *PatientService) RecordResource(ctx context.Context, patientID uuid.UUID) (*policy.Resource, error) {
+	ctx, span := tracing.Start(ctx, "PatientService.RecordResource")
+	defer span.End()
+
+	patient, err := s.lookupPatient(ctx, patientID)
+	if err != nil || patient == nil {
+		return nil, err
+	}
+
+	careTeam, err := s.r.CareTeamRepo().ListStaff(ctx, patient.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy.Resource{
+		Type:        policy.ResourcePatientRecord,
+		ID:          patient.ID,
+		OwnerID:     patient.ID,
+		AssignedIDs: careTeam,
+	}, nil
+}
+
+// Service method for adding a staff member to a patient's care team, which lets doctors read the record.
+func (s *PatientService) AssignCareTeam(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "PatientService.AssignCareTeam")
+	defer span.End()
+
+	patient, err := s.findPatient(ctx, patientID)
+	if err != nil {
+		return err
+	}
+
+	staff, err := s.r.UserRepo().FindUserById(ctx, staffID)
+	if err != nil || staff == nil {
+		slog.ErrorContext(ctx, "Staff member not found", "error", err, "staff_id", staffID)
+		return errWrap.WrapError(errConsts.ErrUserNotFound)
+	}
+	if staff.RoleCode == roles.PATIENT {
+		slog.ErrorContext(ctx, "Patient cannot join a care team", "patient_id", patient.ID, "staff_id", staffID)
+		return errWrap.WrapError(errConsts.ErrCareTeamMemberInvalid)
+	}
+
+	// assign, audited in the same transaction
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		if err := tx.CareTeamRepo().Assign(ctx, patient.ID, staff.ID); err != nil {
+			return err
+		}
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityCareTeam,
+			EntityID:   patient.ID.String(),
+			After:      map[string]any{"staff_user_id": staff.ID},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, success.SuccessAssignCareTeam, "patient_id", patient.ID, "staff_id", staff.ID)
+	return nil
+}
+
+// Service method for removing a staff member from a patient's care team.
+func (s *PatientService) UnassignCareTeam(ctx context.Context, patientID uuid.UUID, staffID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "PatientService.UnassignCareTeam")
+	defer span.End()
+
+	// unassign, audited in the same transaction
+	err := s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		removed, err := tx.CareTeamRepo().Unassign(ctx, patientID, staffID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return errWrap.WrapError(errConsts.ErrCareTeamMemberNotFound)
+		}
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionDelete,
+			EntityType: audit.EntityCareTeam,
+			EntityID:   patientID.String(),
+			Before:     map[string]any{"staff_user_id": staffID},
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error removing care team member", "error", err, "patient_id", patientID, "staff_id", staffID)
+		return err
+	}
+
+	slog.InfoContext(ctx, success.SuccessUnassignCareTeam, "patient_id", patientID, "staff_id", staffID)
+	return nil
+}
+
+// findPatient returns the user with the patient role; other users are reported as not found
+// so staff records cannot be read through the patient endpoints
+func (s *PatientService) findPatient(ctx context.Context, patientID uuid.UUID) (*userdb.FindUserByIdRow, error) {
+	patient, err := s.lookupPatient(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	if patient == nil {
+		slog.WarnContext(ctx, "Patient not found", "patient_id", patientID)
+		return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
+	}
+	return patient, nil
+}
+
+// lookupPatient returns the user with the patient role, or nil when there is none;
+// errors are reserved for failed lookups
+func (s *PatientService) lookupPatient(ctx context.Context, patientID uuid.UUID) (*userdb.FindUserByIdRow, error) {
+	patient, err := s.r.UserRepo().FindUserById(ctx, patientID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding patient", "error", err, "patient_id", patientID)
+		return nil, err
+	}
+	if patient == nil || patient.RoleCode != roles.PATIENT {
+		return nil, nil
+	}
+	return patient, nil
+}
+
+// breakGlassAlert returns the outbox message that emails the patient about emergency access,
+// in the patient's own locale
+func (s *PatientService) breakGlassAlert(ctx context.Context, patient *userdb.FindUserByIdRow, actorID uuid.UUID, reason string) outbox.Message {
+	actorName, actorRole := "", ""
+	actor, err := s.r.UserRepo().FindUserById(ctx, actorID)
+	if err != nil || actor == nil {
+		slog.ErrorContext(ctx, "Error finding break-the-glass actor", "error", err, "actor_id", actorID)
+	} else {
+		actorName, actorRole = actor.Name, actor.RoleName
+	}
+
+	mailLocale := i18n.Locale(ctx, patient.Locale)
+	emailBody := i18n.Translate(mailLocale, locales.MailBreakGlassBody,
+		actorName, actorRole, time.Now().UTC().Format("2006-01-02 15:04 MST"), reason)
+
+	return outbox.NewEmail([]string{patient.Email},
+		i18n.Translate(mailLocale, locales.MailBreakGlassSubject),
+		emailBody)
+}
+
+// toPatientRecordResponse maps a user row to the patient record response
+func toPatientRecordResponse(patient *userdb.FindUserByIdRow) patientDTO.PatientRecordResponse {
+	return patientDTO.PatientRecordResponse{
+		ID:          patient.ID,
+		Name:        patient.Name,
+		Email:       patient.Email,
+		PhoneNumber: patient.PhoneNumber,
+		IsVerified:  patient.IsVerified,
+		CreatedAt:   patient.CreatedAt,
+		UpdatedAt:   patient.UpdatedAt,
+	}
+}
@@ -0,0 +1,268 @@
+package roles
+
+import (
+	"context"
+	"log/slog"
+
+	roleDTO "medisuite-api/app/dto/roles"
+	"medisuite-api/app/repo"
+	errWrap "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/success"
+	"medisuite-api/pkg/audit"
+	roledb "medisuite-api/pkg/db/roles"
+	"medisuite-api/pkg/tracing"
+
+	"github.com/google/uuid"
+)
+
+type IRoleService interface {
+	FindAll(ctx context.Context) ([]roleDTO.RoleResponse, error)
+	Create(ctx context.Context, actorID uuid.UUID, req roleDTO.RoleDTO) (*roleDTO.RoleResponse, error)
+	Update(ctx context.Context, actorID uuid.UUID, roleID uuid.UUID, req roleDTO.RoleDTO) (*roleDTO.RoleResponse, error)
+	AssignRole(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, roleID uuid.UUID) error
+}
+
+type RoleService struct {
+	r repo.IRepo
+}
+
+func NewRoleService(r repo.IRepo) IRoleService {
+	return &RoleService{r: r}
+}
+
+// Outranks reports whether a role at actorLevel may manage a role at targetLevel.
+// A higher level means more privilege, and a role can only manage roles strictly below it.
+func Outranks(actorLevel, targetLevel int32) bool {
+	return actorLevel > targetLevel
+}
+
+// Services method for finding all roles.
+func (s *RoleService) FindAll(ctx context.Context) ([]roleDTO.RoleResponse, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.FindAll")
+	defer span.End()
+
+	roles, err := s.r.RoleRepo().FindAllRoles(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding roles", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+
+	response := make([]roleDTO.RoleResponse, len(roles))
+	for i, role := range roles {
+		response[i] = toRoleResponse(&role)
+	}
+	return response, nil
+}
+
+// Services method for creating a new role below the actor's own level.
+func (s *RoleService) Create(ctx context.Context, actorID uuid.UUID, req roleDTO.RoleDTO) (*roleDTO.RoleResponse, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.Create")
+	defer span.End()
+
+	actorLevel, err := s.actorLevel(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// new role must be below the actor
+	if !Outranks(actorLevel, req.Level) {
+		slog.ErrorContext(ctx, "Role level not below actor level", "actor_id", actorID, "actor_level", actorLevel, "level", req.Level)
+		return nil, errWrap.WrapError(errConsts.ErrRoleLevelTooHigh)
+	}
+
+	// find role if exist
+	findRole, err := s.r.RoleRepo().FindRoleByCode(ctx, req.Code)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding role by code", "error", err, "code", req.Code)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if findRole != nil {
+		slog.ErrorContext(ctx, "Role already exist", "code", req.Code)
+		return nil, errWrap.WrapError(errConsts.ErrRoleAlreadyExists)
+	}
+
+	// create role, audited in the same transaction
+	var role *roledb.Role
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		created, err := tx.RoleRepo().CreateRole(ctx, roledb.CreateRoleParams{
+			Name:               req.Name,
+			Code:               req.Code,
+			Level:              req.Level,
+			Description:        req.Description,
+			CanSelfRegister:    req.CanSelfRegister,
+			InheritPermissions: req.InheritPermissions,
+		})
+		if err != nil {
+			return err
+		}
+		role = created
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityRole,
+			EntityID:   role.ID.String(),
+			After:      role,
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating role", "error", err, "code", req.Code)
+		return nil, err
+	}
+
+	// a new level may be inherited by higher roles
+	s.r.RolePermissionRepo().InvalidateAllRolePermissions()
+
+	slog.InfoContext(ctx, success.SuccessCreateRole, "role_id", role.ID, "actor_id", actorID)
+
+	response := toRoleResponse(role)
+	return &response, nil
+}
+
+// Services method for updating a role; both its current and new level must be below the actor's.
+func (s *RoleService) Update(ctx context.Context, actorID uuid.UUID, roleID uuid.UUID, req roleDTO.RoleDTO) (*roleDTO.RoleResponse, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.Update")
+	defer span.End()
+
+	actorLevel, err := s.actorLevel(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// find role if exist
+	findRole, err := s.r.RoleRepo().FindRoleById(ctx, roleID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding role", "error", err, "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if findRole == nil {
+		slog.ErrorContext(ctx, "Role not found", "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrRoleNotFound)
+	}
+
+	// neither the current nor the requested level may reach the actor's level
+	if !Outranks(actorLevel, findRole.Level) || !Outranks(actorLevel, req.Level) {
+		slog.ErrorContext(ctx, "Role level not below actor level", "actor_id", actorID, "actor_level", actorLevel, "role_id", roleID)
+		return nil, errWrap.WrapError(errConsts.ErrRoleLevelTooHigh)
+	}
+
+	// update role, audited in the same transaction
+	var role *roledb.Role
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		updated, err := tx.RoleRepo().UpdateRole(ctx, roledb.UpdateRoleParams{
+			ID:                 roleID,
+			Name:               req.Name,
+			Level:              req.Level,
+			Description:        req.Description,
+			CanSelfRegister:    req.CanSelfRegister,
+			InheritPermissions: req.InheritPermissions,
+		})
+		if err != nil {
+			return err
+		}
+		role = updated
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityRole,
+			EntityID:   roleID.String(),
+			Before:     findRole,
+			After:      role,
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error updating role", "error", err, "role_id", roleID)
+		return nil, err
+	}
+
+	// level or inheritance changes affect the cached roles and the effective permissions of other roles
+	s.r.RoleRepo().InvalidateRoles()
+	s.r.RolePermissionRepo().InvalidateAllRolePermissions()
+
+	slog.InfoContext(ctx, success.SuccessUpdateRole, "role_id", role.ID, "actor_id", actorID)
+
+	response := toRoleResponse(role)
+	return &response, nil
+}
+
+// Services method for assigning a role to a user.
+// The actor must outrank both the user's current role and the role being assigned.
+func (s *RoleService) AssignRole(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, roleID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "RoleService.AssignRole")
+	defer span.End()
+
+	actorLevel, err := s.actorLevel(ctx, actorID)
+	if err != nil {
+		return err
+	}
+
+	// find target user
+	findUser, err := s.r.UserRepo().FindUserById(ctx, userID)
+	if err != nil || findUser == nil {
+		slog.ErrorContext(ctx, "User not found", "error", err, "user_id", userID)
+		return errWrap.WrapError(errConsts.ErrUserNotFound)
+	}
+
+	// find role if exist
+	role, err := s.r.RoleRepo().FindRoleById(ctx, roleID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding role", "error", err, "role_id", roleID)
+		return errWrap.WrapError(errConsts.ErrSQLError)
+	}
+	if role == nil {
+		slog.ErrorContext(ctx, "Role not found", "role_id", roleID)
+		return errWrap.WrapError(errConsts.ErrRoleNotFound)
+	}
+
+	if !Outranks(actorLevel, findUser.RoleLevel) || !Outranks(actorLevel, role.Level) {
+		slog.ErrorContext(ctx, "Role assignment not below actor level", "actor_id", actorID, "actor_level", actorLevel, "user_id", userID, "role_id", roleID)
+		return errWrap.WrapError(errConsts.ErrRoleLevelTooHigh)
+	}
+
+	// assign role, audited in the same transaction
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		if _, err := tx.UserRepo().UpdateUserRole(ctx, userID, roleID); err != nil {
+			return err
+		}
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionAssignRole,
+			EntityType: audit.EntityUser,
+			EntityID:   userID.String(),
+			Before:     map[string]any{"role_id": findUser.RoleID},
+			After:      map[string]any{"role_id": roleID},
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error assigning role", "error", err, "user_id", userID, "role_id", roleID)
+		return err
+	}
+
+	// the user's next request is authorized with the new role, whatever its access token says
+	s.r.UserRepo().InvalidateUserRole(userID)
+
+	slog.InfoContext(ctx, success.SuccessAssignRole, "user_id", userID, "role_id", roleID, "actor_id", actorID)
+	return nil
+}
+
+// actorLevel returns the role level of the user performing the action
+func (s *RoleService) actorLevel(ctx context.Context, actorID uuid.UUID) (int32, error) {
+	actor, err := s.r.UserRepo().FindUserById(ctx, actorID)
+	if err != nil || actor == nil {
+		slog.ErrorContext(ctx, "Failed to find acting user", "error", err, "user_id", actorID)
+		return 0, errWrap.WrapError(errConsts.ErrUserNotFound)
+	}
+	return actor.RoleLevel, nil
+}
+
+// toRoleResponse maps a role row to its API response
+func toRoleResponse(role *roledb.Role) roleDTO.RoleResponse {
+	return roleDTO.RoleResponse{
+		ID:                 role.ID,
+		Name:               role.Name,
+		Code:               role.Code,
+		Level:              role.Level,
+		Description:        role.Description,
+		CanSelfRegister:    role.CanSelfRegister,
+		InheritPermissions: role.InheritPermissions,
+		CreatedAt:          role.CreatedAt,
+		UpdatedAt:          role.UpdatedAt,
+	}
+}
@@ -2,27 +2,47 @@ package services
 
 import (
 	"medisuite-api/app/repo"
+	auditService "medisuite-api/app/services/audit"
 	categoryService "medisuite-api/app/services/categories"
+	healthService "medisuite-api/app/services/health"
+	inviteService "medisuite-api/app/services/invites"
+	outboxService "medisuite-api/app/services/outbox"
+	patientService "medisuite-api/app/services/patients"
+	roleService "medisuite-api/app/services/roles"
 	treatmentService "medisuite-api/app/services/treatments"
 	userService "medisuite-api/app/services/users"
+	"medisuite-api/common/emails"
+	"medisuite-api/config"
+	"medisuite-api/pkg/jwt"
 )
 
 type IService interface {
 	UserService() userService.IUserService
 	CategoryService() categoryService.ICategoryService
 	TreatmentService() treatmentService.ITreatmentService
+	RoleService() roleService.IRoleService
+	InviteService() inviteService.IInviteService
+	HealthService() healthService.IHealthService
+	AuditService() auditService.IAuditService
+	PatientService() patientService.IPatientService
+	OutboxService() outboxService.IOutboxService
 }
 
 type Service struct {
-	r repo.IRepo
+	r      repo.IRepo
+	cfg    *config.AppConfig
+	signer *jwt.Signer
+	mailer *emails.Service
 }
 
-func NewService(r repo.IRepo) IService {
-	return &Service{r: r}
+// NewService creates the service layer; emails are enqueued to the outbox and sent
+// through mailer by the outbox dispatcher
+func NewService(r repo.IRepo, cfg *config.AppConfig, signer *jwt.Signer, mailer *emails.Service) IService {
+	return &Service{r: r, cfg: cfg, signer: signer, mailer: mailer}
 }
 
 func (s *Service) UserService() userService.IUserService {
-	return userService.NewUserService(s.r)
+	return userService.NewUserService(s.r, s.cfg, s.signer)
 }
 
 func (s *Service) CategoryService() categoryService.ICategoryService {
@@ -32,3 +52,27 @@ func (s *Service) CategoryService() categoryService.ICategoryService {
 func (s *Service) TreatmentService() treatmentService.ITreatmentService {
 	return treatmentService.NewTreatmentService(s.r)
 }
+
+func (s *Service) RoleService() roleService.IRoleService {
+	return roleService.NewRoleService(s.r)
+}
+
+func (s *Service) InviteService() inviteService.IInviteService {
+	return inviteService.NewInviteService(s.r, s.cfg)
+}
+
+func (s *Service) HealthService() healthService.IHealthService {
+	return healthService.NewHealthService(s.r, s.cfg, s.mailer)
+}
+
+func (s *Service) AuditService() auditService.IAuditService {
+	return auditService.NewAuditService(s.r)
+}
+
+func (s *Service) PatientService() patientService.IPatientService {
+	return patientService.NewPatientService(s.r)
+}
+
+func (s *Service) OutboxService() outboxService.IOutboxService {
+	return outboxService.NewOutboxService(s.r, s.cfg, s.mailer)
+}
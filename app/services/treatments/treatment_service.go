@@ -9,13 +9,16 @@ import (
 	errWrap "medisuite-api/common/errors"
 	errConsts "medisuite-api/constants/errors"
 	"medisuite-api/constants/success"
+	"medisuite-api/pkg/audit"
 	treatmentdb "medisuite-api/pkg/db/treatments"
+	"medisuite-api/pkg/tracing"
 	"github.com/google/uuid"
 )
 
 type ITreatmentService interface {
 	Create(ctx context.Context, req treatmentDTO.TreatmentDTO) (*treatmentDTO.TreatmentResponse, error)
-	Update(ctx context.Context, treatmentID uuid.UUID, req treatmentDTO.TreatmentDTO) (*treatmentDTO.TreatmentResponse, error)
+	Update(ctx context.Context, treatmentID uuid.UUID, version int32, req treatmentDTO.TreatmentDTO) (*treatmentDTO.TreatmentResponse, error)
+	Patch(ctx context.Context, treatmentID uuid.UUID, version int32, req treatmentDTO.TreatmentPatchDTO) (*treatmentDTO.TreatmentResponse, error)
 	Delete(ctx context.Context, treatmentID uuid.UUID) error
 	FindAll(ctx context.Context) ([]treatmentDTO.TreatmentResponse, error)
 	FindById(ctx context.Context, treatmentID uuid.UUID) (*treatmentDTO.TreatmentResponse, error)
@@ -31,26 +34,29 @@ func NewTreatmentService(r repo.IRepo) ITreatmentService {
 
 // Services method for creating a new treatment.
 func (s *TreatmentService) Create(ctx context.Context, req treatmentDTO.TreatmentDTO) (*treatmentDTO.TreatmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "TreatmentService.Create")
+	defer span.End()
+
 	// find treatment if exist
 	treatment, err := s.r.TreatmentRepo().FindByName(ctx, req.NameTreatment)
 	if err != nil {
-		slog.Error("Error finding name_treatment", "error", err, "name_treatment", req.NameTreatment)
+		slog.ErrorContext(ctx, "Error finding name_treatment", "error", err, "name_treatment", req.NameTreatment)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if treatment != nil {
-		slog.Error("Treatment already exist", "error", err, "name_treatment", req.NameTreatment)
+		slog.ErrorContext(ctx, "Treatment already exist", "error", err, "name_treatment", req.NameTreatment)
 		return nil, errWrap.WrapError(errConsts.ErrTreatmentExist)
 	}
 
 	category, err := s.r.CategoryRepo().FindById(ctx, req.CategoryID)
 	if err != nil {
-		slog.Error("Error finding category", "error", err, "category_id", req.CategoryID)
+		slog.ErrorContext(ctx, "Error finding category", "error", err, "category_id", req.CategoryID)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if category == nil {
-		slog.Error("Category not found", "error", err, "category_id", req.CategoryID)
+		slog.ErrorContext(ctx, "Category not found", "error", err, "category_id", req.CategoryID)
 		return nil, errWrap.WrapError(errConsts.ErrFindCategoryId)
 	}
 
@@ -64,14 +70,26 @@ func (s *TreatmentService) Create(ctx context.Context, req treatmentDTO.Treatmen
 		IsActive:      req.IsActive,
 	}
 
-	// create treatment
-	treatment, err = s.r.TreatmentRepo().Create(ctx, payload)
+	// create treatment, audited in the same transaction
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		created, err := tx.TreatmentRepo().Create(ctx, payload)
+		if err != nil {
+			return err
+		}
+		treatment = created
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityTreatment,
+			EntityID:   treatment.ID.String(),
+			After:      treatment,
+		})
+	})
 	if err != nil {
-		slog.Error("Error creating treatment", "error", err, "name_treatment", req.NameTreatment)
+		slog.ErrorContext(ctx, "Error creating treatment", "error", err, "name_treatment", req.NameTreatment)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
-	slog.Info(success.SuccessCreateTreatment)
+	slog.InfoContext(ctx, success.SuccessCreateTreatment)
 
 	response := &treatmentDTO.TreatmentResponse{
 		ID:            treatment.ID,
@@ -84,34 +102,43 @@ func (s *TreatmentService) Create(ctx context.Context, req treatmentDTO.Treatmen
 		IsActive:      treatment.IsActive,
 		CreatedAt:     treatment.CreatedAt,
 		UpdatedAt:     treatment.UpdatedAt,
+		Version:       treatment.Version,
 	}
 
 	return response, nil
 }
 
-// Services method for updating a treatment.
-func (s *TreatmentService) Update(ctx context.Context, treatmentID uuid.UUID, req treatmentDTO.TreatmentDTO) (*treatmentDTO.TreatmentResponse, error) {
+// Services method for updating a treatment; version is the one the client's changes are based on.
+func (s *TreatmentService) Update(ctx context.Context, treatmentID uuid.UUID, version int32, req treatmentDTO.TreatmentDTO) (*treatmentDTO.TreatmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "TreatmentService.Update")
+	defer span.End()
+
 	// find treatment if exist
 	findTreatment, err := s.r.TreatmentRepo().FindById(ctx, treatmentID)
 	if err != nil {
-		slog.Error("Error finding treatment", "error", err, "treatment_id", treatmentID)
+		slog.ErrorContext(ctx, "Error finding treatment", "error", err, "treatment_id", treatmentID)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if findTreatment == nil {
-		slog.Error("Treatment not found", "error", err, "treatment_id", treatmentID)
+		slog.ErrorContext(ctx, "Treatment not found", "error", err, "treatment_id", treatmentID)
 		return nil, errWrap.WrapError(errConsts.ErrFindTreatmentId)
 	}
 
+	if findTreatment.Version != version {
+		slog.WarnContext(ctx, "Treatment version conflict", "treatment_id", treatmentID, "version", version, "current_version", findTreatment.Version)
+		return nil, errWrap.WrapError(errConsts.ErrPreconditionFailed)
+	}
+
 	// find category if exist
 	category, err := s.r.CategoryRepo().FindById(ctx, req.CategoryID)
 	if err != nil {
-		slog.Error("Error finding category", "error", err, "category_id", req.CategoryID)
+		slog.ErrorContext(ctx, "Error finding category", "error", err, "category_id", req.CategoryID)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if category == nil {
-		slog.Error("Category not found", "error", err, "category_id", req.CategoryID)
+		slog.ErrorContext(ctx, "Category not found", "error", err, "category_id", req.CategoryID)
 		return nil, errWrap.WrapError(errConsts.ErrFindCategoryId)
 	}
 
@@ -124,13 +151,32 @@ func (s *TreatmentService) Update(ctx context.Context, treatmentID uuid.UUID, re
 		Price:         req.Price,
 		Duration:      req.Duration,
 		IsActive:      req.IsActive,
+		Version:       version,
 	}
 
-	// update treatment
-	updateTreatment, err := s.r.TreatmentRepo().Update(ctx, payload)
+	// update treatment, only if no other update happened since it was read, audited in the same transaction
+	var updateTreatment *treatmentdb.Treatment
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		updated, err := tx.TreatmentRepo().Update(ctx, payload)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			slog.WarnContext(ctx, "Treatment version conflict", "treatment_id", treatmentID, "version", version)
+			return errWrap.WrapError(errConsts.ErrPreconditionFailed)
+		}
+		updateTreatment = updated
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityTreatment,
+			EntityID:   treatmentID.String(),
+			Before:     findTreatment,
+			After:      updateTreatment,
+		})
+	})
 	if err != nil {
-		slog.Error("Error updating treatment", "error", err, "treatment_id", treatmentID)
-		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+		slog.ErrorContext(ctx, "Error updating treatment", "error", err, "treatment_id", treatmentID)
+		return nil, err
 	}
 
 	response := &treatmentDTO.TreatmentResponse{
@@ -143,6 +189,96 @@ func (s *TreatmentService) Update(ctx context.Context, treatmentID uuid.UUID, re
 		Duration:      updateTreatment.Duration,
 		IsActive:      updateTreatment.IsActive,
 		UpdatedAt:     updateTreatment.UpdatedAt,
+		Version:       updateTreatment.Version,
+	}
+
+	return response, nil
+}
+
+// Services method for partially updating a treatment; fields absent from req are left unchanged.
+func (s *TreatmentService) Patch(ctx context.Context, treatmentID uuid.UUID, version int32, req treatmentDTO.TreatmentPatchDTO) (*treatmentDTO.TreatmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "TreatmentService.Patch")
+	defer span.End()
+
+	// find treatment if exist
+	findTreatment, err := s.r.TreatmentRepo().FindById(ctx, treatmentID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error finding treatment", "error", err, "treatment_id", treatmentID)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
+
+	if findTreatment == nil {
+		slog.ErrorContext(ctx, "Treatment not found", "treatment_id", treatmentID)
+		return nil, errWrap.WrapError(errConsts.ErrFindTreatmentId)
+	}
+
+	if findTreatment.Version != version {
+		slog.WarnContext(ctx, "Treatment version conflict", "treatment_id", treatmentID, "version", version, "current_version", findTreatment.Version)
+		return nil, errWrap.WrapError(errConsts.ErrPreconditionFailed)
+	}
+
+	// find category if it is being changed
+	if req.CategoryID != nil {
+		category, err := s.r.CategoryRepo().FindById(ctx, *req.CategoryID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error finding category", "error", err, "category_id", *req.CategoryID)
+			return nil, errWrap.WrapError(errConsts.ErrSQLError)
+		}
+
+		if category == nil {
+			slog.ErrorContext(ctx, "Category not found", "category_id", *req.CategoryID)
+			return nil, errWrap.WrapError(errConsts.ErrFindCategoryId)
+		}
+	}
+
+	payload := treatmentdb.PatchTreatmentParams{
+		CategoryID:    req.CategoryID,
+		NameTreatment: req.NameTreatment,
+		Description:   req.Description,
+		Thumbnail:     req.Thumbnail,
+		Price:         req.Price,
+		Duration:      req.Duration,
+		IsActive:      req.IsActive,
+		ID:            treatmentID,
+		Version:       version,
+	}
+
+	// patch treatment, only if no other update happened since it was read, audited in the same transaction
+	var patchTreatment *treatmentdb.Treatment
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		patched, err := tx.TreatmentRepo().Patch(ctx, payload)
+		if err != nil {
+			return err
+		}
+		if patched == nil {
+			slog.WarnContext(ctx, "Treatment version conflict", "treatment_id", treatmentID, "version", version)
+			return errWrap.WrapError(errConsts.ErrPreconditionFailed)
+		}
+		patchTreatment = patched
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityTreatment,
+			EntityID:   treatmentID.String(),
+			Before:     findTreatment,
+			After:      patchTreatment,
+		})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error patching treatment", "error", err, "treatment_id", treatmentID)
+		return nil, err
+	}
+
+	response := &treatmentDTO.TreatmentResponse{
+		ID:            patchTreatment.ID,
+		CategoryID:    patchTreatment.CategoryID,
+		NameTreatment: patchTreatment.NameTreatment,
+		Description:   patchTreatment.Description,
+		Thumbnail:     patchTreatment.Thumbnail,
+		Price:         patchTreatment.Price,
+		Duration:      patchTreatment.Duration,
+		IsActive:      patchTreatment.IsActive,
+		UpdatedAt:     patchTreatment.UpdatedAt,
+		Version:       patchTreatment.Version,
 	}
 
 	return response, nil
@@ -150,35 +286,51 @@ func (s *TreatmentService) Update(ctx context.Context, treatmentID uuid.UUID, re
 
 // Services method for deleting a treatment.
 func (s *TreatmentService) Delete(ctx context.Context, treatmentID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "TreatmentService.Delete")
+	defer span.End()
+
 	// find treatment if exist
 	findTreatment, err := s.r.TreatmentRepo().FindById(ctx, treatmentID)
 	if err != nil {
-		slog.Error("Error finding treatment", "error", err, "treatment_id", treatmentID)
+		slog.ErrorContext(ctx, "Error finding treatment", "error", err, "treatment_id", treatmentID)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if findTreatment == nil {
-		slog.Error("Treatment not found", "error", err, "treatment_id", treatmentID)
+		slog.ErrorContext(ctx, "Treatment not found", "error", err, "treatment_id", treatmentID)
 		return errWrap.WrapError(errConsts.ErrFindTreatmentId)
 	}
 
-	// delete treatment
-	err = s.r.TreatmentRepo().Delete(ctx, treatmentID)
+	// delete treatment, audited in the same transaction
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		if err := tx.TreatmentRepo().Delete(ctx, treatmentID); err != nil {
+			return err
+		}
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionDelete,
+			EntityType: audit.EntityTreatment,
+			EntityID:   treatmentID.String(),
+			Before:     findTreatment,
+		})
+	})
 	if err != nil {
-		slog.Error("Error deleting treatment", "error", err, "treatment_id", treatmentID)
+		slog.ErrorContext(ctx, "Error deleting treatment", "error", err, "treatment_id", treatmentID)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
-	slog.Info(success.SuccessDeleteTreatment)
+	slog.InfoContext(ctx, success.SuccessDeleteTreatment)
 	return nil
 }
 
 // Services method for finding all treatments.
 func (s *TreatmentService) FindAll(ctx context.Context) ([]treatmentDTO.TreatmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "TreatmentService.FindAll")
+	defer span.End()
+
 	// find treatment if exist
 	treatments, err := s.r.TreatmentRepo().FindAll(ctx)
 	if err != nil {
-		slog.Error("Error finding treatment", "error", err)
+		slog.ErrorContext(ctx, "Error finding treatment", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
@@ -195,6 +347,7 @@ func (s *TreatmentService) FindAll(ctx context.Context) ([]treatmentDTO.Treatmen
 			IsActive:      t.IsActive,
 			CreatedAt:     t.CreatedAt,
 			UpdatedAt:     t.UpdatedAt,
+			Version:       t.Version,
 		}
 	}
 
@@ -203,15 +356,18 @@ func (s *TreatmentService) FindAll(ctx context.Context) ([]treatmentDTO.Treatmen
 
 // Services method for finding a treatment by ID.
 func (s *TreatmentService) FindById(ctx context.Context, treatmentID uuid.UUID) (*treatmentDTO.TreatmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "TreatmentService.FindById")
+	defer span.End()
+
 	// find treatment if exist
 	findTreatment, err := s.r.TreatmentRepo().FindById(ctx, treatmentID)
 	if err != nil {
-		slog.Error("Error finding treatment", "error", err, "treatment_id", treatmentID)
+		slog.ErrorContext(ctx, "Error finding treatment", "error", err, "treatment_id", treatmentID)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if findTreatment == nil {
-		slog.Error("Treatment not found", "error", err, "treatment_id", treatmentID)
+		slog.ErrorContext(ctx, "Treatment not found", "error", err, "treatment_id", treatmentID)
 		return nil, errWrap.WrapError(errConsts.ErrFindTreatmentId)
 	}
 
@@ -226,6 +382,7 @@ func (s *TreatmentService) FindById(ctx context.Context, treatmentID uuid.UUID)
 		IsActive:      findTreatment.IsActive,
 		CreatedAt:     findTreatment.CreatedAt,
 		UpdatedAt:     findTreatment.UpdatedAt,
+		Version:       findTreatment.Version,
 	}
 
 	return &response, nil
@@ -10,15 +10,20 @@ import (
 
 	userDTO "medisuite-api/app/dto/users"
 	"medisuite-api/app/repo"
-	"medisuite-api/common/emails"
 	errWrap "medisuite-api/common/errors"
 	"medisuite-api/config"
 	errConsts "medisuite-api/constants/errors"
 	"medisuite-api/constants/roles"
+	"medisuite-api/constants/locales"
 	"medisuite-api/constants/success"
+	"medisuite-api/pkg/audit"
 	sessiondb "medisuite-api/pkg/db/user_sessions"
 	userdb "medisuite-api/pkg/db/users"
+	"medisuite-api/pkg/i18n"
 	"medisuite-api/pkg/jwt"
+	"medisuite-api/pkg/metrics"
+	"medisuite-api/pkg/outbox"
+	"medisuite-api/pkg/tracing"
 
 	"github.com/google/uuid"
 )
@@ -33,47 +38,54 @@ type IUserService interface {
 	RefreshToken(ctx context.Context, token string, clientIP string) (*userDTO.AuthResponse, error)
 	ForgotPassword(ctx context.Context, req *userDTO.EmailRequest) error
 	ResetPassword(ctx context.Context, req *userDTO.ResetPasswordDTO) error
+	UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error
+	PatchProfile(ctx context.Context, userID uuid.UUID, req userDTO.ProfilePatchDTO) (*userDTO.AuthResponse, error)
 }
 
 type UserService struct {
-	r repo.IRepo
+	r      repo.IRepo
+	cfg    *config.AppConfig
+	signer *jwt.Signer
 }
 
-func NewUserService(r repo.IRepo) IUserService {
-	return &UserService{r: r}
+func NewUserService(r repo.IRepo, cfg *config.AppConfig, signer *jwt.Signer) IUserService {
+	return &UserService{r: r, cfg: cfg, signer: signer}
 }
 
 // Service method for creating a new user.
 func (s *UserService) CreateUser(ctx context.Context, req userDTO.RegisterDTO) (*userDTO.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
 	// find user by email
 	findUser, err := s.r.UserRepo().FindUserByEmail(ctx, req.Email)
 	if err != nil {
-		slog.Error("Error finding user by email", "error", err, "email", req.Email)
+		slog.ErrorContext(ctx, "Error finding user by email", "error", err, "email", req.Email)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// Check if user already exists
 	if findUser != nil {
-		slog.Error("User already exists with this email", "email", req.Email)
+		slog.ErrorContext(ctx, "User already exists with this email", "email", req.Email)
 		return nil, errWrap.WrapError(errConsts.ErrUserEmailAlreadyExists)
 	}
 
 	// get role
 	role, err := s.r.RoleRepo().FindRoleById(ctx, req.RoleID)
 	if err != nil {
-		slog.Error("Error finding role by id", "error", err)
+		slog.ErrorContext(ctx, "Error finding role by id", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// check if role exists
 	if role == nil {
-		slog.Error("Role not found", "role_id", req.RoleID)
+		slog.ErrorContext(ctx, "Role not found", "role_id", req.RoleID)
 		return nil, errWrap.WrapError(errConsts.ErrRoleNotFound)
 	}
 
 	// check if role can self register
 	if !role.CanSelfRegister {
-		slog.Error("Role cannot self register", "role_id", req.RoleID, "role_code", role.Code)
+		slog.ErrorContext(ctx, "Role cannot self register", "role_id", req.RoleID, "role_code", role.Code)
 		return nil, errWrap.WrapError(errConsts.ErrRoleSelfRegister)
 	}
 
@@ -81,7 +93,7 @@ func (s *UserService) CreateUser(ctx context.Context, req userDTO.RegisterDTO) (
 	if role.Code == roles.OWNER {
 		users, err := s.r.UserRepo().FindAllUsers(ctx)
 		if err != nil {
-			slog.Error("Error fetching users to check owner count", "error", err)
+			slog.ErrorContext(ctx, "Error fetching users to check owner count", "error", err)
 			return nil, errWrap.WrapError(errConsts.ErrSQLError)
 		}
 
@@ -98,7 +110,7 @@ func (s *UserService) CreateUser(ctx context.Context, req userDTO.RegisterDTO) (
 		}
 
 		if ownerExists {
-			slog.Error("Owner already exists in the system")
+			slog.ErrorContext(ctx, "Owner already exists in the system")
 			return nil, errWrap.WrapError(errConsts.ErrOwnerAlreadyExists)
 		}
 	}
@@ -106,10 +118,15 @@ func (s *UserService) CreateUser(ctx context.Context, req userDTO.RegisterDTO) (
 	// hashpassword
 	hashedPassword, err := config.HashPassword(req.Password)
 	if err != nil {
-		slog.Error("Error hashing password", "error", err)
+		slog.ErrorContext(ctx, "Error hashing password", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrUserPassword)
 	}
 
+	locale, err := resolveLocale(req.Locale)
+	if err != nil {
+		return nil, err
+	}
+
 	verifyCode := config.GenerateRandomToken(32)
 	verifyExpiresAt := time.Now().Add(24 * time.Hour)
 
@@ -122,32 +139,43 @@ func (s *UserService) CreateUser(ctx context.Context, req userDTO.RegisterDTO) (
 		RoleID:          req.RoleID,
 		VerifyCode:      verifyCode,
 		VerifyExpiresAt: verifyExpiresAt,
+		Locale:          locale,
 	}
 
-	newUser, err := s.r.UserRepo().Create(ctx, payload)
+	// email verification
+	site := s.cfg.ClientURL
+	verificationLink := fmt.Sprintf(site+"/verify-account?verify_token=%s", verifyCode)
+	mailLocale := i18n.Locale(ctx, locale)
+	emailBody := i18n.Translate(mailLocale, locales.MailVerifyBody, verificationLink)
+
+	// create user, audited in the same transaction with the new account as the actor;
+	// the verification email is enqueued with it and delivered by the outbox dispatcher
+	var newUser *userdb.CreateUserRow
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		created, err := tx.UserRepo().Create(ctx, payload)
+		if err != nil {
+			return err
+		}
+		newUser = created
+		err = tx.AuditRepo().Record(audit.WithUser(ctx, newUser.ID), audit.Event{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityUser,
+			EntityID:   newUser.ID.String(),
+			After:      newUser,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.OutboxRepo().Enqueue(ctx, outbox.NewEmail([]string{newUser.Email},
+			i18n.Translate(mailLocale, locales.MailVerifySubject),
+			emailBody))
+	})
 	if err != nil {
-		slog.Error("Error creating user in repository", "error", err, "email", req.Email)
+		slog.ErrorContext(ctx, "Error creating user in repository", "error", err, "email", req.Email)
 		return nil, err // Return the error as is (it's already wrapped)
 	}
 
-	// send email verification
-	site := "http://localhost:3002"
-	verificationLink := fmt.Sprintf(site+"/verify-account?verify_token=%s", verifyCode)
-	emailBody := fmt.Sprintf("Thank you for registering with Bizpos. Please verify your account by clicking the link below:\n\n%s\n\nThis link will expire in 24 hours.", verificationLink)
-
-	// send email (async or in background goroutine to not block response)
-	go func() {
-		errMail := emails.SendEmail([]string{newUser.Email}, nil,
-			"Verify Your Account",
-			emailBody)
-		if errMail != nil {
-			slog.Error("Error sending verification email", "error", errMail, "email", newUser.Email)
-		} else {
-			slog.Debug("Verification email sent successfully", "email", newUser.Email)
-		}
-	}()
-
-	slog.Debug(success.SuccessCreateUser, "user_id", newUser.ID, "email", newUser.Email)
+	slog.DebugContext(ctx, success.SuccessCreateUser, "user_id", newUser.ID, "email", newUser.Email)
 
 	// response body
 	response := &userDTO.AuthResponse{
@@ -198,6 +226,9 @@ func (s *UserService) validateVerificationToken(token string) error {
 
 // Service method for verify account
 func (s *UserService) VerifyAccount(ctx context.Context, verify_token string) (*userDTO.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyAccount")
+	defer span.End()
+
 	// Validate token
 	if err := s.validateVerificationToken(verify_token); err != nil {
 		return nil, err
@@ -218,37 +249,51 @@ func (s *UserService) VerifyAccount(ctx context.Context, verify_token string) (*
 		}
 	}
 	if user.VerifyCode == "" {
-		slog.Error("Token not found")
+		slog.ErrorContext(ctx, "Token not found")
 		return nil, errWrap.WrapError(errConsts.ErrTokenNotFound)
 	}
 
 	if user.IsVerified == true {
-		slog.Error("user already verified")
+		slog.ErrorContext(ctx, "user already verified")
 		return nil, errWrap.WrapError(errConsts.ErrUserAlreadyVerified)
 	}
 
 	if time.Now().After(user.VerifyExpiresAt) {
-		slog.Error("verify token expired")
+		slog.ErrorContext(ctx, "verify token expired")
 		return nil, errWrap.WrapError(errConsts.ErrVerifyCodeExpired)
 	}
 
-	// Update user verification status
-	updateUser, err := s.r.UserRepo().UpdateUser(ctx, userdb.UpdateUserParams{
-		ID:              user.ID,
-		Name:            user.Name,
-		PhoneNumber:     user.PhoneNumber,
-		Email:           user.Email,
-		Password:        user.Password,
-		IsVerified:      true,
-		VerifyCode:      nil,
-		VerifyExpiresAt: nil,
+	// Update user verification status, audited in the same transaction
+	var updateUser *userdb.UpdateUserRow
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		updated, err := tx.UserRepo().UpdateUser(ctx, userdb.UpdateUserParams{
+			ID:              user.ID,
+			Name:            user.Name,
+			PhoneNumber:     user.PhoneNumber,
+			Email:           user.Email,
+			Password:        user.Password,
+			IsVerified:      true,
+			VerifyCode:      nil,
+			VerifyExpiresAt: nil,
+		})
+		if err != nil {
+			return err
+		}
+		updateUser = updated
+		return tx.AuditRepo().Record(audit.WithUser(ctx, user.ID), audit.Event{
+			Action:     audit.ActionVerify,
+			EntityType: audit.EntityUser,
+			EntityID:   user.ID.String(),
+			Before:     map[string]any{"is_verified": user.IsVerified},
+			After:      map[string]any{"is_verified": updateUser.IsVerified},
+		})
 	})
 	if err != nil {
-		slog.Error("User update failed", "error", err)
+		slog.ErrorContext(ctx, "User update failed", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrUpdatedUser)
 	}
 
-	slog.Debug(success.SuccessCreateUser)
+	slog.DebugContext(ctx, success.SuccessCreateUser)
 
 	// response body
 	response := &userDTO.AuthResponse{
@@ -266,27 +311,30 @@ func (s *UserService) VerifyAccount(ctx context.Context, verify_token string) (*
 
 // Service method for resend verify account
 func (s *UserService) ResendVerifyAccount(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx, "UserService.ResendVerifyAccount")
+	defer span.End()
+
 	// Validation email
 	if email == "" {
-		slog.Error("Email is empty")
+		slog.ErrorContext(ctx, "Email is empty")
 		return errWrap.WrapError(errConsts.ErrUserEmailInvalid)
 	}
 
 	// find user by email
 	findUser, err := s.r.UserRepo().FindUserByEmail(ctx, email)
 	if err != nil {
-		slog.Error("Error finding user by email", "error", err, "email", email)
+		slog.ErrorContext(ctx, "Error finding user by email", "error", err, "email", email)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if findUser == nil {
-		slog.Error("user email not found", "email", email)
+		slog.ErrorContext(ctx, "user email not found", "email", email)
 		return errWrap.WrapError(errConsts.ErrUserEmailNotFound)
 	}
 
 	// check user already verified
 	if findUser.IsVerified == true {
-		slog.Error("user already verified", "email", email)
+		slog.ErrorContext(ctx, "user already verified", "email", email)
 		return errWrap.WrapError(errConsts.ErrUserAlreadyVerified)
 	}
 
@@ -305,80 +353,94 @@ func (s *UserService) ResendVerifyAccount(ctx context.Context, email string) err
 		VerifyExpiresAt: &expiredAt,
 	}
 
-	// execute update user
-	updatedUser, err := s.r.UserRepo().UpdateUser(ctx, payload)
-	if err != nil {
-		slog.Error("error updating user", "error", err)
-		return errWrap.WrapError(errConsts.ErrUpdatedUser)
-	}
-
-	slog.Debug(success.SuccessResendVerifyAccount)
-
-	// send email verification and email body
-site := "http://localhost:3002"
+	// email verification and email body
+	site := s.cfg.ClientURL
 	verificationLink := fmt.Sprintf(site+"/verify-account?verify_token=%s", verifyToken)
-	emailBody := fmt.Sprintf("Thank you for registering with Bizpos. Please verify your account by clicking the link below:\n\n%s\n\nThis link will expire in 24 hours.", verificationLink)
+	mailLocale := i18n.Locale(ctx, findUser.Locale)
+	emailBody := i18n.Translate(mailLocale, locales.MailVerifyBody, verificationLink)
 
-	// create send email
-	errMail := emails.SendEmail([]string{updatedUser.Email}, nil,
-		"Verify Your Account",
-		emailBody)
-	if errMail != nil {
-		slog.Error("error sending email", "error", errMail)
+	// execute update user, enqueueing the email in the same transaction
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		updatedUser, err := tx.UserRepo().UpdateUser(ctx, payload)
+		if err != nil {
+			slog.ErrorContext(ctx, "error updating user", "error", err)
+			return errWrap.WrapError(errConsts.ErrUpdatedUser)
+		}
+		return tx.OutboxRepo().Enqueue(ctx, outbox.NewEmail([]string{updatedUser.Email},
+			i18n.Translate(mailLocale, locales.MailVerifySubject),
+			emailBody))
+	})
+	if err != nil {
+		return err
 	}
 
+	slog.DebugContext(ctx, success.SuccessResendVerifyAccount)
+
 	return nil
 }
 
 // Service method for login
 func (s *UserService) LoginUser(ctx context.Context, req *userDTO.LoginDTO, clientIP string) (*userDTO.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.LoginUser")
+	defer span.End()
+
 	// check email if already exists
 	// Validation email
 	if req.Email == "" {
-		slog.Error("Email is empty")
+		slog.ErrorContext(ctx, "Email is empty")
 		return nil, errWrap.WrapError(errConsts.ErrUserEmailInvalid)
 	}
 
 	// find user by email
 	findUser, err := s.r.UserRepo().FindUserByEmail(ctx, req.Email)
 	if err != nil {
-		slog.Error("Error finding user by email", "error", err, "email", req.Email)
+		slog.ErrorContext(ctx, "Error finding user by email", "error", err, "email", req.Email)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	if findUser == nil {
-		slog.Error("user not found", "email", req.Email)
+		slog.ErrorContext(ctx, "user not found", "email", req.Email)
+		metrics.ObserveLoginFailure(metrics.ReasonUnknownUser)
 		return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
 	}
 
 	// check user already verified
 	if findUser.IsVerified == false {
-		slog.Error("user not verified", "email", req.Email)
+		slog.ErrorContext(ctx, "user not verified", "email", req.Email)
+		metrics.ObserveLoginFailure(metrics.ReasonUnverified)
 		return nil, errWrap.WrapError(errConsts.ErrUserNotVerified)
 	}
 
 	// verify password
 	_, err = config.VerifyPassword(req.Password, findUser.Password)
 	if err != nil {
-		slog.Error("password is invalid", "error", err)
+		slog.ErrorContext(ctx, "password is invalid", "error", err)
+		metrics.ObserveLoginFailure(metrics.ReasonInvalidPassword)
 		return nil, errWrap.WrapError(errConsts.ErrInvalidCredentials)
 	}
 
 	// generate expired access token and refresh token
-	accessTTL := 15 * time.Minute
-	refreshTTL := 7 * 24 * time.Hour
+	accessTTL := s.cfg.JWT.AccessTTL
+	refreshTTL := s.cfg.JWT.RefreshTTL
+
+	// resolve permissions version for the access token claims
+	permVersion, err := s.r.RolePermissionRepo().GetPermissionsVersion(ctx, findUser.RoleID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to resolve permissions version", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
 
 	// generate access token
-	accessToken, err := jwt.GenerateAccessToken(findUser.ID, findUser.RoleName, accessTTL)
+	accessToken, err := s.signer.GenerateAccessToken(findUser.ID, findUser.RoleName, permVersion, findUser.Locale, accessTTL)
 	if err != nil {
-		slog.Error("failed to generate access token", "error", err)
+		slog.ErrorContext(ctx, "failed to generate access token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// generate refresh token
-	refreshToken, err := jwt.GenerateRefreshToken(findUser.ID, findUser.RoleName, refreshTTL)
+	refreshToken, err := s.signer.GenerateRefreshToken(findUser.ID, findUser.RoleName, refreshTTL)
 	if err != nil {
-		slog.Error("failed to generate refresh token", "error", err)
+		slog.ErrorContext(ctx, "failed to generate refresh token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
@@ -393,14 +455,15 @@ func (s *UserService) LoginUser(ctx context.Context, req *userDTO.LoginDTO, clie
 	// save new refresh token
 	_, err = s.r.UserRepo().SaveSession(ctx, tokens)
 	if err != nil {
-		slog.Error("Failed to save token", "error", err)
+		slog.ErrorContext(ctx, "Failed to save token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// add Bearer prefix to access token for client usage
 	tokenWithPrefix := fmt.Sprintf("Bearer %s", accessToken)
 
-	slog.Debug(success.SuccessLoginUser)
+	slog.DebugContext(ctx, success.SuccessLoginUser)
+	metrics.ObserveLogin()
 
 	// create auth response
 	response := &userDTO.AuthResponse{
@@ -409,6 +472,7 @@ func (s *UserService) LoginUser(ctx context.Context, req *userDTO.LoginDTO, clie
 		Email:        findUser.Email,
 		IsVerified:   findUser.IsVerified,
 		RoleID:       findUser.RoleID,
+		Locale:       findUser.Locale,
 		Token:        tokenWithPrefix,
 		RefreshToken: refreshToken,
 	}
@@ -418,50 +482,56 @@ func (s *UserService) LoginUser(ctx context.Context, req *userDTO.LoginDTO, clie
 
 // Service method for logout
 func (s *UserService) Logout(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "UserService.Logout")
+	defer span.End()
+
 	// find token by user id
 	findToken, err := s.r.UserRepo().FindSessionByUserId(ctx, userID)
 	if err != nil {
-		slog.Error("Failed to find token", "error", err)
+		slog.ErrorContext(ctx, "Failed to find token", "error", err)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// check if token is nil
 	if findToken == nil {
-		slog.Error("token not found", "error", err)
+		slog.ErrorContext(ctx, "token not found", "error", err)
 		return errWrap.WrapError(errConsts.ErrTokenNotFound)
 	}
 
 	// delete token by user id
 	err = s.r.UserRepo().DeleteSession(ctx, findToken.RefToken)
 	if err != nil {
-		slog.Error("Failed to delete token", "error", err)
+		slog.ErrorContext(ctx, "Failed to delete token", "error", err)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
-	slog.Debug(success.SuccessLogoutUser)
+	slog.DebugContext(ctx, success.SuccessLogoutUser)
 
 	return nil
 }
 
 // Service method for Get user
 func (s *UserService) GetUser(ctx context.Context, userID uuid.UUID) (*userDTO.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
 	// find user if exists
 	findUser, err := s.r.UserRepo().FindUserById(ctx, userID)
 	if err != nil {
-		slog.Error("Failed to find user", "error", err)
+		slog.ErrorContext(ctx, "Failed to find user", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
 	}
 	if findUser == nil {
-		slog.Error("user not found", "error", err)
+		slog.ErrorContext(ctx, "user not found", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
 	}
 
 	if findUser.IsVerified == false {
-		slog.Error("user not verified", "error", err)
+		slog.ErrorContext(ctx, "user not verified", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrUserNotVerified)
 	}
 
-	slog.Debug(success.SuccessFindUser)
+	slog.DebugContext(ctx, success.SuccessFindUser)
 	// response body
 	response := &userDTO.AuthResponse{
 		ID:         findUser.ID,
@@ -469,6 +539,7 @@ func (s *UserService) GetUser(ctx context.Context, userID uuid.UUID) (*userDTO.A
 		Email:      findUser.Email,
 		IsVerified: findUser.IsVerified,
 		RoleID:     findUser.RoleID,
+		Locale:     findUser.Locale,
 		RoleDetail: &userDTO.RoleResponse{
 			Name:            findUser.RoleName,
 			Code:            findUser.RoleCode,
@@ -482,55 +553,67 @@ func (s *UserService) GetUser(ctx context.Context, userID uuid.UUID) (*userDTO.A
 
 // Service method for refresh token
 func (s *UserService) RefreshToken(ctx context.Context, token string, clientIP string) (*userDTO.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.RefreshToken")
+	defer span.End()
+
 	// check if refresh token is valid
 	findToken, err := s.r.UserRepo().FindSessions(ctx, token)
 	if err != nil {
-		slog.Error("Failed to get token", "error", err)
+		slog.ErrorContext(ctx, "Failed to get token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	if findToken == nil {
-		slog.Error("token not found", "error", err)
+		slog.ErrorContext(ctx, "token not found", "error", err)
+		metrics.ObserveTokenRefresh(metrics.OutcomeRejected)
 		return nil, errWrap.WrapError(errConsts.ErrTokenNotFound)
 	}
 
 	// check if token expired
 	if findToken.ExpiresAt.Before(time.Now()) {
-		slog.Error("token expired", "error", err)
+		slog.ErrorContext(ctx, "token expired", "error", err)
+		metrics.ObserveTokenRefresh(metrics.OutcomeRejected)
 		return nil, errWrap.WrapError(errConsts.ErrTokenExpired)
 	}
 
 	// get user to access role
 	findUser, err := s.r.UserRepo().FindUserById(ctx, findToken.UserID)
 	if err != nil {
-		slog.Error("Failed to get user", "error", err)
+		slog.ErrorContext(ctx, "Failed to get user", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 	if findUser == nil {
-		slog.Error("user not found", "error", err)
+		slog.ErrorContext(ctx, "user not found", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
 	}
 
 	// generate new access token
-	accessTTL := 15 * time.Minute
-	refreshTTL := 7 * 24 * time.Hour
+	accessTTL := s.cfg.JWT.AccessTTL
+	refreshTTL := s.cfg.JWT.RefreshTTL
+
+	// resolve permissions version for the access token claims
+	permVersion, err := s.r.RolePermissionRepo().GetPermissionsVersion(ctx, findUser.RoleID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to resolve permissions version", "error", err)
+		return nil, errWrap.WrapError(errConsts.ErrSQLError)
+	}
 
-	accessToken, err := jwt.GenerateAccessToken(findUser.ID, findUser.RoleName, accessTTL)
+	accessToken, err := s.signer.GenerateAccessToken(findUser.ID, findUser.RoleName, permVersion, findUser.Locale, accessTTL)
 	if err != nil {
-		slog.Error("failed to generate access token", "error", err)
+		slog.ErrorContext(ctx, "failed to generate access token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// generate new refresh token
-	newRefreshToken, err := jwt.GenerateRefreshToken(findUser.ID, findUser.RoleName, refreshTTL)
+	newRefreshToken, err := s.signer.GenerateRefreshToken(findUser.ID, findUser.RoleName, refreshTTL)
 	if err != nil {
-		slog.Error("failed to generate refresh token", "error", err)
+		slog.ErrorContext(ctx, "failed to generate refresh token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// delete old refresh token
 	err = s.r.UserRepo().DeleteSession(ctx, findToken.RefToken)
 	if err != nil {
-		slog.Error("failed to delete token", "error", err)
+		slog.ErrorContext(ctx, "failed to delete token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
@@ -544,14 +627,15 @@ func (s *UserService) RefreshToken(ctx context.Context, token string, clientIP s
 	// save new refresh token
 	_, err = s.r.UserRepo().SaveSession(ctx, tokens)
 	if err != nil {
-		slog.Error("Failed to save token", "error", err)
+		slog.ErrorContext(ctx, "Failed to save token", "error", err)
 		return nil, errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
 	// add Bearer prefix to access token for client usage
 	tokenWithPrefix := fmt.Sprintf("Bearer %s", accessToken)
 
-	slog.Debug(success.SuccessLoginUser)
+	slog.DebugContext(ctx, success.SuccessLoginUser)
+	metrics.ObserveTokenRefresh(metrics.OutcomeOK)
 
 	// create auth response
 	response := &userDTO.AuthResponse{
@@ -560,6 +644,7 @@ func (s *UserService) RefreshToken(ctx context.Context, token string, clientIP s
 		Email:        findUser.Email,
 		IsVerified:   findUser.IsVerified,
 		RoleID:       findUser.RoleID,
+		Locale:       findUser.Locale,
 		Token:        tokenWithPrefix,
 		RefreshToken: newRefreshToken,
 	}
@@ -569,19 +654,22 @@ func (s *UserService) RefreshToken(ctx context.Context, token string, clientIP s
 
 // Service method for forgot password
 func (s *UserService) ForgotPassword(ctx context.Context, req *userDTO.EmailRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.ForgotPassword")
+	defer span.End()
+
 	// check email if already exists
 	findUser, err := s.r.UserRepo().FindUserByEmail(ctx, req.Email)
 	if err != nil {
-		slog.Error("error finding user by email", "error", err)
+		slog.ErrorContext(ctx, "error finding user by email", "error", err)
 		return errWrap.WrapError(errConsts.ErrUserEmailNotFound)
 	}
 	if findUser == nil {
-		slog.Error("user already exists", "email", req.Email)
+		slog.ErrorContext(ctx, "user already exists", "email", req.Email)
 		return errWrap.WrapError(errConsts.ErrUserAlreadyExists)
 	}
 
 	if findUser.IsVerified == false {
-		slog.Error("user not verified", "email", req.Email)
+		slog.ErrorContext(ctx, "user not verified", "email", req.Email)
 		return errWrap.WrapError(errConsts.ErrUserNotVerified)
 	}
 
@@ -600,26 +688,25 @@ func (s *UserService) ForgotPassword(ctx context.Context, req *userDTO.EmailRequ
 		VerifyExpiresAt: &expiredAt,
 	}
 
-	_, err = s.r.UserRepo().UpdateUser(ctx, payload)
-	if err != nil {
-		slog.Error("error updating user", "error", err)
-		return errWrap.WrapError(errConsts.ErrSQLError)
-	}
-
-	// Send forgot password email
-	site := "http://localhost:3002"
+	// forgot password email
+	site := s.cfg.ClientURL
 	verificationLink := fmt.Sprintf(site+"/reset-password?verify_token=%s", forgotToken)
-	emailBody := fmt.Sprintf("You have requested to reset your password. Please click the link below to reset your password:\n\n%s\n\nThis link will expire in 24 hours.", verificationLink)
-
-	// Send email
-	errMail := emails.SendEmail([]string{findUser.Email}, nil,
-		"Reset Password",
-		emailBody)
-	if errMail != nil {
-		slog.Error("error sending forgot password email", "error", errMail)
-		return errWrap.WrapError(errConsts.ErrSQLError)
-	} else {
-		slog.Debug(success.SuccessEmailSent)
+	mailLocale := i18n.Locale(ctx, findUser.Locale)
+	emailBody := i18n.Translate(mailLocale, locales.MailResetPasswordBody, verificationLink)
+
+	// store the token and enqueue the email in the same transaction, so a mail server
+	// outage delays the email instead of failing the request
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		if _, err := tx.UserRepo().UpdateUser(ctx, payload); err != nil {
+			slog.ErrorContext(ctx, "error updating user", "error", err)
+			return errWrap.WrapError(errConsts.ErrSQLError)
+		}
+		return tx.OutboxRepo().Enqueue(ctx, outbox.NewEmail([]string{findUser.Email},
+			i18n.Translate(mailLocale, locales.MailResetPasswordSubject),
+			emailBody))
+	})
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -627,66 +714,178 @@ func (s *UserService) ForgotPassword(ctx context.Context, req *userDTO.EmailRequ
 
 // Service method for reset password
 func (s *UserService) ResetPassword(ctx context.Context, req *userDTO.ResetPasswordDTO) error {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword")
+	defer span.End()
+
 	// check verify code exists
 	findVerifyCode, err := s.r.UserRepo().FindUserByVerify(ctx, req.VerifyCode)
 	if err != nil {
 		if errors.Is(err, errConsts.ErrUserNotFound) {
 			return errWrap.WrapError(errConsts.ErrVerifyCodeNotFound)
 		}
-		slog.Error("error finding verify code", "error", err)
+		slog.ErrorContext(ctx, "error finding verify code", "error", err)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
-	slog.Debug("verify code found", "verify code", findVerifyCode)
+	slog.DebugContext(ctx, "verify code found", "user_id", findVerifyCode.ID)
 
 	// check verify code expired
 	if findVerifyCode.VerifyExpiresAt.Before(time.Now()) {
-		slog.Error("verify code expired", "verify code", req.VerifyCode)
+		slog.ErrorContext(ctx, "verify code expired", "user_id", findVerifyCode.ID)
 		return errWrap.WrapError(errConsts.ErrVerifyCodeExpired)
 	}
 
 	// check if password and retry password not match
 	if req.Password != req.RetryPassword {
-		slog.Error("password and retry password not match", "password", req.Password)
+		slog.ErrorContext(ctx, "password and retry password not match", "user_id", findVerifyCode.ID)
 		return errWrap.WrapError(errConsts.ErrUserPasswordNotMatch)
 	}
 
 	// hash password
 	hashPassword, err := config.HashPassword(req.Password)
 	if err != nil {
-		slog.Error("error hashing password", "error", err)
+		slog.ErrorContext(ctx, "error hashing password", "error", err)
 		return errWrap.WrapError(errConsts.ErrSQLError)
 	}
 
-	// execute update user
-	_, err = s.r.UserRepo().UpdateUser(ctx, userdb.UpdateUserParams{
-		ID:              findVerifyCode.ID,
-		Name:            findVerifyCode.Name,
-		Email:           findVerifyCode.Email,
-		PhoneNumber:     findVerifyCode.PhoneNumber,
-		Password:        hashPassword,
-		IsVerified:      true,
-		VerifyCode:      nil,
-		VerifyExpiresAt: nil,
+	// reset success email
+	mailLocale := i18n.Locale(ctx, findVerifyCode.Locale)
+	emailBody := i18n.Translate(mailLocale, locales.MailResetPasswordSuccessBody)
+
+	// execute update user, audited and the email enqueued in the same transaction;
+	// the password itself is redacted
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		_, err := tx.UserRepo().UpdateUser(ctx, userdb.UpdateUserParams{
+			ID:              findVerifyCode.ID,
+			Name:            findVerifyCode.Name,
+			Email:           findVerifyCode.Email,
+			PhoneNumber:     findVerifyCode.PhoneNumber,
+			Password:        hashPassword,
+			IsVerified:      true,
+			VerifyCode:      nil,
+			VerifyExpiresAt: nil,
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.AuditRepo().Record(audit.WithUser(ctx, findVerifyCode.ID), audit.Event{
+			Action:     audit.ActionResetPassword,
+			EntityType: audit.EntityUser,
+			EntityID:   findVerifyCode.ID.String(),
+			Before:     map[string]any{"password": findVerifyCode.Password, "is_verified": findVerifyCode.IsVerified},
+			After:      map[string]any{"password": hashPassword, "is_verified": true},
+		})
+		if err != nil {
+			return err
+		}
+		return tx.OutboxRepo().Enqueue(ctx, outbox.NewEmail([]string{findVerifyCode.Email},
+			i18n.Translate(mailLocale, locales.MailResetPasswordSuccessSubject),
+			emailBody))
 	})
 	if err != nil {
-		slog.Error("User update failed", "error", err)
+		slog.ErrorContext(ctx, "User update failed", "error", err)
 		return errWrap.WrapError(errConsts.ErrUpdatedUser)
 	}
 
-	slog.Debug(success.SuccessResetPassword)
+	slog.DebugContext(ctx, success.SuccessResetPassword)
+
+	return nil
+}
 
-	// Send reset success email
-	emailBody := "You have successfully reset your password."
+// Service method for updating the user's preferred locale; an empty locale clears it.
+// Access tokens carry the locale, so API messages follow it from the next token refresh.
+func (s *UserService) UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateLocale")
+	defer span.End()
 
-	// Send email
-	errMail := emails.SendEmail([]string{findVerifyCode.Email}, nil,
-		"Reset Password Success",
-		emailBody)
-	if errMail != nil {
-		slog.Error("error sending password reset email", "error", errMail)
-		// We don't return error here as password is already reset
+	locale, err := resolveLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	findUser, err := s.r.UserRepo().FindUserById(ctx, userID)
+	if err != nil || findUser == nil {
+		slog.ErrorContext(ctx, "User not found", "error", err, "user_id", userID)
+		return errWrap.WrapError(errConsts.ErrUserNotFound)
 	}
-	slog.Debug(success.SuccessEmailSent)
 
+	// update locale, audited in the same transaction
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		if err := tx.UserRepo().UpdateUserLocale(ctx, userID, locale); err != nil {
+			return err
+		}
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityUser,
+			EntityID:   userID.String(),
+			Before:     map[string]any{"locale": findUser.Locale},
+			After:      map[string]any{"locale": locale},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, success.SuccessUpdateLocale, "locale", locale)
 	return nil
 }
+
+// Service method for partially updating the caller's profile; fields absent from req are left unchanged.
+func (s *UserService) PatchProfile(ctx context.Context, userID uuid.UUID, req userDTO.ProfilePatchDTO) (*userDTO.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.PatchProfile")
+	defer span.End()
+
+	findUser, err := s.r.UserRepo().FindUserById(ctx, userID)
+	if err != nil || findUser == nil {
+		slog.ErrorContext(ctx, "User not found", "error", err, "user_id", userID)
+		return nil, errWrap.WrapError(errConsts.ErrUserNotFound)
+	}
+
+	// patch profile, audited in the same transaction
+	var user *userdb.PatchUserRow
+	err = s.r.ExecTx(ctx, func(tx repo.IRepo) error {
+		patched, err := tx.UserRepo().PatchUser(ctx, userID, req.Name, req.PhoneNumber)
+		if err != nil {
+			return err
+		}
+		user = patched
+		return tx.AuditRepo().Record(ctx, audit.Event{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityUser,
+			EntityID:   userID.String(),
+			Before:     map[string]any{"name": findUser.Name, "phone_number": findUser.PhoneNumber},
+			After:      map[string]any{"name": user.Name, "phone_number": user.PhoneNumber},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, success.SuccessUpdateUser, "user_id", userID)
+
+	response := &userDTO.AuthResponse{
+		ID:          user.ID,
+		Name:        user.Name,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		IsVerified:  user.IsVerified,
+		RoleID:      user.RoleID,
+		Locale:      user.Locale,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+	}
+
+	return response, nil
+}
+
+// resolveLocale normalizes a locale preference ("id-ID" to "id"); an empty preference stays empty
+func resolveLocale(locale string) (string, error) {
+	if locale == "" {
+		return "", nil
+	}
+	if bundle := i18n.Default(); bundle != nil {
+		if supported, ok := bundle.Supported(locale); ok {
+			return supported, nil
+		}
+	}
+	return "", errWrap.WrapError(errConsts.ErrLocaleUnsupported)
+}
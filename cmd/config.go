@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"medisuite-api/config"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the application configuration",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration after files, env vars and flags are applied",
+	Run:   runConfigPrint,
+}
+
+func init() {
+	configPrintCmd.Flags().Bool("redacted", false, "mask secrets such as passwords and signing keys")
+	configCmd.AddCommand(configPrintCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigPrint(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cmd.Flags())
+	if err != nil {
+		slog.Error("Failed to load config", "err", err)
+		os.Exit(1)
+	}
+
+	if redacted, _ := cmd.Flags().GetBool("redacted"); redacted {
+		cfg = cfg.Redacted()
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		slog.Error("Failed to encode config", "err", err)
+		os.Exit(1)
+	}
+	fmt.Print(string(out))
+
+	// print still succeeds so an invalid config can be inspected
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "\nconfig is invalid:\n%v\n", err)
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"medisuite-api/app/repo"
+	"medisuite-api/config"
+	"medisuite-api/infra/databases"
+	"medisuite-api/pkg/fieldcrypt"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var encryptionCmd = &cobra.Command{
+	Use:   "encryption",
+	Short: "Manage the keyfile for encrypted columns",
+}
+
+var encryptionInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a new keyfile with a fresh master key and blind index key",
+	Run:   runEncryptionInit,
+}
+
+var encryptionRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Add a new master key to the configured keyfile and make it active",
+	Long: "Add a new master key to the configured keyfile and make it active.\n" +
+		"Older keys stay in the file so existing values remain readable; run\n" +
+		"`encryption reencrypt` afterwards to move every value onto the new key.",
+	Run: runEncryptionRotate,
+}
+
+var encryptionReencryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "Move every encrypted column onto the active master key",
+	Long: "Move every encrypted column onto the active master key, encrypting values\n" +
+		"still stored as plaintext and refreshing their blind indexes. It is safe to\n" +
+		"run repeatedly and while the server is up: values that are already current are\n" +
+		"left alone, and rows changed after they were read are skipped, not overwritten.",
+	Run: runEncryptionReencrypt,
+}
+
+func init() {
+	encryptionInitCmd.Flags().String("out", "", "path of the keyfile to create (defaults to encryption.key_file)")
+	encryptionReencryptCmd.Flags().Int32("batch-size", 500, "rows re-encrypted per query")
+	encryptionCmd.AddCommand(encryptionInitCmd, encryptionRotateCmd, encryptionReencryptCmd)
+	rootCmd.AddCommand(encryptionCmd)
+}
+
+func runEncryptionInit(cmd *cobra.Command, args []string) {
+	path, _ := cmd.Flags().GetString("out")
+	if path == "" {
+		cfg, err := config.Load(cmd.Flags())
+		if err != nil {
+			slog.Error("Failed to load config", "err", err)
+			os.Exit(1)
+		}
+		path = cfg.Encryption.KeyFile
+	}
+	if path == "" {
+		slog.Error("No keyfile path; set --out or encryption.key_file")
+		os.Exit(1)
+	}
+
+	// never replace an existing keyfile, its keys may still be needed to decrypt data
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		slog.Error("Keyfile already exists", "path", path, "err", err)
+		os.Exit(1)
+	}
+
+	f, err := fieldcrypt.NewKeyFile()
+	if err != nil {
+		slog.Error("Failed to generate keys", "err", err)
+		os.Exit(1)
+	}
+	if err := f.Write(path); err != nil {
+		slog.Error("Failed to write keyfile", "path", path, "err", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created keyfile %s with master key %s\n", path, f.Active)
+}
+
+func runEncryptionRotate(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cmd.Flags())
+	if err != nil {
+		slog.Error("Failed to load config", "err", err)
+		os.Exit(1)
+	}
+
+	f, err := fieldcrypt.ReadKeyFile(cfg.Encryption.KeyFile)
+	if err != nil {
+		slog.Error("Failed to read keyfile", "path", cfg.Encryption.KeyFile, "err", err)
+		os.Exit(1)
+	}
+	id, err := f.Rotate()
+	if err != nil {
+		slog.Error("Failed to rotate master key", "err", err)
+		os.Exit(1)
+	}
+	if err := f.Write(cfg.Encryption.KeyFile); err != nil {
+		slog.Error("Failed to write keyfile", "path", cfg.Encryption.KeyFile, "err", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Master key %s is now active; restart the server, then run `encryption reencrypt`\n", id)
+}
+
+func runEncryptionReencrypt(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cmd.Flags())
+	if err != nil {
+		slog.Error("Failed to load config", "err", err)
+		os.Exit(1)
+	}
+	if err := cfg.Validate(); err != nil {
+		slog.Error("Invalid config", "err", err)
+		os.Exit(1)
+	}
+	batchSize, _ := cmd.Flags().GetInt32("batch-size")
+	if batchSize <= 0 {
+		slog.Error("--batch-size must be positive")
+		os.Exit(1)
+	}
+
+	keyring, err := fieldcrypt.Load(cfg.Encryption.KeyFile)
+	if err != nil {
+		slog.Error("Failed to load encryption keyfile", "err", err)
+		os.Exit(1)
+	}
+
+	db, err := databases.InitDB(cfg.Database)
+	if err != nil {
+		slog.Error("Failed to initialize database", "err", err)
+		os.Exit(1)
+	}
+	defer databases.CloseDB(db)
+
+	r := repo.NewRepo(repo.NewStore(db), 0, keyring)
+	ctx := context.Background()
+
+	scanned, updated, skipped := 0, 0, 0
+	afterID := uuid.Nil
+	for {
+		result, err := r.UserRepo().ReencryptPhoneNumbers(ctx, afterID, batchSize)
+		if err != nil {
+			slog.Error("Failed to re-encrypt phone numbers", "after_id", afterID, "err", err)
+			databases.CloseDB(db)
+			os.Exit(1)
+		}
+		scanned += result.Scanned
+		updated += result.Updated
+		skipped += result.Skipped
+		if result.LastID == uuid.Nil {
+			break
+		}
+		afterID = result.LastID
+	}
+	fmt.Printf("users.phone_number: scanned %d, re-encrypted %d onto master key %s, skipped %d changed meanwhile\n", scanned, updated, keyring.ActiveKeyID(), skipped)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"medisuite-api/constants/locales"
+
+	"github.com/spf13/cobra"
+)
+
+var localesCmd = &cobra.Command{
+	Use:   "locales",
+	Short: "Check that every message key exists in each supported locale",
+	Run:   runLocales,
+}
+
+func init() {
+	rootCmd.AddCommand(localesCmd)
+}
+
+func runLocales(cmd *cobra.Command, args []string) {
+	if err := locales.Check(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println("All locales are complete")
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"os"
 
+	"medisuite-api/config"
+
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +14,11 @@ var rootCmd = &cobra.Command{
 	Long:  "Medisuite API Server",
 }
 
+func init() {
+	// --config and per-setting overrides such as --database.host apply to every command
+	config.RegisterFlags(rootCmd.PersistentFlags())
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"medisuite-api/api/handler"
+	"medisuite-api/common/cookies"
+	"medisuite-api/config"
+	"medisuite-api/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+)
+
+var routesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "List every API route with its method, path and required permission",
+	Run:   runRoutes,
+}
+
+func init() {
+	rootCmd.AddCommand(routesCmd)
+}
+
+func runRoutes(cmd *cobra.Command, args []string) {
+	// Routes are only registered, never served, so no services or database are needed
+	gin.SetMode(gin.ReleaseMode)
+	cfg := config.Default()
+	session := cookies.NewSession(cfg.Cookie, cfg.JWT.RefreshTTL, "")
+	_, reg, err := setupRouter(cfg, handler.NewHandler(nil, session), nil, jwt.NewSigner("", ""), session)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tPERMISSION\tMIN ROLE")
+	for _, route := range reg.Describe() {
+		permission := "-"
+		if route.Module != "" {
+			permission = route.Module + ":" + route.Action
+		}
+		minRole := "-"
+		if route.MinRole != "" {
+			minRole = route.MinRole
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", route.Method, route.Path, permission, minRole)
+	}
+	w.Flush()
+}
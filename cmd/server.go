@@ -1,23 +1,43 @@
 package cmd
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"medisuite-api/api/handler"
 	"medisuite-api/api/routes"
+	"medisuite-api/api/routes/registry"
 	"medisuite-api/app/repo"
 	"medisuite-api/app/services"
+	"medisuite-api/common/cookies"
+	"medisuite-api/common/emails"
 	"medisuite-api/common/middlewares"
+	"medisuite-api/config"
+	"medisuite-api/constants/locales"
 	"medisuite-api/infra/databases"
-
-	dbconsts "medisuite-api/constants/databases"
+	infraEmails "medisuite-api/infra/emails"
+	infraTracing "medisuite-api/infra/tracing"
+	"medisuite-api/pkg/audit"
+	"medisuite-api/pkg/background"
+	"medisuite-api/pkg/cors"
+	"medisuite-api/pkg/fieldcrypt"
+	"medisuite-api/pkg/i18n"
+	"medisuite-api/pkg/jwt"
+	"medisuite-api/pkg/logs"
+	"medisuite-api/pkg/metrics"
+	"medisuite-api/pkg/tracing"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/jackc/pgx/v5/pgxpool"
 	goose "github.com/pressly/goose/v3"
 	"github.com/spf13/cobra"
 
@@ -27,101 +47,261 @@ import (
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the Medisuite API server",
-	Run:   runServer,
+	// setup failures are returned, so the process exits non-zero without printing the usage
+	RunE:         runServer,
+	SilenceUsage: true,
 }
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
 }
 
-func runServer(cmd *cobra.Command, args []string) {
-	env := os.Getenv("GO_ENV")
-	if env == "" {
-		env = "development"
+func runServer(cmd *cobra.Command, args []string) error {
+	// Load and validate configuration
+	cfg, err := config.Load(cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	// Structured, redacted logging for everything from here on
+	level, _ := cfg.Log.SlogLevel()
+	slog.SetDefault(logs.NewLogger(os.Stdout, cfg.Log.Format, level))
 
-	// Load environment variables
-	err := godotenv.Load(".env." + env)
+	// Initialize tracing before anything that creates spans
+	shutdownTracing, err := infraTracing.InitTracing(context.Background(), cfg.Tracing, cfg.Environment)
 	if err != nil {
-		slog.Error("Warning: Error loading .env." + env + " file")
-		// Try to load .env as fallback
-		err = godotenv.Load(".env")
-		if err != nil {
-			slog.Error("Error: No .env file found")
-		}
+		return fmt.Errorf("failed to initialize tracing: %w", err)
 	}
 
 	// Initialize database
-	db, err := databases.InitDB()
+	db, err := databases.InitDB(cfg.Database)
 	if err != nil {
-		slog.Error("Failed to initialize database", "err", err)
-		return
+		_ = shutdownTracing(context.Background())
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	if err := metrics.RegisterPool(db); err != nil {
+		slog.Warn("Failed to register database pool metrics", "err", err)
 	}
-	defer databases.CloseDB(db)
+
+	// Load the keys for encrypted columns
+	keyring, err := fieldcrypt.Load(cfg.Encryption.KeyFile)
+	if err != nil {
+		databases.CloseDB(db)
+		_ = shutdownTracing(context.Background())
+		return fmt.Errorf("failed to load encryption keyfile: %w", err)
+	}
+
+	signer := jwt.NewSigner(cfg.JWT.Secret, cfg.JWT.RefreshSecret)
+	mailer := emails.NewService(infraEmails.NewSMTPSender(cfg.SMTP))
+	bg := background.NewGroup()
 
 	store := repo.NewStore(db)
-	repo := repo.NewRepo(store)
-	service := services.NewService(repo)
-	handler := handler.NewHandler(service)
+	repo := repo.NewRepo(store, cfg.Authz.PermissionCacheTTL, keyring)
+	service := services.NewService(repo, cfg, signer, mailer)
+	session := cookies.NewSession(cfg.Cookie, cfg.JWT.RefreshTTL, cfg.JWT.Secret)
+	handler := handler.NewHandler(service, session)
 
 	// Run migrations
-	if err := runMigrations(); err != nil {
-		slog.Error("Migration failed", "err", err)
-		return
+	if err := runMigrations(cfg); err != nil {
+		databases.CloseDB(db)
+		_ = shutdownTracing(context.Background())
+		return fmt.Errorf("migration failed: %w", err)
 	}
 
-	// Initialize Gin
-	r := gin.Default()
-	r.Use(middlewares.HandlePanic())
+	// Initialize Gin and register routes
+	r, reg, err := setupRouter(cfg, handler, repo, signer, session)
+	if err != nil {
+		databases.CloseDB(db)
+		_ = shutdownTracing(context.Background())
+		return fmt.Errorf("failed to set up router: %w", err)
+	}
+
+	// Warn about permissions referenced by routes but missing from the database
+	reg.WarnMissingPermissions(context.Background(), repo)
+
+	srv := &http.Server{
+		Addr:              ":" + strconv.Itoa(cfg.Server.Port),
+		Handler:           r,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+
+	// Start server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Delete expired idempotency keys until shutdown
+	if cfg.Idempotency.PurgeInterval > 0 {
+		bg.Go("idempotency-purge", func() {
+			purgeIdempotencyKeys(ctx, repo, cfg.Idempotency.PurgeInterval)
+		})
+	}
+
+	// Deliver outbox messages such as emails until shutdown
+	bg.Go("outbox-dispatcher", func() {
+		dispatchOutbox(ctx, service, cfg.Outbox)
+	})
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Debug("Server running on " + srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	var runErr error
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			runErr = fmt.Errorf("failed to start server: %w", err)
+		}
+	case <-ctx.Done():
+		slog.Info("Shutdown signal received, draining requests")
+	}
+	stop()
+
+	shutdown(cfg, srv, bg, db, shutdownTracing)
+	return runErr
+}
+
+// shutdown stops accepting connections, drains in-flight requests, waits for background
+// work such as outgoing emails, closes the database pool and finally flushes pending spans,
+// all within cfg.Server.ShutdownTimeout
+func shutdown(cfg *config.AppConfig, srv *http.Server, bg *background.Group, db *pgxpool.Pool, shutdownTracing func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Error("HTTP server did not shut down cleanly", "err", err)
+	}
+	if err := bg.Wait(ctx); err != nil {
+		slog.Error("Background work did not finish before shutdown timeout", "err", err)
+	}
+	databases.CloseDB(db)
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "err", err)
+	}
+
+	slog.Info("Server stopped")
+}
 
-	// CORS middleware
-	r.Use(func(c *gin.Context) {
-		origin := c.GetHeader("Origin")
-		if origin == "" {
-			origin = "*"
+// purgeIdempotencyKeys deletes expired idempotency keys every interval until ctx is done
+func purgeIdempotencyKeys(ctx context.Context, repository repo.IRepo, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := repository.IdempotencyRepo().DeleteExpired(ctx)
+			if err == nil && deleted > 0 {
+				slog.Info("Purged expired idempotency keys", "deleted", deleted)
+			}
 		}
-		c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+	}
+}
+
+// dispatchOutbox delivers due outbox messages every cfg.PollInterval until ctx is done; a full
+// batch is followed by the next one right away. A batch in progress is finished on shutdown.
+func dispatchOutbox(ctx context.Context, service services.IService, cfg config.OutboxConfig) {
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+		}
+
+		for ctx.Err() == nil {
+			claimed, err := service.OutboxService().Dispatch(context.WithoutCancel(ctx))
+			if err != nil {
+				slog.Error("Failed to dispatch outbox messages", "err", err)
+				break
+			}
+			if claimed < cfg.BatchSize {
+				break
+			}
 		}
-		c.Next()
+	}
+}
+
+// setupRouter creates the gin engine with global middlewares and every API route,
+// and returns the registry describing each route's required permission
+func setupRouter(cfg *config.AppConfig, handler handler.IHandler, repo repo.IRepo, signer *jwt.Signer, session *cookies.Session) (*gin.Engine, *registry.Registry, error) {
+	corsPolicy, err := cors.New(cors.Options{
+		AllowedOrigins:   cfg.CORSOrigins(),
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   cfg.CORS.ExposedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
 	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Message catalogs; responses, validation errors and emails are translated with the default bundle
+	bundle, err := locales.NewBundle(cfg.I18n.DefaultLocale)
+	if err != nil {
+		return nil, nil, err
+	}
+	i18n.SetDefault(bundle)
+
+	// Initialize Gin; request logging is done by logs.AccessLog instead of gin's text logger
+	r := gin.New()
+	// handlers pass the *gin.Context to services as their context; let it expose the request
+	// context's values (locale, request ID, audit actor) and cancellation
+	r.ContextWithFallback = true
+	r.Use(logs.RequestID())
+	r.Use(i18n.Middleware(bundle))
+	r.Use(audit.Middleware())
+	r.Use(logs.AccessLog("/healthz", "/readyz", cfg.Metrics.Path))
+	r.Use(middlewares.HandlePanic())
+	r.Use(middlewares.SecurityHeaders(cfg.Security))
+	r.Use(metrics.Middleware())
+	r.Use(tracing.Middleware(cfg.Tracing.ServiceName, "/healthz", "/readyz", cfg.Metrics.Path))
+
+	// CORS: only allowlisted origins may call the API from a browser
+	r.Use(corsPolicy.Middleware())
 
 	// Test route
 	r.GET("/", func(c *gin.Context) {
-		c.String(200, "Medisuite API is running - Welcome to the backend! Environment: "+env)
+		c.String(200, "Medisuite API is running - Welcome to the backend! Environment: "+cfg.Environment)
 	})
 
+	// Liveness and readiness probes
+	r.GET("/healthz", handler.HealthHandler().Liveness)
+	r.GET("/readyz", handler.HealthHandler().Readiness)
+
+	// Prometheus scrape endpoint
+	if cfg.Metrics.Enabled {
+		r.GET(cfg.Metrics.Path, gin.WrapH(metrics.Handler()))
+	}
+
 	// Add your routes here
 	group := r.Group("/api/v1")
-	route := routes.NewRoutes(handler, group, repo)
+	reg := registry.New(repo, r.Routes)
+	route := routes.NewRoutes(handler, group, repo, reg, middlewares.AuthMiddleware(signer), middlewares.RequireCSRF(session), middlewares.Idempotency(repo, cfg.Idempotency))
 	route.Serve()
 
-	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	slog.Debug("Server running on port " + port)
-	if err := r.Run(":" + port); err != nil {
-		slog.Error("Failed to start server", "err", err)
-	}
+	return r, reg, nil
 }
 
-func runMigrations() error {
-	// Allow disabling migrations via env
-	if os.Getenv("MIGRATE_ENABLED") == "false" {
-		slog.Info("Migrations are disabled via MIGRATE_ENABLED=false")
+func runMigrations(cfg *config.AppConfig) error {
+	// Allow disabling migrations via config
+	if !cfg.Migration.Enabled {
+		slog.Info("Migrations are disabled via migration.enabled=false")
 		return nil
 	}
 
-	// Build Postgres DSN from central DB constants
-	dbconsts.LoadDBEnv()
-	dsn := dbconsts.PostgresURL()
+	dsn := cfg.Database.DSN()
 
 	// Open *sql.DB using pgx stdlib driver (no pgx Pool)
 	db, err := sql.Open("pgx", dsn)
@@ -139,10 +319,7 @@ func runMigrations() error {
 		return fmt.Errorf("failed to set goose dialect: %w", err)
 	}
 
-	migrationDir := os.Getenv("MIGRATION_PATH")
-	if migrationDir == "" {
-		migrationDir = "infra/databases/migrations"
-	}
+	migrationDir := cfg.Migration.Path
 
 	// Apply all pending migrations
 	if err := goose.Up(db, migrationDir); err != nil {
@@ -0,0 +1,115 @@
+package cookies
+
+import (
+	"net/http"
+	"time"
+
+	"medisuite-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cookie names before any prefix
+const (
+	RefreshTokenName = "refresh_token"
+	CSRFTokenName    = "csrf_token"
+)
+
+// hostPrefix binds a cookie to the exact host that set it (Secure, no Domain, Path=/)
+const hostPrefix = "__Host-"
+
+// Cookie writes, reads and clears one cookie with the configured attributes,
+// so every handler uses the same name, path, domain, Secure and SameSite settings
+type Cookie struct {
+	name     string
+	path     string
+	domain   string
+	secure   bool
+	httpOnly bool
+	sameSite http.SameSite
+	maxAge   time.Duration
+}
+
+// NewRefreshTokenCookie creates the HttpOnly refresh token cookie, sent only to cfg.Path;
+// maxAge should match the refresh token TTL
+func NewRefreshTokenCookie(cfg config.CookieConfig, maxAge time.Duration) *Cookie {
+	return &Cookie{
+		name:     prefixed(cfg, RefreshTokenName),
+		path:     cfg.Path,
+		domain:   cfg.Domain,
+		secure:   cfg.Secure,
+		httpOnly: true,
+		sameSite: sameSite(cfg.SameSite),
+		maxAge:   maxAge,
+	}
+}
+
+// NewCSRFTokenCookie creates the CSRF token cookie. It is readable by scripts on the site root
+// so a same-site frontend can copy it into the X-CSRF-Token header.
+func NewCSRFTokenCookie(cfg config.CookieConfig, maxAge time.Duration) *Cookie {
+	return &Cookie{
+		name:     prefixed(cfg, CSRFTokenName),
+		path:     "/",
+		domain:   cfg.Domain,
+		secure:   cfg.Secure,
+		sameSite: sameSite(cfg.SameSite),
+		maxAge:   maxAge,
+	}
+}
+
+// Name returns the cookie name including any prefix
+func (k *Cookie) Name() string {
+	return k.name
+}
+
+// Set stores value in the cookie
+func (k *Cookie) Set(c *gin.Context, value string) {
+	k.write(c, value, int(k.maxAge.Seconds()))
+}
+
+// Clear expires the cookie, including a copy left at the site root by releases that
+// did not scope the cookie path
+func (k *Cookie) Clear(c *gin.Context) {
+	k.write(c, "", -1)
+	if k.path != "/" {
+		legacy := *k
+		legacy.path = "/"
+		legacy.write(c, "", -1)
+	}
+}
+
+// Value returns the cookie sent with the request, or http.ErrNoCookie
+func (k *Cookie) Value(c *gin.Context) (string, error) {
+	return c.Cookie(k.name)
+}
+
+func (k *Cookie) write(c *gin.Context, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     k.name,
+		Value:    value,
+		Path:     k.path,
+		Domain:   k.domain,
+		MaxAge:   maxAge,
+		Secure:   k.secure,
+		HttpOnly: k.httpOnly,
+		SameSite: k.sameSite,
+	})
+}
+
+func prefixed(cfg config.CookieConfig, name string) string {
+	if cfg.HostPrefix {
+		return hostPrefix + name
+	}
+	return name
+}
+
+func sameSite(mode string) http.SameSite {
+	switch mode {
+	case config.SameSiteNone:
+		return http.SameSiteNoneMode
+	case config.SameSiteLax:
+		return http.SameSiteLaxMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
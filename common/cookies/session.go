@@ -0,0 +1,58 @@
+package cookies
+
+import (
+	"time"
+
+	"medisuite-api/config"
+	"medisuite-api/pkg/csrf"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Session manages the cookies of a refresh-token session: the HttpOnly refresh token and the
+// CSRF token bound to it, which clients must echo in the X-CSRF-Token header
+type Session struct {
+	refresh *Cookie
+	csrf    *Cookie
+	tokens  *csrf.Protector
+}
+
+// NewSession creates the session cookies; ttl should match the refresh token TTL and
+// secret signs the CSRF tokens
+func NewSession(cfg config.CookieConfig, ttl time.Duration, secret string) *Session {
+	return &Session{
+		refresh: NewRefreshTokenCookie(cfg, ttl),
+		csrf:    NewCSRFTokenCookie(cfg, ttl),
+		tokens:  csrf.NewProtector(secret),
+	}
+}
+
+// Start stores refreshToken and its CSRF token in cookies and returns the CSRF token,
+// which is also returned in the response body for cross-origin frontends that cannot read the cookie
+func (s *Session) Start(c *gin.Context, refreshToken string) string {
+	token := s.tokens.Token(refreshToken)
+	s.refresh.Set(c, refreshToken)
+	s.csrf.Set(c, token)
+	return token
+}
+
+// End clears the session cookies
+func (s *Session) End(c *gin.Context) {
+	s.refresh.Clear(c)
+	s.csrf.Clear(c)
+}
+
+// RefreshToken returns the refresh token cookie sent with the request
+func (s *Session) RefreshToken(c *gin.Context) (string, error) {
+	return s.refresh.Value(c)
+}
+
+// CheckCSRF reports whether the request carries a refresh token cookie and, if so, whether
+// its X-CSRF-Token header matches it. Requests without the cookie authenticate by bearer token only.
+func (s *Session) CheckCSRF(c *gin.Context) (hasSession bool, valid bool) {
+	refreshToken, err := s.refresh.Value(c)
+	if err != nil || refreshToken == "" {
+		return false, false
+	}
+	return true, s.tokens.Valid(refreshToken, c.GetHeader(csrf.HeaderName))
+}
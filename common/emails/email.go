@@ -1,12 +1,16 @@
 package emails
 
 import (
+	"context"
+
 	"medisuite-api/config"
-	"medisuite-api/infra/emails"
+	"medisuite-api/pkg/metrics"
 )
 
 type EmailSender interface {
-	Send(email config.Email) error
+	Send(ctx context.Context, email config.Email) error
+	// Ping checks that the mail server is reachable
+	Ping(ctx context.Context) error
 }
 
 type Service struct {
@@ -17,16 +21,20 @@ func NewService(sender EmailSender) *Service {
 	return &Service{sender: sender}
 }
 
-// SendEmail sends an email using the configured email sender
-func SendEmail(to []string, cc []string, subject string, body string) error {
-	smtpSender := emails.NewSMTPSender()
-	emailService := NewService(smtpSender)
-
-	// Send the email using the configured email sender
-	return emailService.sender.Send(config.Email{
+// SendEmail sends an email using the configured email sender.
+// ctx carries the trace; pass context.WithoutCancel when sending after the request has finished.
+func (s *Service) SendEmail(ctx context.Context, to []string, cc []string, subject string, body string) error {
+	err := s.sender.Send(ctx, config.Email{
 		To:      to,
 		Cc:      cc,
 		Subject: subject,
 		Body:    body,
 	})
+	metrics.ObserveEmail(err)
+	return err
+}
+
+// Ping checks that the configured mail server is reachable
+func (s *Service) Ping(ctx context.Context) error {
+	return s.sender.Ping(ctx)
 }
@@ -1,12 +1,15 @@
 package error
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
 
-	errValid "medisuite-api/constants/errors"
+	"medisuite-api/constants/locales"
+	"medisuite-api/pkg/audit"
+	"medisuite-api/pkg/i18n"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -20,8 +23,9 @@ type ValidationError struct {
 // ErrValidation is a map to hold custom validation error messages.
 var ErrValidation = map[string]string{}
 
-// ErrValidationResponse converts validation errors into a structured response.
-func ErrValidationResponse(err error) (validationResponse []ValidationError) {
+// ErrValidationResponse converts validation errors into a structured response
+// with messages in the locale of ctx.
+func ErrValidationResponse(ctx context.Context, err error) (validationResponse []ValidationError) {
 	var fieldErrors validator.ValidationErrors
 
 	// Check if the error is of type ValidationErrors
@@ -30,11 +34,25 @@ func ErrValidationResponse(err error) (validationResponse []ValidationError) {
 			var message string
 			switch err.Tag() {
 			case "required":
-				message = fmt.Sprintf(errValid.RequiredErrorMsg, err.Field())
+				message = i18n.T(ctx, locales.ValidationRequired, err.Field())
 			case "email":
-				message = fmt.Sprintf(errValid.EmailErrorMsg, err.Field())
+				message = i18n.T(ctx, locales.ValidationEmail, err.Field())
 			case "oneof":
-				message = fmt.Sprintf(errValid.OneOfErrorMsg, err.Field(), err.Param())
+				message = i18n.T(ctx, locales.ValidationOneOf, err.Field(), err.Param())
+			case "min":
+				message = i18n.T(ctx, locales.ValidationMin, err.Field(), err.Param())
+			case "max":
+				message = i18n.T(ctx, locales.ValidationMax, err.Field(), err.Param())
+			case "password":
+				message = i18n.T(ctx, locales.ValidationPassword, err.Field())
+			case "phone_id":
+				message = i18n.T(ctx, locales.ValidationPhone, err.Field())
+			case "uuid":
+				message = i18n.T(ctx, locales.ValidationUUID, err.Field())
+			case "money":
+				message = i18n.T(ctx, locales.ValidationMoney, err.Field())
+			case "audit_entity":
+				message = i18n.T(ctx, locales.ValidationOneOf, err.Field(), strings.Join(audit.EntityTypes, " "))
 			default:
 				// Check for custom error messages
 				if errValidator, ok := ErrValidation[err.Tag()]; ok {
@@ -45,7 +63,7 @@ func ErrValidationResponse(err error) (validationResponse []ValidationError) {
 						message = fmt.Sprintf(errValidator, err.Field(), err.Param())
 					}
 				} else {
-					message = fmt.Sprintf("Something went wrong on %s; %s", err.Field(), err.Tag())
+					message = i18n.T(ctx, locales.ValidationUnknown, err.Field(), err.Tag())
 				}
 			}
 			validationResponse = append(validationResponse, ValidationError{
@@ -55,10 +73,10 @@ func ErrValidationResponse(err error) (validationResponse []ValidationError) {
 		}
 	} else {
 		// Log unexpected errors
-		slog.Error("Validation error")
+		slog.ErrorContext(ctx, "Validation error")
 		validationResponse = append(validationResponse, ValidationError{
 			Field:   "general",
-			Message: errValid.GeneralError,
+			Message: i18n.T(ctx, locales.ValidationGeneral),
 		})
 	}
 
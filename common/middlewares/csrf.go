@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+
+	"medisuite-api/common/cookies"
+	errWrap "medisuite-api/common/errors"
+	"medisuite-api/common/response"
+	errConstants "medisuite-api/constants/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireCSRF rejects state-changing requests that carry the session cookie without a matching
+// X-CSRF-Token header. Bearer-only requests (no session cookie) are exempt since browsers
+// never attach the Authorization header cross-site.
+// Usage: router.POST("/auth/refresh-token", RequireCSRF(session), handler)
+func RequireCSRF(session *cookies.Session) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		hasSession, valid := session.CheckCSRF(c)
+		if hasSession && !valid {
+			slog.WarnContext(c.Request.Context(), "CSRF token missing or invalid", "path", c.Request.URL.Path)
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Code:  http.StatusForbidden,
+				Error: errWrap.WrapError(errConstants.ErrCSRFTokenInvalid),
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
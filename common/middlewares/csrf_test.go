@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"medisuite-api/common/cookies"
+	"medisuite-api/config"
+	"medisuite-api/pkg/csrf"
+
+	"github.com/gin-gonic/gin"
+)
+
+const csrfSecret = "csrf-test-secret"
+
+func TestRequireCSRF(t *testing.T) {
+	protector := csrf.NewProtector(csrfSecret)
+	session := cookies.NewSession(config.CookieConfig{Path: "/", SameSite: config.SameSiteStrict}, time.Hour, csrfSecret)
+
+	const refreshToken = "refresh-token-a"
+
+	tests := []struct {
+		name         string
+		method       string
+		refreshToken string
+		csrfToken    string
+		wantStatus   int
+	}{
+		{"missing token", http.MethodPost, refreshToken, "", http.StatusForbidden},
+		{"wrong token", http.MethodPost, refreshToken, "not-the-token", http.StatusForbidden},
+		{"token bound to another refresh token", http.MethodPost, refreshToken, protector.Token("refresh-token-b"), http.StatusForbidden},
+		{"valid double submit", http.MethodPost, refreshToken, protector.Token(refreshToken), http.StatusOK},
+		{"valid double submit on delete", http.MethodDelete, refreshToken, protector.Token(refreshToken), http.StatusOK},
+		{"safe method without token", http.MethodGet, refreshToken, "", http.StatusOK},
+		{"bearer only request without cookie", http.MethodPost, "", "", http.StatusOK},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(RequireCSRF(session))
+			router.Handle(tt.method, "/auth/refresh-token", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/auth/refresh-token", nil)
+			if tt.refreshToken != "" {
+				req.AddCookie(&http.Cookie{Name: cookies.RefreshTokenName, Value: tt.refreshToken})
+			}
+			if tt.csrfToken != "" {
+				req.Header.Set(csrf.HeaderName, tt.csrfToken)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusForbidden {
+				return
+			}
+
+			var problem struct {
+				Code string `json:"code"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if problem.Code != "CSRF_TOKEN_INVALID" {
+				t.Errorf("code = %q, want CSRF_TOKEN_INVALID", problem.Code)
+			}
+		})
+	}
+}
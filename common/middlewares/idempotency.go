@@ -0,0 +1,192 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"medisuite-api/app/repo"
+	errWrap "medisuite-api/common/errors"
+	"medisuite-api/common/response"
+	"medisuite-api/config"
+	errConstants "medisuite-api/constants/errors"
+	idempotencydb "medisuite-api/pkg/db/idempotency_keys"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Idempotency headers
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed" // set on replayed responses
+)
+
+const (
+	idempotencyKeyMaxLength = 255
+	idempotencyRetryAfter   = "1" // seconds a client should wait while the first request runs
+)
+
+// Idempotency makes retries of a mutating request safe. The first request sent with an
+// Idempotency-Key header claims the key for the caller and its response is stored for cfg.TTL;
+// a retry with the same key and body replays that response, a retry with a different body is
+// rejected with 422 and a retry while the first request is still running gets a retryable 409.
+// 5xx responses release the key so the request can be retried. Requests without the header
+// pass through. Keys are scoped per user, so it must run after AuthMiddleware when used on
+// authenticated routes.
+// Usage: router.POST("/treatments/create", auth, Idempotency(repo, cfg.Idempotency), handler)
+func Idempotency(repository repo.IRepo, cfg config.IdempotencyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if !validIdempotencyKey(key) {
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Error: errWrap.WrapError(errConstants.ErrIdempotencyKeyInvalid),
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Error: errConstants.ErrInvalidRequest,
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := uuid.Nil
+		if value, ok := c.Get("userID"); ok {
+			if id, ok := value.(uuid.UUID); ok {
+				userID = id
+			}
+		}
+
+		ctx := c.Request.Context()
+		now := time.Now()
+		requestHash := fingerprint(c.Request, body)
+		claimed, err := repository.IdempotencyRepo().Claim(ctx, idempotencydb.ClaimIdempotencyKeyParams{
+			UserID:         userID,
+			IdempotencyKey: key,
+			RequestHash:    requestHash,
+			ExpiresAt:      now.Add(cfg.TTL),
+			StaleBefore:    now.Add(-cfg.LockTimeout),
+		})
+		if err != nil {
+			response.HttpResponse(response.ParamHttpResp[any]{Error: err, Gin: c})
+			c.Abort()
+			return
+		}
+
+		if claimed == nil {
+			replayIdempotent(c, repository, userID, key, requestHash)
+			c.Abort()
+			return
+		}
+
+		// this request owns the key: record its response, or release the key when it fails
+		// or panics; the store must outlive a client that disconnects
+		storeCtx := context.WithoutCancel(ctx)
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		completed := false
+		defer func() {
+			if !completed {
+				_ = repository.IdempotencyRepo().Release(storeCtx, userID, key)
+			}
+		}()
+
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		err = repository.IdempotencyRepo().Complete(storeCtx, idempotencydb.CompleteIdempotencyKeyParams{
+			UserID:         userID,
+			IdempotencyKey: key,
+			StatusCode:     int32(status),
+			ContentType:    writer.Header().Get("Content-Type"),
+			ResponseBody:   writer.body.Bytes(),
+		})
+		completed = err == nil
+	}
+}
+
+// replayIdempotent answers a request whose key is held by an earlier request
+func replayIdempotent(c *gin.Context, repository repo.IRepo, userID uuid.UUID, key string, requestHash string) {
+	ctx := c.Request.Context()
+	record, err := repository.IdempotencyRepo().Find(ctx, userID, key)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{Error: err, Gin: c})
+		return
+	}
+
+	switch {
+	case record != nil && record.RequestHash != requestHash:
+		slog.WarnContext(ctx, "Idempotency key reused with a different request", "path", c.Request.URL.Path)
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errWrap.WrapError(errConstants.ErrIdempotencyKeyReused),
+			Gin:   c,
+		})
+	case record == nil || record.StatusCode == 0:
+		// still in flight, or released by a failed first attempt a moment ago
+		c.Header("Retry-After", idempotencyRetryAfter)
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: errConstants.ErrIdempotencyInProgress,
+			Gin:   c,
+		})
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(int(record.StatusCode), record.ContentType, record.ResponseBody)
+	}
+}
+
+// fingerprint identifies a request by its method, URI and body
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// validIdempotencyKey reports whether key is 1 to 255 printable ASCII characters
+func validIdempotencyKey(key string) bool {
+	if len(key) > idempotencyKeyMaxLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// recordingWriter keeps a copy of the response body so it can be replayed
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
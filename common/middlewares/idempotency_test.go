@@ -0,0 +1,256 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"medisuite-api/app/repo"
+	idempotencyRepo "medisuite-api/app/repo/idempotency"
+	"medisuite-api/config"
+	idempotencydb "medisuite-api/pkg/db/idempotency_keys"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// idempotencyStore keeps idempotency keys in memory with the claim rules of ClaimIdempotencyKey
+type idempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*idempotencydb.IdempotencyKey
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{records: map[string]*idempotencydb.IdempotencyKey{}}
+}
+
+func (s *idempotencyStore) Claim(_ context.Context, req idempotencydb.ClaimIdempotencyKeyParams) (*idempotencydb.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	id := req.UserID.String() + "/" + req.IdempotencyKey
+	if held, ok := s.records[id]; ok {
+		stale := held.StatusCode == 0 && held.RequestHash == req.RequestHash && !held.UpdatedAt.After(req.StaleBefore)
+		if held.ExpiresAt.After(now) && !stale {
+			return nil, nil
+		}
+	}
+	record := &idempotencydb.IdempotencyKey{
+		UserID:         req.UserID,
+		IdempotencyKey: req.IdempotencyKey,
+		RequestHash:    req.RequestHash,
+		ExpiresAt:      req.ExpiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	s.records[id] = record
+	copied := *record
+	return &copied, nil
+}
+
+func (s *idempotencyStore) Find(_ context.Context, userID uuid.UUID, key string) (*idempotencydb.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[userID.String()+"/"+key]
+	if !ok || !record.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	copied := *record
+	return &copied, nil
+}
+
+func (s *idempotencyStore) Complete(_ context.Context, req idempotencydb.CompleteIdempotencyKeyParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[req.UserID.String()+"/"+req.IdempotencyKey]; ok {
+		record.StatusCode = req.StatusCode
+		record.ContentType = req.ContentType
+		record.ResponseBody = req.ResponseBody
+		record.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (s *idempotencyStore) Release(_ context.Context, userID uuid.UUID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, userID.String()+"/"+key)
+	return nil
+}
+
+func (s *idempotencyStore) DeleteExpired(context.Context) (int64, error) {
+	return 0, nil
+}
+
+// fakeIdempotencyRepo is a repository whose only working part is the in-memory idempotency store
+type fakeIdempotencyRepo struct {
+	repo.IRepo
+	store *idempotencyStore
+}
+
+func (r fakeIdempotencyRepo) IdempotencyRepo() idempotencyRepo.IIdempotencyRepo {
+	return r.store
+}
+
+var idempotencyConfig = config.IdempotencyConfig{TTL: time.Hour, LockTimeout: time.Minute}
+
+// idempotentRouter serves POST /resource behind Idempotency with handler
+func idempotentRouter(store *idempotencyStore, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.POST("/resource", Idempotency(fakeIdempotencyRepo{store: store}, idempotencyConfig), handler)
+	return router
+}
+
+func postIdempotent(router http.Handler, key string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/resource", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func problemCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var problem struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	return problem.Code
+}
+
+func TestIdempotencyReplaysTheStoredResponse(t *testing.T) {
+	calls := 0
+	router := idempotentRouter(newIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"id": calls})
+	})
+
+	first := postIdempotent(router, "key-1", `{"name":"a"}`)
+	if first.Code != http.StatusCreated || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("first request: status %d, replayed %q", first.Code, first.Header().Get(IdempotentReplayedHeader))
+	}
+
+	retry := postIdempotent(router, "key-1", `{"name":"a"}`)
+	if retry.Code != http.StatusCreated {
+		t.Fatalf("retry status = %d, want %d", retry.Code, http.StatusCreated)
+	}
+	if retry.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("retry is not marked as replayed")
+	}
+	if retry.Body.String() != first.Body.String() {
+		t.Errorf("retry body = %s, want %s", retry.Body, first.Body)
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyRejectsTheKeyForAnotherBody(t *testing.T) {
+	router := idempotentRouter(newIdempotencyStore(), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	postIdempotent(router, "key-1", `{"name":"a"}`)
+	w := postIdempotent(router, "key-1", `{"name":"b"}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if code := problemCode(t, w); code != "IDEMPOTENCY_KEY_REUSED" {
+		t.Errorf("code = %q, want IDEMPOTENCY_KEY_REUSED", code)
+	}
+}
+
+func TestIdempotencyAsksConcurrentRetriesToWait(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	router := idempotentRouter(newIdempotencyStore(), func(c *gin.Context) {
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- postIdempotent(router, "key-1", `{"name":"a"}`) }()
+	<-started
+
+	w := postIdempotent(router, "key-1", `{"name":"a"}`)
+	close(release)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("first request status = %d, want %d", first.Code, http.StatusCreated)
+	}
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After is not set")
+	}
+	if code := problemCode(t, w); code != "IDEMPOTENCY_REQUEST_IN_PROGRESS" {
+		t.Errorf("code = %q, want IDEMPOTENCY_REQUEST_IN_PROGRESS", code)
+	}
+}
+
+func TestIdempotencyReleasesTheKeyOnFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		fail gin.HandlerFunc
+	}{
+		{"server error", func(c *gin.Context) { c.JSON(http.StatusInternalServerError, gin.H{}) }},
+		{"panic", func(c *gin.Context) { panic("handler failed") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			router := idempotentRouter(newIdempotencyStore(), func(c *gin.Context) {
+				calls++
+				if calls == 1 {
+					tt.fail(c)
+					return
+				}
+				c.JSON(http.StatusCreated, gin.H{})
+			})
+
+			if w := postIdempotent(router, "key-1", `{"name":"a"}`); w.Code != http.StatusInternalServerError {
+				t.Fatalf("first request status = %d, want %d", w.Code, http.StatusInternalServerError)
+			}
+			retry := postIdempotent(router, "key-1", `{"name":"a"}`)
+			if retry.Code != http.StatusCreated || retry.Header().Get(IdempotentReplayedHeader) != "" {
+				t.Fatalf("retry: status %d, replayed %q; want a fresh 201", retry.Code, retry.Header().Get(IdempotentReplayedHeader))
+			}
+			if calls != 2 {
+				t.Errorf("handler ran %d times, want 2", calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyPassesRequestsWithoutAKey(t *testing.T) {
+	calls := 0
+	router := idempotentRouter(newIdempotencyStore(), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	for i := 0; i < 2; i++ {
+		if w := postIdempotent(router, "", `{"name":"a"}`); w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+}
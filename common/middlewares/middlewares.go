@@ -1,22 +1,26 @@
 package middlewares
 
 import (
-	"context"
+	"errors"
 	"log/slog"
 	"net/http"
-	"os"
 	"strings"
 
+	"medisuite-api/app/policy"
 	"medisuite-api/app/repo"
 	errWrap "medisuite-api/common/errors"
 	"medisuite-api/common/response"
 	errConstants "medisuite-api/constants/errors"
-	constants "medisuite-api/constants/http_status"
+	"medisuite-api/pkg/audit"
+	roledb "medisuite-api/pkg/db/roles"
+	"medisuite-api/pkg/i18n"
+	"medisuite-api/pkg/jwt"
+	"medisuite-api/pkg/logs"
+	"medisuite-api/pkg/metrics"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -24,10 +28,10 @@ func HandlePanic() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if r := recover(); r != nil {
-				slog.Error("Recovered from panic", r)
-				c.JSON(http.StatusInternalServerError, response.Response[any]{
-					Status:  constants.Error,
-					Message: errConstants.ErrInternalServerError.Error(),
+				slog.ErrorContext(c.Request.Context(), "Recovered from panic", "panic", r)
+				response.HttpResponse(response.ParamHttpResp[any]{
+					Error: errConstants.ErrInternalServerError,
+					Gin:   c,
 				})
 				c.Abort()
 			}
@@ -36,7 +40,8 @@ func HandlePanic() gin.HandlerFunc {
 	}
 }
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware verifies the bearer access token with tokens and stores its claims in the context
+func AuthMiddleware(tokens *jwt.Signer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -61,14 +66,8 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// Verify the token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := tokens.ParseAccessToken(tokenString)
+		if err != nil {
 			response.HttpResponse(response.ParamHttpResp[any]{
 				Code:  http.StatusUnauthorized,
 				Error: errWrap.WrapError(errConstants.ErrUnauthorized),
@@ -79,22 +78,42 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// Set user info in context if needed
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			if rawID, ok := claims["user_id"]; ok {
-				// JWT library typically unmarshals UUIDs as strings
-				if idStr, ok := rawID.(string); ok {
-					if userID, err := uuid.Parse(idStr); err == nil {
-						c.Set("userID", userID)
-					} else {
-						slog.Error("invalid user_id in token claims", "value", idStr, "error", err)
-					}
+		if rawID, ok := claims["user_id"]; ok {
+			// JWT library typically unmarshals UUIDs as strings
+			if idStr, ok := rawID.(string); ok {
+				if userID, err := uuid.Parse(idStr); err == nil {
+					c.Set("userID", userID)
+					c.Request = c.Request.WithContext(logs.WithUserID(c.Request.Context(), userID.String()))
+				} else {
+					slog.ErrorContext(c.Request.Context(), "invalid user_id in token claims", "error", err)
 				}
 			}
-			// Extract role from JWT claims
-			if rawRole, ok := claims["role"]; ok {
-				if roleStr, ok := rawRole.(string); ok {
-					c.Set("roleCode", roleStr)
-				}
+		}
+		// Extract role from JWT claims
+		if rawRole, ok := claims["role"]; ok {
+			if roleStr, ok := rawRole.(string); ok {
+				c.Set("roleCode", roleStr)
+			}
+		}
+		// Permissions version the token was issued with; a stale one is rejected once permissions change
+		if rawVersion, ok := claims["perm_ver"]; ok {
+			if version, ok := rawVersion.(string); ok && version != "" {
+				c.Set("permVersion", version)
+			}
+		}
+		// changes made by this request are audited as this user and role
+		actor := audit.ActorFromContext(c.Request.Context())
+		if userID, ok := c.Get("userID"); ok {
+			actor.UserID, _ = userID.(uuid.UUID)
+		}
+		actor.Role = c.GetString("roleCode")
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), actor))
+
+		// the user's stored locale takes precedence over Accept-Language
+		if rawLocale, ok := claims["locale"]; ok {
+			if locale, ok := rawLocale.(string); ok && locale != "" {
+				ctx := c.Request.Context()
+				c.Request = c.Request.WithContext(i18n.WithLocale(ctx, i18n.Locale(ctx, locale)))
 			}
 		}
 
@@ -127,12 +146,10 @@ func RequirePermission(repository repo.IRepo, module, action string) gin.Handler
 		// Get userID from context (set by AuthMiddleware)
 		userIDVal, exists := c.Get("userID")
 		if !exists {
-			errMessage := "User not authenticated"
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusUnauthorized,
-				Error:   errWrap.WrapError(errConstants.ErrUnauthorized),
-				Message: &errMessage,
-				Gin:     c,
+				Code:  http.StatusUnauthorized,
+				Error: errWrap.WrapError(errConstants.ErrUnauthorized),
+				Gin:   c,
 			})
 			c.Abort()
 			return
@@ -140,40 +157,29 @@ func RequirePermission(repository repo.IRepo, module, action string) gin.Handler
 
 		userID, ok := userIDVal.(uuid.UUID)
 		if !ok {
-			errMessage := "Invalid user ID"
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusUnauthorized,
-				Error:   errWrap.WrapError(errConstants.ErrUnauthorized),
-				Message: &errMessage,
-				Gin:     c,
+				Code:  http.StatusUnauthorized,
+				Error: errWrap.WrapError(errConstants.ErrUnauthorized),
+				Gin:   c,
 			})
 			c.Abort()
 			return
 		}
 
-		// Get user to retrieve role_id
-		user, err := repository.UserRepo().FindUserById(c.Request.Context(), userID)
-		if err != nil {
-			errMessage := "Failed to get user information"
-			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusInternalServerError,
-				Error:   err,
-				Message: &errMessage,
-				Gin:     c,
-			})
-			c.Abort()
+		// Resolve the user's current role, which may have changed since the token was issued
+		roleID, ok := resolveRoleID(c, repository, userID)
+		if !ok {
 			return
 		}
 
-		// Get role permissions
-		permissions, err := repository.RolePermissionRepo().GetRolePermissions(c.Request.Context(), user.RoleID)
+		// Get role permissions (served from the in-process cache when the token's version matches);
+		// a token issued before the permissions changed is answered with 401 TOKEN_STALE
+		permVersion := c.GetString("permVersion")
+		permissions, err := repository.RolePermissionRepo().GetRolePermissionsForVersion(c.Request.Context(), roleID, permVersion)
 		if err != nil {
-			errMessage := "Failed to check permissions"
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusInternalServerError,
-				Error:   err,
-				Message: &errMessage,
-				Gin:     c,
+				Error: err,
+				Gin:   c,
 			})
 			c.Abort()
 			return
@@ -189,13 +195,12 @@ func RequirePermission(repository repo.IRepo, module, action string) gin.Handler
 		}
 
 		if !hasPermission {
-			errMessage := "You do not have permission to perform this action"
-			slog.Warn("Permission denied", "user_id", userID, "module", module, "action", action)
+			slog.WarnContext(c.Request.Context(), "Permission denied", "user_id", userID, "module", module, "action", action)
+			metrics.ObservePermissionDenied(module, action)
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusForbidden,
-				Error:   errWrap.WrapError(errConstants.ErrInsufficientPermissions),
-				Message: &errMessage,
-				Gin:     c,
+				Code:  http.StatusForbidden,
+				Error: errWrap.WrapError(errConstants.ErrInsufficientPermissions),
+				Gin:   c,
 			})
 			c.Abort()
 			return
@@ -212,12 +217,10 @@ func RequireRole(repository repo.IRepo, allowedRoles ...string) gin.HandlerFunc
 		// Get userID from context (set by AuthMiddleware)
 		userIDVal, exists := c.Get("userID")
 		if !exists {
-			errMessage := "User not authenticated"
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusUnauthorized,
-				Error:   errWrap.WrapError(errConstants.ErrUnauthorized),
-				Message: &errMessage,
-				Gin:     c,
+				Code:  http.StatusUnauthorized,
+				Error: errWrap.WrapError(errConstants.ErrUnauthorized),
+				Gin:   c,
 			})
 			c.Abort()
 			return
@@ -225,40 +228,31 @@ func RequireRole(repository repo.IRepo, allowedRoles ...string) gin.HandlerFunc
 
 		userID, ok := userIDVal.(uuid.UUID)
 		if !ok {
-			errMessage := "Invalid user ID"
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusUnauthorized,
-				Error:   errWrap.WrapError(errConstants.ErrUnauthorized),
-				Message: &errMessage,
-				Gin:     c,
+				Code:  http.StatusUnauthorized,
+				Error: errWrap.WrapError(errConstants.ErrUnauthorized),
+				Gin:   c,
 			})
 			c.Abort()
 			return
 		}
 
-		// Get user to retrieve role_id
-		user, err := repository.UserRepo().FindUserById(c.Request.Context(), userID)
-		if err != nil {
-			errMessage := "Failed to get user information"
-			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusInternalServerError,
-				Error:   err,
-				Message: &errMessage,
-				Gin:     c,
-			})
-			c.Abort()
+		// Resolve the user's current role, which may have changed since the token was issued
+		roleID, ok := resolveRoleID(c, repository, userID)
+		if !ok {
 			return
 		}
 
-		// Get role information
-		role, err := repository.RoleRepo().FindRoleById(context.Background(), user.RoleID)
+		// Get role information (served from the in-process cache when possible)
+		role, err := repository.RoleRepo().FindRoleById(c.Request.Context(), roleID)
+		if err == nil && role == nil {
+			err = errWrap.WrapError(errConstants.ErrRoleNotFound)
+		}
 		if err != nil {
-			errMessage := "Failed to get role information"
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusInternalServerError,
-				Error:   err,
-				Message: &errMessage,
-				Gin:     c,
+				Code:  http.StatusInternalServerError,
+				Error: err,
+				Gin:   c,
 			})
 			c.Abort()
 			return
@@ -275,18 +269,190 @@ func RequireRole(repository repo.IRepo, allowedRoles ...string) gin.HandlerFunc
 		}
 
 		if !roleAllowed {
-			errMessage := "Your role does not have access to this resource"
-			slog.Warn("Role access denied", "user_id", userID, "role", roleCode, "allowed_roles", allowedRoles)
+			slog.WarnContext(c.Request.Context(), "Role access denied", "user_id", userID, "role", roleCode, "allowed_roles", allowedRoles)
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Code:  http.StatusForbidden,
+				Error: errWrap.WrapError(errConstants.ErrInvalidRole),
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// resolveRoleID returns the current role ID of the authenticated user.
+// The role is never taken from the access token: it is looked up through the user role cache,
+// which RoleService.AssignRole invalidates, so a role change applies from the next request.
+// On failure it writes the error response, aborts the request and returns false.
+func resolveRoleID(c *gin.Context, repository repo.IRepo, userID uuid.UUID) (uuid.UUID, bool) {
+	roleID, err := repository.UserRepo().FindUserRoleID(c.Request.Context(), userID)
+	if err != nil {
+		// a deleted user's token no longer authenticates anyone
+		if errors.Is(err, errConstants.ErrUserNotFound) {
+			err = errWrap.WrapError(errConstants.ErrUnauthorized)
+		}
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Error: err,
+			Gin:   c,
+		})
+		c.Abort()
+		return uuid.Nil, false
+	}
+
+	return roleID, true
+}
+
+// RequireMinLevel checks if the authenticated user's role level is at least the level of minRoleCode.
+// Higher levels are more privileged, so RequireMinLevel(repo, "admin") admits admins and owners.
+// Usage: router.GET("/roles", AuthMiddleware(), RequireMinLevel(repo, roles.ADMIN), handler)
+func RequireMinLevel(repository repo.IRepo, minRoleCode string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Get userID from context (set by AuthMiddleware)
+		userIDVal, exists := c.Get("userID")
+		userID, ok := userIDVal.(uuid.UUID)
+		if !exists || !ok {
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Code:  http.StatusUnauthorized,
+				Error: errWrap.WrapError(errConstants.ErrUnauthorized),
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+
+		// Resolve the user's current role, which may have changed since the token was issued
+		roleID, ok := resolveRoleID(c, repository, userID)
+		if !ok {
+			return
+		}
+
+		// Get the user's role and the reference role (both served from cache when possible)
+		role, err := repository.RoleRepo().FindRoleById(c.Request.Context(), roleID)
+		if err == nil && role == nil {
+			err = errWrap.WrapError(errConstants.ErrRoleNotFound)
+		}
+		var minRole *roledb.Role
+		if err == nil {
+			minRole, err = repository.RoleRepo().FindRoleByCode(c.Request.Context(), minRoleCode)
+			if err == nil && minRole == nil {
+				err = errWrap.WrapError(errConstants.ErrRoleNotFound)
+			}
+		}
+		if err != nil {
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Code:  http.StatusInternalServerError,
+				Error: err,
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+
+		if role.Level < minRole.Level {
+			slog.WarnContext(c.Request.Context(), "Role level denied", "user_id", userID, "role", role.Code, "level", role.Level, "min_role", minRoleCode, "min_level", minRole.Level)
 			response.HttpResponse(response.ParamHttpResp[any]{
-				Code:    http.StatusForbidden,
-				Error:   errWrap.WrapError(errConstants.ErrInvalidRole),
-				Message: &errMessage,
-				Gin:     c,
+				Code:  http.StatusForbidden,
+				Error: errWrap.WrapError(errConstants.ErrRoleLevelTooLow),
+				Gin:   c,
 			})
 			c.Abort()
 			return
 		}
 
+		c.Set("roleLevel", role.Level)
 		c.Next()
 	}
 }
+
+// ResourceLoader loads the resource addressed by the request so a policy can be evaluated against it.
+// It returns nil when the resource does not exist.
+type ResourceLoader func(c *gin.Context) (*policy.Resource, error)
+
+// RequirePolicy checks resource-level access (e.g. "can this doctor read this patient")
+// after RequirePermission has checked module-level access.
+// Usage: router.GET("/appointments/:id", AuthMiddleware(), RequirePolicy(repo, policy.Default(), policy.ActionRead, loadAppointment), handler)
+func RequirePolicy(repository repo.IRepo, engine *policy.Engine, action string, load ResourceLoader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c, repository)
+		if !ok {
+			return
+		}
+
+		// Load the resource being accessed
+		resource, err := load(c)
+		if err != nil {
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Error: err,
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+		if resource == nil {
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Code:  http.StatusNotFound,
+				Error: errWrap.WrapError(errConstants.ErrResourceNotFound),
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+
+		// Evaluate the policy (denials are logged with their reason and carry their own status)
+		if err := engine.Authorize(c.Request.Context(), principal, action, *resource); err != nil {
+			response.HttpResponse(response.ParamHttpResp[any]{
+				Error: err,
+				Gin:   c,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("policyResource", *resource)
+		c.Next()
+	}
+}
+
+// PrincipalFromContext builds the policy principal for the authenticated user.
+// On failure it writes the error response, aborts the request and returns false.
+func PrincipalFromContext(c *gin.Context, repository repo.IRepo) (policy.Principal, bool) {
+	userIDVal, exists := c.Get("userID")
+	userID, ok := userIDVal.(uuid.UUID)
+	if !exists || !ok {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Code:  http.StatusUnauthorized,
+			Error: errWrap.WrapError(errConstants.ErrUnauthorized),
+			Gin:   c,
+		})
+		c.Abort()
+		return policy.Principal{}, false
+	}
+
+	roleID, ok := resolveRoleID(c, repository, userID)
+	if !ok {
+		return policy.Principal{}, false
+	}
+
+	role, err := repository.RoleRepo().FindRoleById(c.Request.Context(), roleID)
+	if err == nil && role == nil {
+		err = errWrap.WrapError(errConstants.ErrRoleNotFound)
+	}
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp[any]{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		c.Abort()
+		return policy.Principal{}, false
+	}
+
+	return policy.Principal{
+		UserID:    userID,
+		RoleCode:  role.Code,
+		RoleLevel: role.Level,
+	}, true
+}
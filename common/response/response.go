@@ -1,11 +1,16 @@
 package response
 
 import (
+	"context"
 	"net/http"
+	"strings"
 
 	httpstatus "medisuite-api/constants/http_status"
 
 	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/locales"
+	"medisuite-api/pkg/i18n"
+	"medisuite-api/pkg/logs"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +23,22 @@ type Response[T any] struct {
 	Token   *string `json:"token,omitempty"`
 }
 
+// ProblemContentType is the media type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body, extended with a stable machine-readable code
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	Details   any    `json:"details,omitempty"`
+	Retryable bool   `json:"retryable"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
 // detail response field data
 type ParamHttpResp[T any] struct {
 	Code    int
@@ -34,6 +55,8 @@ type ParamHttpResp[T any] struct {
 **/
 
 func HttpResponse[T any](param ParamHttpResp[T]) {
+	ctx := param.Gin.Request.Context()
+
 	// Check if there's no error in the response
 	if param.Error == nil {
 		/*
@@ -47,7 +70,12 @@ func HttpResponse[T any](param ParamHttpResp[T]) {
 		message := http.StatusText(param.Code)
 		if param.Message != nil {
 			message = *param.Message
+			// success messages from constants/success are translated to the request locale
+			if key, ok := locales.SuccessKey(message); ok {
+				message = i18n.T(ctx, key)
+			}
 		}
+		setContentLanguage(param.Gin)
 		param.Gin.JSON(param.Code, Response[T]{
 			Status:  httpstatus.Success,
 			Message: message,
@@ -58,23 +86,50 @@ func HttpResponse[T any](param ParamHttpResp[T]) {
 	}
 
 	/*
-		If we reach here, there was an error
-		Set default error message
+		If we reach here, there was an error: respond with RFC 7807 problem details.
+		The status comes from the explicit Code when given, otherwise from the application error.
+		The detail is always the translated message of the error code: Message only applies to
+		success responses, and errors that are not application errors never leak their text
 	**/
-	message := errConsts.ErrInternalServerError.Error()
-	// Prioritize explicit message first
-	if param.Message != nil {
-		message = *param.Message
-	} else if param.Error != nil {
-		// Check if error is in our mapped errors
-		if errConsts.MappingError(param.Error) {
-			message = param.Error.Error()
+	appErr, ok := errConsts.AsAppError(param.Error)
+	if !ok {
+		appErr = errConsts.ErrInternalServerError
+		if param.Code >= 400 && param.Code < 500 {
+			appErr = errConsts.New(param.Code, strings.ToUpper(strings.ReplaceAll(http.StatusText(param.Code), " ", "_")), http.StatusText(param.Code))
 		}
 	}
+	status := param.Code
+	if status == 0 {
+		status = appErr.Status
+	}
+
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    translate(ctx, appErr.Code, appErr.Message),
+		Instance:  param.Gin.Request.URL.Path,
+		Code:      appErr.Code,
+		Details:   appErr.Details,
+		Retryable: appErr.Retryable,
+		RequestID: logs.RequestIDFromContext(ctx),
+	}
+	setContentLanguage(param.Gin)
+	param.Gin.Header("Content-Type", ProblemContentType)
+	param.Gin.JSON(status, problem)
+}
+
+// translate returns the message of code in the request locale, or message when code has none
+func translate(ctx context.Context, code string, message string) string {
+	if translated := i18n.T(ctx, code); translated != code {
+		return translated
+	}
+	return message
+}
 
-	param.Gin.JSON(param.Code, Response[T]{
-		Status:  httpstatus.Error,
-		Message: message,
-		Data:    param.Data,
-	})
+// setContentLanguage announces the locale the response messages are written in
+func setContentLanguage(c *gin.Context) {
+	if locale := i18n.Locale(c.Request.Context()); locale != "" {
+		c.Header("Content-Language", locale)
+	}
 }
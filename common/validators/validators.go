@@ -0,0 +1,220 @@
+package validators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	errValidation "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/locales"
+	"medisuite-api/pkg/audit"
+	"medisuite-api/pkg/etag"
+	"medisuite-api/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Custom validation tags, usable in `validate:"..."` struct tags
+const (
+	TagPassword = "password" // upper and lower case letter, digit and special character
+	TagPhoneID  = "phone_id" // Indonesian mobile number: 08xx, 628xx or +628xx
+	TagUUID     = "uuid"     // parseable UUID; also accepts uuid.UUID fields
+	TagMoney    = "money"    // non-negative amount with at most two decimals
+
+	TagAuditEntity = "audit_entity" // entity type recorded in audit events
+)
+
+var phoneIDPattern = regexp.MustCompile(`^(\+62|62|0)8[1-9][0-9]{6,11}$`)
+
+var (
+	once     sync.Once
+	instance *validator.Validate
+)
+
+// Validator returns the shared validator, created once with every custom rule registered.
+// Field errors are reported with the json name of the field.
+func Validator() *validator.Validate {
+	once.Do(func() {
+		v := validator.New(validator.WithRequiredStructEnabled())
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+
+		// uuid.UUID is validated as its string form; the nil UUID counts as empty
+		v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+			if id, ok := field.Interface().(uuid.UUID); ok && id != uuid.Nil {
+				return id.String()
+			}
+			return ""
+		}, uuid.UUID{})
+
+		rules := map[string]validator.Func{
+			TagPassword: validatePassword,
+			TagPhoneID:  validatePhoneID,
+			TagUUID:     validateUUID,
+			TagMoney:    validateMoney,
+
+			TagAuditEntity: validateAuditEntity,
+		}
+		for tag, fn := range rules {
+			if err := v.RegisterValidation(tag, fn); err != nil {
+				panic(err)
+			}
+		}
+		instance = v
+	})
+	return instance
+}
+
+// Validate validates s; field errors are returned as ErrValidationFailed with their messages
+// in the locale of ctx
+func Validate(ctx context.Context, s any) error {
+	if err := Validator().StructCtx(ctx, s); err != nil {
+		return errConsts.ErrValidationFailed.WithDetails(errValidation.ErrValidationResponse(ctx, err))
+	}
+	return nil
+}
+
+// BindJSON decodes the request body into dst and validates it.
+// A malformed body returns ErrInvalidRequest, an invalid one ErrValidationFailed.
+func BindJSON(c *gin.Context, dst any) error {
+	if err := c.ShouldBindJSON(dst); err != nil {
+		return errConsts.ErrInvalidRequest
+	}
+	return Validate(c.Request.Context(), dst)
+}
+
+// BindQuery decodes the query string into dst using its form tags and validates it.
+// A malformed value returns ErrInvalidRequest, an invalid one ErrValidationFailed.
+func BindQuery(c *gin.Context, dst any) error {
+	if err := c.ShouldBindQuery(dst); err != nil {
+		return errConsts.ErrInvalidRequest
+	}
+	return Validate(c.Request.Context(), dst)
+}
+
+// MergePatchContentType is the media type of JSON Merge Patch bodies (RFC 7396);
+// PATCH endpoints also accept plain application/json
+const MergePatchContentType = "application/merge-patch+json"
+
+// BindMergePatch decodes a JSON Merge Patch body into dst, whose fields are pointers left nil
+// when the member is absent, and validates it. The body must be a JSON object; a member set
+// to null is rejected with ErrValidationFailed, as none of the patchable fields can be cleared.
+func BindMergePatch(c *gin.Context, dst any) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return errConsts.ErrInvalidRequest
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return errConsts.ErrInvalidRequest
+	}
+
+	ctx := c.Request.Context()
+	var nulls []errValidation.ValidationError
+	for field, value := range members {
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			nulls = append(nulls, errValidation.ValidationError{
+				Field:   field,
+				Message: i18n.T(ctx, locales.ValidationNotNull, field),
+			})
+		}
+	}
+	if len(nulls) > 0 {
+		sort.Slice(nulls, func(i, j int) bool { return nulls[i].Field < nulls[j].Field })
+		return errConsts.ErrValidationFailed.WithDetails(nulls)
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		return errConsts.ErrInvalidRequest
+	}
+	return Validate(ctx, dst)
+}
+
+// IfMatch returns the row version named by the request's If-Match header.
+// A missing header returns ErrPreconditionRequired, one that names no version ErrPreconditionFailed.
+func IfMatch(c *gin.Context) (int32, error) {
+	header := c.GetHeader(etag.HeaderIfMatch)
+	if header == "" {
+		return 0, errConsts.ErrPreconditionRequired
+	}
+	version, ok := etag.Parse(header)
+	if !ok {
+		return 0, errConsts.ErrPreconditionFailed
+	}
+	return version, nil
+}
+
+func validatePassword(fl validator.FieldLevel) bool {
+	var upper, lower, digit, special bool
+	for _, r := range fl.Field().String() {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			special = true
+		}
+	}
+	return upper && lower && digit && special
+}
+
+func validatePhoneID(fl validator.FieldLevel) bool {
+	return phoneIDPattern.MatchString(fl.Field().String())
+}
+
+func validateAuditEntity(fl validator.FieldLevel) bool {
+	return audit.IsEntityType(fl.Field().String())
+}
+
+func validateUUID(fl validator.FieldLevel) bool {
+	_, err := uuid.Parse(fl.Field().String())
+	return err == nil
+}
+
+func validateMoney(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return isMoney(field.Float())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() >= 0
+	case reflect.String:
+		amount, err := strconv.ParseFloat(field.String(), 64)
+		return err == nil && isMoney(amount)
+	default:
+		return false
+	}
+}
+
+// isMoney reports whether amount is finite, non-negative and has at most two decimals
+func isMoney(amount float64) bool {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount < 0 {
+		return false
+	}
+	cents := amount * 100
+	return math.Abs(cents-math.Round(cents)) < 1e-6
+}
@@ -0,0 +1,193 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	treatmentDTO "medisuite-api/app/dto/treatments"
+	userDTO "medisuite-api/app/dto/users"
+	errValidation "medisuite-api/common/errors"
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/locales"
+	"medisuite-api/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+func TestMain(m *testing.M) {
+	bundle, err := locales.NewBundle(locales.English)
+	if err != nil {
+		panic(err)
+	}
+	i18n.SetDefault(bundle)
+	m.Run()
+}
+
+// fieldErrors returns the sorted fields err reports, failing unless it is ErrValidationFailed
+func fieldErrors(t *testing.T, err error) []string {
+	t.Helper()
+	if !errors.Is(err, errConsts.ErrValidationFailed) {
+		t.Fatalf("error = %v, want %v", err, errConsts.ErrValidationFailed)
+	}
+	appErr, _ := errConsts.AsAppError(err)
+	details, ok := appErr.Details.([]errValidation.ValidationError)
+	if !ok {
+		t.Fatalf("details = %T, want []ValidationError", appErr.Details)
+	}
+
+	var fields []string
+	for _, detail := range details {
+		if detail.Message == "" {
+			t.Errorf("field %s has no message", detail.Field)
+		}
+		fields = append(fields, detail.Field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func validRegistration() userDTO.RegisterDTO {
+	return userDTO.RegisterDTO{
+		Name:        "Jane Doe",
+		Email:       "jane@example.com",
+		Password:    "Str0ng!pass",
+		PhoneNumber: "081234567890",
+		RoleID:      uuid.New(),
+	}
+}
+
+func TestValidateRegistration(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(*userDTO.RegisterDTO)
+		wantFields []string
+	}{
+		{"valid", func(*userDTO.RegisterDTO) {}, nil},
+		{"international phone", func(d *userDTO.RegisterDTO) { d.PhoneNumber = "+6281234567890" }, nil},
+		{"empty", func(d *userDTO.RegisterDTO) { *d = userDTO.RegisterDTO{} }, []string{"email", "name", "password", "phone_number", "role_id"}},
+		{"invalid email", func(d *userDTO.RegisterDTO) { d.Email = "jane" }, []string{"email"}},
+		{"short password", func(d *userDTO.RegisterDTO) { d.Password = "S0!a" }, []string{"password"}},
+		{"weak password", func(d *userDTO.RegisterDTO) { d.Password = "password123" }, []string{"password"}},
+		{"foreign phone", func(d *userDTO.RegisterDTO) { d.PhoneNumber = "+14155550123" }, []string{"phone_number"}},
+		{"landline", func(d *userDTO.RegisterDTO) { d.PhoneNumber = "0215550123" }, []string{"phone_number"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto := validRegistration()
+			tt.modify(&dto)
+
+			err := Validate(context.Background(), dto)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if got := fieldErrors(t, err); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func validTreatment() treatmentDTO.TreatmentDTO {
+	return treatmentDTO.TreatmentDTO{
+		CategoryID:    uuid.New(),
+		NameTreatment: "Scaling",
+		Description:   "Tartar removal",
+		Thumbnail:     "https://cdn.example.com/scaling.png",
+		Price:         250000.50,
+		Duration:      30,
+	}
+}
+
+func TestValidateTreatment(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(*treatmentDTO.TreatmentDTO)
+		wantFields []string
+	}{
+		{"valid", func(*treatmentDTO.TreatmentDTO) {}, nil},
+		{"empty", func(d *treatmentDTO.TreatmentDTO) { *d = treatmentDTO.TreatmentDTO{} }, []string{"category_id", "description", "duration", "name_treatment", "price", "thumbnail"}},
+		{"negative price", func(d *treatmentDTO.TreatmentDTO) { d.Price = -1 }, []string{"price"}},
+		{"fractional cents", func(d *treatmentDTO.TreatmentDTO) { d.Price = 10.005 }, []string{"price"}},
+		{"negative duration", func(d *treatmentDTO.TreatmentDTO) { d.Duration = -5 }, []string{"duration"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto := validTreatment()
+			tt.modify(&dto)
+
+			err := Validate(context.Background(), dto)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if got := fieldErrors(t, err); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestCustomRules(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value any
+		want  bool
+	}{
+		{TagPassword, "Str0ng!pass", true},
+		{TagPassword, "NoDigits!", false},
+		{TagPassword, "n0upper!", false},
+		{TagPassword, "N0LOWER!", false},
+		{TagPassword, "N0special", false},
+		{TagPhoneID, "081234567890", true},
+		{TagPhoneID, "6281234567890", true},
+		{TagPhoneID, "+6281234567890", true},
+		{TagPhoneID, "0812345", false},
+		{TagPhoneID, "08123456789012345", false},
+		{TagPhoneID, "0801234567890", false},
+		{TagUUID, uuid.NewString(), true},
+		{TagUUID, "not-a-uuid", false},
+		{TagMoney, 19.99, true},
+		{TagMoney, 0.0, true},
+		{TagMoney, 19.999, false},
+		{TagMoney, -0.01, false},
+		{TagMoney, "12.50", true},
+		{TagMoney, "12.5x", false},
+		{TagMoney, 100, true},
+		{TagMoney, -100, false},
+		{TagAuditEntity, "care_team", true},
+		{TagAuditEntity, "outbox_message", true},
+		{TagAuditEntity, "patient", false},
+	}
+
+	for _, tt := range tests {
+		err := Validator().Var(tt.value, tt.tag)
+		if got := err == nil; got != tt.want {
+			t.Errorf("%s(%v) valid = %v, want %v (%v)", tt.tag, tt.value, got, tt.want, err)
+		}
+	}
+}
+
+func TestValidationMessagesAreTranslated(t *testing.T) {
+	ctx := i18n.WithLocale(context.Background(), locales.Indonesian)
+	err := Validate(ctx, userDTO.RegisterDTO{})
+
+	appErr, ok := errConsts.AsAppError(err)
+	if !ok {
+		t.Fatalf("Validate() = %v, want an application error", err)
+	}
+	for _, detail := range appErr.Details.([]errValidation.ValidationError) {
+		if english := i18n.Translate(locales.English, locales.ValidationRequired, detail.Field); detail.Message == english {
+			t.Errorf("field %s message %q is not translated", detail.Field, detail.Message)
+		}
+	}
+}
@@ -1,11 +1,507 @@
 package config
 
-var Config AppConfig
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
 
+// Environments accepted in AppConfig.Environment
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// AppConfig is the typed application configuration.
+// Values are resolved by Load from defaults, a YAML/TOML file, environment variables and flags,
+// in that order of precedence. Fields tagged secret are masked by Redacted.
 type AppConfig struct {
-	RateLimiterMaxRequest float64 `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond int     `json:"rateLimiterTimeSecond"`
-	Environment           string  `json:"environment"`  // "development", "production", etc.
-	CookieDomain          string  `json:"cookieDomain"` // Cookie domain for production
-	CookieSecure          bool    `json:"cookieSecure"` // HTTPS requirement for cookies
+	Environment string            `yaml:"environment" toml:"environment" env:"GO_ENV"`
+	ClientURL   string            `yaml:"client_url" toml:"client_url" env:"CLIENT_URL"`
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	Database    DatabaseConfig    `yaml:"database" toml:"database"`
+	Migration   MigrationConfig   `yaml:"migration" toml:"migration"`
+	JWT         JWTConfig         `yaml:"jwt" toml:"jwt"`
+	SMTP        SMTPConfig        `yaml:"smtp" toml:"smtp"`
+	Authz       AuthzConfig       `yaml:"authz" toml:"authz"`
+	RateLimiter RateLimiterConfig `yaml:"rate_limiter" toml:"rate_limiter"`
+	Cookie      CookieConfig      `yaml:"cookie" toml:"cookie"`
+	Security    SecurityConfig    `yaml:"security_headers" toml:"security_headers"`
+	CORS        CORSConfig        `yaml:"cors" toml:"cors"`
+	Health      HealthConfig      `yaml:"health" toml:"health"`
+	Metrics     MetricsConfig     `yaml:"metrics" toml:"metrics"`
+	Tracing     TracingConfig     `yaml:"tracing" toml:"tracing"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	I18n        I18nConfig        `yaml:"i18n" toml:"i18n"`
+	Idempotency IdempotencyConfig `yaml:"idempotency" toml:"idempotency"`
+	Encryption  EncryptionConfig  `yaml:"encryption" toml:"encryption"`
+	Outbox      OutboxConfig      `yaml:"outbox" toml:"outbox"`
+}
+
+type ServerConfig struct {
+	Port              int           `yaml:"port" toml:"port" env:"PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes" toml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES"`
+	// ShutdownTimeout bounds draining in-flight requests and background work on SIGINT/SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" toml:"host" env:"DB_HOST,POSTGRES_HOST"`
+	Port     int    `yaml:"port" toml:"port" env:"DB_PORT,POSTGRES_PORT"`
+	User     string `yaml:"user" toml:"user" env:"DB_USER,POSTGRES_USER"`
+	Password string `yaml:"password" toml:"password" env:"DB_PASS,POSTGRES_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" toml:"name" env:"DB_NAME,POSTGRES_DB"`
+	SSLMode  string `yaml:"ssl_mode" toml:"ssl_mode" env:"DB_SSLMODE"`
+	TimeZone string `yaml:"time_zone" toml:"time_zone" env:"DB_TIMEZONE"`
+	MaxConns int    `yaml:"max_conns" toml:"max_conns" env:"DB_MAX_CONNS"`
+}
+
+type MigrationConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"MIGRATE_ENABLED"`
+	Path    string `yaml:"path" toml:"path" env:"MIGRATION_PATH"`
+}
+
+type JWTConfig struct {
+	Secret        string        `yaml:"secret" toml:"secret" env:"JWT_SECRET" secret:"true"`
+	RefreshSecret string        `yaml:"refresh_secret" toml:"refresh_secret" env:"REFRESH_SECRET" secret:"true"`
+	AccessTTL     time.Duration `yaml:"access_ttl" toml:"access_ttl" env:"JWT_ACCESS_TTL"`
+	RefreshTTL    time.Duration `yaml:"refresh_ttl" toml:"refresh_ttl" env:"JWT_REFRESH_TTL"`
+}
+
+type SMTPConfig struct {
+	Service  string `yaml:"service" toml:"service" env:"SMTP_SERVICES"`
+	Host     string `yaml:"host" toml:"host" env:"SMTP_HOST"`
+	Port     int    `yaml:"port" toml:"port" env:"SMTP_PORT"`
+	User     string `yaml:"user" toml:"user" env:"SMTP_USER"`
+	Password string `yaml:"password" toml:"password" env:"SMTP_PASS" secret:"true"`
+}
+
+type AuthzConfig struct {
+	// PermissionCacheTTL is how long roles, role permissions and user roles stay cached in-process; 0 disables caching
+	PermissionCacheTTL time.Duration `yaml:"permission_cache_ttl" toml:"permission_cache_ttl" env:"PERMISSION_CACHE_TTL"`
+}
+
+type RateLimiterConfig struct {
+	MaxRequest float64 `yaml:"max_request" toml:"max_request" env:"RATE_LIMITER_MAX_REQUEST"`
+	TimeSecond int     `yaml:"time_second" toml:"time_second" env:"RATE_LIMITER_TIME_SECOND"`
+}
+
+type CookieConfig struct {
+	Domain   string `yaml:"domain" toml:"domain" env:"COOKIE_DOMAIN"`         // Cookie domain for production
+	Secure   bool   `yaml:"secure" toml:"secure" env:"COOKIE_SECURE"`         // HTTPS requirement for cookies
+	SameSite string `yaml:"same_site" toml:"same_site" env:"COOKIE_SAMESITE"` // strict, lax or none
+	Path     string `yaml:"path" toml:"path" env:"COOKIE_PATH"`               // refresh cookie is only sent to this path
+	// HostPrefix names cookies "__Host-..." so they are bound to this exact host;
+	// it requires secure, no domain and path "/"
+	HostPrefix bool `yaml:"host_prefix" toml:"host_prefix" env:"COOKIE_HOST_PREFIX"`
+}
+
+// SameSite values accepted in CookieConfig.SameSite
+const (
+	SameSiteStrict = "strict"
+	SameSiteLax    = "lax"
+	SameSiteNone   = "none"
+)
+
+type SecurityConfig struct {
+	// HSTSMaxAge is sent as Strict-Transport-Security on HTTPS requests; 0 disables HSTS
+	HSTSMaxAge            time.Duration `yaml:"hsts_max_age" toml:"hsts_max_age" env:"HSTS_MAX_AGE"`
+	HSTSIncludeSubdomains bool          `yaml:"hsts_include_subdomains" toml:"hsts_include_subdomains" env:"HSTS_INCLUDE_SUBDOMAINS"`
+	ContentSecurityPolicy string        `yaml:"content_security_policy" toml:"content_security_policy" env:"CONTENT_SECURITY_POLICY"`
+	ReferrerPolicy        string        `yaml:"referrer_policy" toml:"referrer_policy" env:"REFERRER_POLICY"`
+	FrameOptions          string        `yaml:"frame_options" toml:"frame_options" env:"FRAME_OPTIONS"`
+}
+
+type CORSConfig struct {
+	// AllowedOrigins are exact origins ("https://app.example.com") or wildcard subdomains
+	// ("https://*.example.com"); empty allows only client_url
+	AllowedOrigins   []string      `yaml:"allowed_origins" toml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowCredentials bool          `yaml:"allow_credentials" toml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	AllowedMethods   []string      `yaml:"allowed_methods" toml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" toml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" toml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	MaxAge           time.Duration `yaml:"max_age" toml:"max_age" env:"CORS_MAX_AGE"` // preflight cache duration
+}
+
+type HealthConfig struct {
+	// CheckTimeout bounds each dependency check made by /readyz and /health/details
+	CheckTimeout time.Duration `yaml:"check_timeout" toml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+}
+
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"METRICS_ENABLED"`
+	Path    string `yaml:"path" toml:"path" env:"METRICS_PATH"` // Prometheus scrape path, served outside /api/v1
+}
+
+type TracingConfig struct {
+	// Exporter is one of TracingExporters; "none" keeps W3C propagation but records nothing
+	Exporter string `yaml:"exporter" toml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	// OTLPEndpoint is the OTLP/HTTP collector URL (e.g. http://localhost:4318); empty uses the exporter default
+	OTLPEndpoint string  `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	ServiceName  string  `yaml:"service_name" toml:"service_name" env:"OTEL_SERVICE_NAME"`
+	SampleRatio  float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"OTEL_TRACES_SAMPLER_ARG"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL"`    // debug, info, warn or error
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"` // json or text
+}
+
+type I18nConfig struct {
+	// DefaultLocale is used when neither the user's stored locale nor Accept-Language is supported (en or id)
+	DefaultLocale string `yaml:"default_locale" toml:"default_locale" env:"DEFAULT_LOCALE"`
+}
+
+type IdempotencyConfig struct {
+	// TTL is how long a response is replayed for retries with the same Idempotency-Key
+	TTL time.Duration `yaml:"ttl" toml:"ttl" env:"IDEMPOTENCY_TTL"`
+	// LockTimeout is how long an in-flight request holds its key before a retry may take it over,
+	// e.g. after a crash; keep it above server.write_timeout
+	LockTimeout time.Duration `yaml:"lock_timeout" toml:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT"`
+	// PurgeInterval is how often expired keys are deleted; 0 disables purging
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval" env:"IDEMPOTENCY_PURGE_INTERVAL"`
+}
+
+type EncryptionConfig struct {
+	// KeyFile is the local keyfile holding the master keys and blind index key for encrypted
+	// columns; create one with `encryption init` and keep it out of the repository
+	KeyFile string `yaml:"key_file" toml:"key_file" env:"ENCRYPTION_KEY_FILE"`
+}
+
+type OutboxConfig struct {
+	// PollInterval is how often the dispatcher looks for due messages; a full batch is
+	// followed by the next one right away
+	PollInterval time.Duration `yaml:"poll_interval" toml:"poll_interval" env:"OUTBOX_POLL_INTERVAL"`
+	BatchSize    int           `yaml:"batch_size" toml:"batch_size" env:"OUTBOX_BATCH_SIZE"`
+	// Lease is how long a claimed batch is hidden from other dispatchers; it must be above the
+	// time a batch can take to deliver, batch_size sends of send_timeout, or messages may be delivered twice
+	Lease time.Duration `yaml:"lease" toml:"lease" env:"OUTBOX_LEASE"`
+	// SendTimeout bounds the delivery of a single message
+	SendTimeout time.Duration `yaml:"send_timeout" toml:"send_timeout" env:"OUTBOX_SEND_TIMEOUT"`
+	// MaxAttempts is how many deliveries are tried before a message is dead-lettered
+	MaxAttempts int `yaml:"max_attempts" toml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS"`
+	// BackoffBase is the delay after the first failure, doubled after each further one up to BackoffMax
+	BackoffBase time.Duration `yaml:"backoff_base" toml:"backoff_base" env:"OUTBOX_BACKOFF_BASE"`
+	BackoffMax  time.Duration `yaml:"backoff_max" toml:"backoff_max" env:"OUTBOX_BACKOFF_MAX"`
+}
+
+// Log formats accepted in LogConfig.Format
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// Trace exporters accepted in TracingConfig.Exporter
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+	TracingExporterNone   = "none"
+)
+
+// Default returns the configuration used before any file, env var or flag is applied.
+// Secrets deliberately have no defaults; scripts/check-config-literals.sh keeps
+// credential-looking literals out of this package.
+func Default() *AppConfig {
+	return &AppConfig{
+		Environment: EnvDevelopment,
+		ClientURL:   "http://localhost:3002",
+		Server: ServerConfig{
+			Port:              8080,
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Port:     5432,
+			SSLMode:  "disable",
+			TimeZone: "Asia/Jakarta",
+			MaxConns: 10,
+		},
+		Migration: MigrationConfig{
+			Enabled: true,
+			Path:    "infra/databases/migrations",
+		},
+		JWT: JWTConfig{
+			AccessTTL:  15 * time.Minute,
+			RefreshTTL: 7 * 24 * time.Hour,
+		},
+		SMTP: SMTPConfig{
+			Service: "gmail",
+			Host:    "smtp.gmail.com",
+			Port:    465,
+		},
+		Authz: AuthzConfig{
+			PermissionCacheTTL: 5 * time.Minute,
+		},
+		RateLimiter: RateLimiterConfig{
+			MaxRequest: 10,
+			TimeSecond: 1,
+		},
+		Cookie: CookieConfig{
+			Secure:   true,
+			SameSite: SameSiteStrict,
+			Path:     "/api/v1/auth",
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            180 * 24 * time.Hour,
+			HSTSIncludeSubdomains: true,
+			// the API serves no HTML, so nothing may be loaded or framed
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'",
+			ReferrerPolicy:        "no-referrer",
+			FrameOptions:          "DENY",
+		},
+		CORS: CORSConfig{
+			AllowCredentials: true,
+			AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+			AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Request-ID", "X-CSRF-Token", "Idempotency-Key", "If-Match"},
+			ExposedHeaders:   []string{"X-Request-ID", "Idempotent-Replayed", "ETag"},
+			MaxAge:           10 * time.Minute,
+		},
+		Health: HealthConfig{
+			CheckTimeout: 2 * time.Second,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			ServiceName: "medisuite-api",
+			SampleRatio: 1,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: LogFormatJSON,
+		},
+		I18n: I18nConfig{
+			DefaultLocale: "en",
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           24 * time.Hour,
+			LockTimeout:   time.Minute,
+			PurgeInterval: time.Hour,
+		},
+		Outbox: OutboxConfig{
+			PollInterval: 2 * time.Second,
+			BatchSize:    50,
+			Lease:        5 * time.Minute,
+			SendTimeout:  4 * time.Second,
+			MaxAttempts:  10,
+			BackoffBase:  10 * time.Second,
+			BackoffMax:   time.Hour,
+		},
+	}
+}
+
+// Validate reports every setting the server cannot start with
+func (c *AppConfig) Validate() error {
+	var errs []error
+
+	switch c.Environment {
+	case EnvDevelopment, EnvStaging, EnvProduction:
+	default:
+		errs = append(errs, fmt.Errorf("environment must be one of %s, %s or %s, got %q", EnvDevelopment, EnvStaging, EnvProduction, c.Environment))
+	}
+	if _, err := url.ParseRequestURI(c.ClientURL); err != nil {
+		errs = append(errs, fmt.Errorf("client_url is not a valid URL: %q", c.ClientURL))
+	}
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.ReadTimeout <= 0 || c.Server.ReadHeaderTimeout <= 0 || c.Server.WriteTimeout <= 0 || c.Server.IdleTimeout <= 0 {
+		errs = append(errs, errors.New("server read, read header, write and idle timeouts must be positive"))
+	}
+	if c.Server.MaxHeaderBytes <= 0 {
+		errs = append(errs, errors.New("server.max_header_bytes must be positive"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+
+	if c.Database.Host == "" {
+		errs = append(errs, errors.New("database.host is required"))
+	}
+	if c.Database.User == "" {
+		errs = append(errs, errors.New("database.user is required"))
+	}
+	if c.Database.Name == "" {
+		errs = append(errs, errors.New("database.name is required"))
+	}
+	if c.Database.MaxConns < 1 {
+		errs = append(errs, fmt.Errorf("database.max_conns must be at least 1, got %d", c.Database.MaxConns))
+	}
+	if c.Migration.Enabled && c.Migration.Path == "" {
+		errs = append(errs, errors.New("migration.path is required when migrations are enabled"))
+	}
+
+	errs = append(errs, c.requireSettings("jwt.secret", "jwt.refresh_secret")...)
+	if c.JWT.AccessTTL <= 0 || c.JWT.RefreshTTL <= 0 {
+		errs = append(errs, errors.New("jwt.access_ttl and jwt.refresh_ttl must be positive"))
+	}
+
+	if c.SMTP.Host == "" || c.SMTP.Port < 1 || c.SMTP.Port > 65535 {
+		errs = append(errs, fmt.Errorf("smtp.host and smtp.port must be set, got %q:%d", c.SMTP.Host, c.SMTP.Port))
+	}
+	errs = append(errs, c.Cookie.validate(c.IsProduction())...)
+	if c.Security.HSTSMaxAge < 0 {
+		errs = append(errs, errors.New("security_headers.hsts_max_age cannot be negative"))
+	}
+	if slices.Contains(c.CORS.AllowedOrigins, "*") && (c.CORS.AllowCredentials || c.IsProduction()) {
+		errs = append(errs, errors.New(`cors.allowed_origins cannot contain "*" with credentials or in production`))
+	}
+	if c.CORS.MaxAge < 0 {
+		errs = append(errs, errors.New("cors.max_age cannot be negative"))
+	}
+	if c.Health.CheckTimeout <= 0 {
+		errs = append(errs, errors.New("health.check_timeout must be positive"))
+	}
+	if c.Metrics.Enabled && !strings.HasPrefix(c.Metrics.Path, "/") {
+		errs = append(errs, fmt.Errorf("metrics.path must start with /, got %q", c.Metrics.Path))
+	}
+	switch c.Tracing.Exporter {
+	case TracingExporterOTLP, TracingExporterStdout, TracingExporterNone:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter must be one of %s, %s or %s, got %q", TracingExporterOTLP, TracingExporterStdout, TracingExporterNone, c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio must be between 0 and 1, got %g", c.Tracing.SampleRatio))
+	}
+	if _, err := c.Log.SlogLevel(); err != nil {
+		errs = append(errs, fmt.Errorf("log.level must be debug, info, warn or error, got %q", c.Log.Level))
+	}
+	if c.Log.Format != LogFormatJSON && c.Log.Format != LogFormatText {
+		errs = append(errs, fmt.Errorf("log.format must be %s or %s, got %q", LogFormatJSON, LogFormatText, c.Log.Format))
+	}
+	if c.I18n.DefaultLocale == "" {
+		errs = append(errs, errors.New("i18n.default_locale is required"))
+	}
+	if c.Idempotency.TTL <= 0 || c.Idempotency.LockTimeout <= 0 {
+		errs = append(errs, errors.New("idempotency.ttl and idempotency.lock_timeout must be positive"))
+	}
+	if c.Idempotency.PurgeInterval < 0 {
+		errs = append(errs, errors.New("idempotency.purge_interval cannot be negative"))
+	}
+	if c.Authz.PermissionCacheTTL < 0 {
+		errs = append(errs, errors.New("authz.permission_cache_ttl cannot be negative"))
+	}
+	errs = append(errs, c.requireSettings("encryption.key_file")...)
+	if c.Outbox.PollInterval <= 0 || c.Outbox.Lease <= 0 || c.Outbox.BackoffBase <= 0 || c.Outbox.BackoffMax <= 0 {
+		errs = append(errs, errors.New("outbox.poll_interval, outbox.lease, outbox.backoff_base and outbox.backoff_max must be positive"))
+	}
+	if c.Outbox.BatchSize <= 0 || c.Outbox.MaxAttempts <= 0 {
+		errs = append(errs, errors.New("outbox.batch_size and outbox.max_attempts must be positive"))
+	}
+	if c.Outbox.SendTimeout <= 0 {
+		errs = append(errs, errors.New("outbox.send_timeout must be positive"))
+	} else if c.Outbox.BatchSize > 0 && c.Outbox.SendTimeout*time.Duration(c.Outbox.BatchSize) >= c.Outbox.Lease {
+		errs = append(errs, fmt.Errorf("outbox.lease (%s) must be above outbox.batch_size × outbox.send_timeout (%s)",
+			c.Outbox.Lease, c.Outbox.SendTimeout*time.Duration(c.Outbox.BatchSize)))
+	}
+
+	// secrets have no defaults, so production refuses to start without them
+	if c.IsProduction() {
+		errs = append(errs, c.requireSettings(productionSecrets...)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// productionSecrets are the settings that must be provided when running in production
+var productionSecrets = []string{"database.password", "smtp.user", "smtp.password"}
+
+// requireSettings returns an error for each of keys that is empty, naming where it can be set
+func (c *AppConfig) requireSettings(keys ...string) []error {
+	var errs []error
+	for _, s := range settingsOf(c) {
+		if !slices.Contains(keys, s.key) || !s.value.IsZero() {
+			continue
+		}
+		sources := make([]string, 0, 2*len(s.envs))
+		for _, env := range s.envs {
+			sources = append(sources, env)
+			if s.secret {
+				sources = append(sources, env+"_FILE")
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s is required in %s (set %s)", s.key, c.Environment, strings.Join(sources, " or ")))
+	}
+	return errs
+}
+
+// IsProduction reports whether the server runs in production mode
+func (c *AppConfig) IsProduction() bool {
+	return c.Environment == EnvProduction
+}
+
+// DSN builds the postgres connection string
+func (d DatabaseConfig) DSN() string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(d.User, d.Password),
+		Host:   fmt.Sprintf("%s:%d", d.Host, d.Port),
+		Path:   d.Name,
+	}
+	q := url.Values{}
+	q.Set("sslmode", d.SSLMode)
+	if d.TimeZone != "" {
+		q.Set("timezone", d.TimeZone)
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// SlogLevel parses the configured log level
+func (l LogConfig) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(l.Level))
+	return level, err
+}
+
+// validate checks the cookie attributes browsers would otherwise silently reject
+func (k CookieConfig) validate(production bool) []error {
+	var errs []error
+	switch k.SameSite {
+	case SameSiteStrict, SameSiteLax:
+	case SameSiteNone:
+		if !k.Secure {
+			errs = append(errs, errors.New("cookie.same_site none requires cookie.secure"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("cookie.same_site must be %s, %s or %s, got %q", SameSiteStrict, SameSiteLax, SameSiteNone, k.SameSite))
+	}
+	if !strings.HasPrefix(k.Path, "/") {
+		errs = append(errs, fmt.Errorf("cookie.path must start with /, got %q", k.Path))
+	}
+	if k.HostPrefix && (!k.Secure || k.Domain != "" || k.Path != "/") {
+		errs = append(errs, errors.New("cookie.host_prefix requires cookie.secure, an empty cookie.domain and cookie.path /"))
+	}
+	if production && !k.Secure {
+		errs = append(errs, errors.New("cookie.secure is required in production"))
+	}
+	return errs
+}
+
+// CORSOrigins returns the allowed CORS origins, defaulting to the client URL
+func (c *AppConfig) CORSOrigins() []string {
+	if len(c.CORS.AllowedOrigins) > 0 {
+		return c.CORS.AllowedOrigins
+	}
+	return []string{c.ClientURL}
+}
+
+// Addr returns the SMTP server address in host:port form
+func (s SMTPConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// TestNoCredentialLiterals runs scripts/check-config-literals.sh over this package so
+// credentials cannot be hard-coded in the config sources again
+func TestNoCredentialLiterals(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	out, err := exec.Command(sh, "../scripts/check-config-literals.sh", ".").CombinedOutput()
+	if err != nil {
+		t.Fatalf("check-config-literals.sh: %v\n%s", err, out)
+	}
+}
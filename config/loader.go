@@ -0,0 +1,329 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// FileFlag is the flag naming the YAML/TOML config file; CONFIG_FILE is its env equivalent
+const FileFlag = "config"
+
+// defaultFiles are looked up in the working directory when no config file is given
+var defaultFiles = []string{"config.yaml", "config.yml", "config.toml"}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setting is one leaf of AppConfig, addressed by its dotted file key (e.g. "database.host")
+type setting struct {
+	key    string
+	envs   []string
+	secret bool
+	value  reflect.Value
+}
+
+// Load resolves the configuration from defaults, the config file, environment variables
+// (after loading .env.<GO_ENV> or .env) and flags registered with RegisterFlags.
+// flags may be nil. The result is not validated; call Validate before serving.
+func Load(flags *pflag.FlagSet) (*AppConfig, error) {
+	loadDotEnv()
+
+	cfg := Default()
+	settings := settingsOf(cfg)
+
+	// config file
+	path, err := configFile(flags)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range settings {
+			raw, ok := values[s.key]
+			if !ok {
+				continue
+			}
+			if err := s.set(raw); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			delete(values, s.key)
+		}
+		if len(values) > 0 {
+			return nil, fmt.Errorf("%s: unknown config keys %s", path, strings.Join(sortedKeys(values), ", "))
+		}
+		slog.Info("Loaded config file", "path", path)
+	}
+
+	// environment variables; secrets may also come from a <ENV>_FILE path
+	for _, s := range settings {
+		for _, env := range s.envs {
+			raw, err := lookupEnv(env, s.secret)
+			if err != nil {
+				return nil, err
+			}
+			if raw == "" {
+				continue
+			}
+			if err := s.set(raw); err != nil {
+				return nil, fmt.Errorf("env %s: %w", env, err)
+			}
+			break
+		}
+	}
+
+	// flags
+	if flags != nil {
+		for _, s := range settings {
+			if !flags.Changed(s.key) {
+				continue
+			}
+			raw, err := flags.GetString(s.key)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.set(raw); err != nil {
+				return nil, fmt.Errorf("flag --%s: %w", s.key, err)
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// RegisterFlags adds --config and one string flag per setting (e.g. --database.host) to fs
+func RegisterFlags(fs *pflag.FlagSet) {
+	fs.String(FileFlag, "", "path to a YAML or TOML config file (env CONFIG_FILE)")
+	for _, s := range settingsOf(Default()) {
+		usage := "overrides " + s.key
+		if len(s.envs) > 0 {
+			usage += " (env " + strings.Join(s.envs, ", ")
+			if s.secret {
+				usage += " or " + s.envs[0] + "_FILE"
+			}
+			usage += ")"
+		}
+		fs.String(s.key, "", usage)
+	}
+}
+
+// Redacted returns a copy of the configuration with every secret setting masked
+func (c *AppConfig) Redacted() *AppConfig {
+	copied := *c
+	for _, s := range settingsOf(&copied) {
+		if s.secret && s.value.String() != "" {
+			s.value.SetString("******")
+		}
+	}
+	return &copied
+}
+
+// loadDotEnv loads .env.<GO_ENV>, falling back to .env; existing env vars are never overridden
+func loadDotEnv() {
+	env := os.Getenv("GO_ENV")
+	if env == "" {
+		env = EnvDevelopment
+	}
+
+	if err := godotenv.Load(".env." + env); err != nil {
+		slog.Debug("No .env." + env + " file loaded")
+		if err := godotenv.Load(".env"); err != nil {
+			slog.Debug("No .env file loaded")
+		}
+	}
+}
+
+// lookupEnv returns the value of env. For secrets, env+"_FILE" may instead name a file
+// holding the value (Docker/Kubernetes secrets); setting both is an error.
+func lookupEnv(env string, secret bool) (string, error) {
+	value := os.Getenv(env)
+	if !secret {
+		return value, nil
+	}
+
+	path := os.Getenv(env + "_FILE")
+	if path == "" {
+		return value, nil
+	}
+	if value != "" {
+		return "", fmt.Errorf("both %s and %s_FILE are set, use only one", env, env)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("env %s_FILE: %w", env, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// configFile returns the config file to read, or "" when none is given or found
+func configFile(flags *pflag.FlagSet) (string, error) {
+	path := os.Getenv("CONFIG_FILE")
+	if flags != nil && flags.Changed(FileFlag) {
+		path, _ = flags.GetString(FileFlag)
+	}
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("config file: %w", err)
+		}
+		return path, nil
+	}
+
+	for _, candidate := range defaultFiles {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// readFile decodes a YAML or TOML file into dotted keys and their raw string values
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported extension, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", tree, values)
+	return values, nil
+}
+
+// flatten turns nested maps into dotted keys; lists become comma-separated values
+func flatten(prefix string, tree map[string]any, out map[string]string) {
+	for k, v := range tree {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			flatten(key, val, out)
+		case []any:
+			items := make([]string, len(val))
+			for i, item := range val {
+				items[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+}
+
+// settingsOf lists every leaf field of cfg, keyed by its yaml tags
+func settingsOf(cfg *AppConfig) []setting {
+	var settings []setting
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key := f.Tag.Get("yaml")
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if f.Type.Kind() == reflect.Struct && f.Type != durationType {
+				walk(v.Field(i), key)
+				continue
+			}
+			s := setting{key: key, secret: f.Tag.Get("secret") == "true", value: v.Field(i)}
+			if env := f.Tag.Get("env"); env != "" {
+				s.envs = strings.Split(env, ",")
+			}
+			settings = append(settings, s)
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return settings
+}
+
+// set parses raw into the setting's field
+func (s setting) set(raw string) error {
+	v := s.value
+	raw = strings.TrimSpace(raw)
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s: invalid duration %q", s.key, raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: invalid boolean %q", s.key, raw)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q", s.key, raw)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %q", s.key, raw)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return errors.New(s.key + ": unsupported list type")
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s: unsupported type %s", s.key, v.Type())
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
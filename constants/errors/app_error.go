@@ -0,0 +1,52 @@
+package errors
+
+import "errors"
+
+// AppError is an application error exposed to clients: it carries the HTTP status to respond with,
+// a stable machine-readable code (e.g. "TREATMENT_NOT_FOUND"), a human message, optional details
+// and whether retrying the same request may succeed.
+type AppError struct {
+	Status    int
+	Code      string
+	Message   string
+	Details   any
+	Retryable bool
+}
+
+// New creates an AppError; codes must be unique since errors.Is matches on the code
+func New(status int, code string, message string) *AppError {
+	return &AppError{Status: status, Code: code, Message: message}
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an AppError with the same code, so errors.Is still matches
+// the sentinel after WithDetails
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// WithDetails returns a copy of e carrying details (e.g. field validation errors)
+func (e *AppError) WithDetails(details any) *AppError {
+	err := *e
+	err.Details = details
+	return &err
+}
+
+// retryable marks e as retryable, used when declaring sentinels
+func (e *AppError) retryable() *AppError {
+	e.Retryable = true
+	return e
+}
+
+// AsAppError returns the AppError in err's chain
+func AsAppError(err error) (*AppError, bool) {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
@@ -1,17 +1,33 @@
 package errors
 
-import "errors"
+import "net/http"
 
 var (
-	ErrInternalServerError = errors.New("Internal Server Error")
-	ErrSQLError            = errors.New("database server failed to execute query")
-	ErrTooManyRequests     = errors.New("too many requests")
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrInvalidToken        = errors.New("invalid token")
-	ErrInvalidUploadFile   = errors.New("invalid upload file")
-	ErrSizeTooBig          = errors.New("size too big")
-	ErrForbidden           = errors.New("forbidden")
-	ErrSendEmail           = errors.New("error send mail")
+	ErrInternalServerError = New(http.StatusInternalServerError, "INTERNAL_ERROR", "Internal Server Error")
+	ErrSQLError            = New(http.StatusInternalServerError, "DATABASE_ERROR", "database server failed to execute query").retryable()
+	ErrTooManyRequests     = New(http.StatusTooManyRequests, "TOO_MANY_REQUESTS", "too many requests").retryable()
+	ErrUnauthorized        = New(http.StatusUnauthorized, "UNAUTHORIZED", "unauthorized")
+	ErrInvalidToken        = New(http.StatusUnauthorized, "INVALID_TOKEN", "invalid token")
+	ErrInvalidUploadFile   = New(http.StatusBadRequest, "INVALID_UPLOAD_FILE", "invalid upload file")
+	ErrSizeTooBig          = New(http.StatusRequestEntityTooLarge, "SIZE_TOO_BIG", "size too big")
+	ErrForbidden           = New(http.StatusForbidden, "FORBIDDEN", "forbidden")
+	ErrSendEmail           = New(http.StatusBadGateway, "EMAIL_SEND_FAILED", "error send mail").retryable()
+	ErrResourceNotFound    = New(http.StatusNotFound, "RESOURCE_NOT_FOUND", "resource not found")
+	ErrCSRFTokenInvalid    = New(http.StatusForbidden, "CSRF_TOKEN_INVALID", "missing or invalid CSRF token")
+	ErrInvalidRequest      = New(http.StatusBadRequest, "INVALID_REQUEST", "malformed request")
+	ErrInvalidID           = New(http.StatusBadRequest, "INVALID_ID", "invalid ID format")
+	ErrValidationFailed    = New(http.StatusUnprocessableEntity, "VALIDATION_FAILED", "request validation failed")
+
+	ErrPreconditionRequired = New(http.StatusPreconditionRequired, "PRECONDITION_REQUIRED", "If-Match header with the resource ETag is required")
+	ErrPreconditionFailed   = New(http.StatusPreconditionFailed, "PRECONDITION_FAILED", "the resource was modified by another request; fetch it again and retry with its current ETag")
+
+	ErrIdempotencyKeyInvalid = New(http.StatusBadRequest, "IDEMPOTENCY_KEY_INVALID", "Idempotency-Key must be 1 to 255 printable characters")
+	ErrIdempotencyKeyReused  = New(http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used for a different request")
+	ErrIdempotencyInProgress = New(http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS", "a request with this Idempotency-Key is still being processed").retryable()
+
+	ErrOutboxMessageNotFound = New(http.StatusNotFound, "OUTBOX_MESSAGE_NOT_FOUND", "no dead-lettered outbox message with this ID")
+
+	ErrTokenStale = New(http.StatusUnauthorized, "TOKEN_STALE", "your permissions changed since the access token was issued; refresh it")
 )
 
 var GeneralErrors = []error{
@@ -24,4 +40,16 @@ var GeneralErrors = []error{
 	ErrSizeTooBig,
 	ErrForbidden,
 	ErrSendEmail,
+	ErrResourceNotFound,
+	ErrCSRFTokenInvalid,
+	ErrInvalidRequest,
+	ErrInvalidID,
+	ErrValidationFailed,
+	ErrPreconditionRequired,
+	ErrPreconditionFailed,
+	ErrIdempotencyKeyInvalid,
+	ErrIdempotencyKeyReused,
+	ErrIdempotencyInProgress,
+	ErrOutboxMessageNotFound,
+	ErrTokenStale,
 }
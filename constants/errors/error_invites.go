@@ -0,0 +1,17 @@
+package errors
+
+import "net/http"
+
+var (
+	ErrInviteNotFound       = New(http.StatusNotFound, "INVITE_NOT_FOUND", "invite not found")
+	ErrInviteInvalid        = New(http.StatusConflict, "INVITE_INVALID", "invite is invalid or has already been used")
+	ErrInviteExpired        = New(http.StatusGone, "INVITE_EXPIRED", "invite is expired")
+	ErrInviteAlreadyPending = New(http.StatusConflict, "INVITE_ALREADY_PENDING", "a pending invite already exists for this email")
+)
+
+var InviteErrorMessage = []error{
+	ErrInviteNotFound,
+	ErrInviteInvalid,
+	ErrInviteExpired,
+	ErrInviteAlreadyPending,
+}
@@ -1,16 +1,22 @@
 package errors
 
+// MappingError reports whether err is an application error whose message may be shown to clients
 func MappingError(err error) bool {
-	allErrors := make([]error, 0)
-	allErrors = append(allErrors, GeneralErrors...)
-	allErrors = append(allErrors, ServiceErrorMessage...)
+	_, ok := AsAppError(err)
+	return ok
+}
+
+// All returns every application error declared in this package
+func All() []*AppError {
+	lists := [][]error{GeneralErrors, InviteErrorMessage, RoleErrorMessage, ServiceErrorMessage, FindUserErr, PatientErrorMessage}
 
-	// check errors
-	for _, item := range allErrors {
-		if err.Error() == item.Error() {
-			return true
+	all := make([]*AppError, 0)
+	for _, list := range lists {
+		for _, err := range list {
+			if appErr, ok := AsAppError(err); ok {
+				all = append(all, appErr)
+			}
 		}
 	}
-
-	return false
+	return all
 }
@@ -0,0 +1,15 @@
+package errors
+
+import "net/http"
+
+var (
+	ErrCareTeamMemberInvalid  = New(http.StatusUnprocessableEntity, "CARE_TEAM_MEMBER_INVALID", "only staff can be assigned to a patient's care team")
+	ErrCareTeamMemberNotFound = New(http.StatusNotFound, "CARE_TEAM_MEMBER_NOT_FOUND", "the staff member is not on this patient's care team")
+	ErrBreakGlassRequired     = New(http.StatusForbidden, "BREAK_GLASS_REQUIRED", "you are not on this patient's care team; break the glass with a reason for emergency access")
+)
+
+var PatientErrorMessage = []error{
+	ErrCareTeamMemberInvalid,
+	ErrCareTeamMemberNotFound,
+	ErrBreakGlassRequired,
+}
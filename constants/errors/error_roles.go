@@ -1,13 +1,15 @@
 package errors
 
-import "errors"
+import "net/http"
 
 var (
-	ErrRoleNotFound      = errors.New("role not found")
-	ErrRoleInvalid       = errors.New("role invalid")
-	ErrRoleAlreadyExists = errors.New("role already exists")
-	ErrRoleDeleted       = errors.New("role deleted")
-	ErrRoleSelfRegister  = errors.New("role self register")
+	ErrRoleNotFound      = New(http.StatusNotFound, "ROLE_NOT_FOUND", "role not found")
+	ErrRoleInvalid       = New(http.StatusUnprocessableEntity, "ROLE_INVALID", "role invalid")
+	ErrRoleAlreadyExists = New(http.StatusConflict, "ROLE_ALREADY_EXISTS", "role already exists")
+	ErrRoleDeleted       = New(http.StatusGone, "ROLE_DELETED", "role deleted")
+	ErrRoleSelfRegister  = New(http.StatusForbidden, "ROLE_SELF_REGISTER_NOT_ALLOWED", "role self register")
+	ErrRoleLevelTooHigh  = New(http.StatusForbidden, "ROLE_LEVEL_TOO_HIGH", "cannot manage a role at or above your own level")
+	ErrRoleLevelTooLow   = New(http.StatusForbidden, "ROLE_LEVEL_TOO_LOW", "your role level is too low for this action")
 )
 
 var RoleErrorMessage = []error{
@@ -16,4 +18,6 @@ var RoleErrorMessage = []error{
 	ErrRoleAlreadyExists,
 	ErrRoleDeleted,
 	ErrRoleSelfRegister,
+	ErrRoleLevelTooHigh,
+	ErrRoleLevelTooLow,
 }
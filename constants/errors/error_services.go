@@ -1,22 +1,22 @@
 package errors
 
-import "errors"
+import "net/http"
 
 var (
-	ErrFindCategories     = errors.New("Error find categories")
-	ErrCreateCategory     = errors.New("Error create category")
-	ErrUpdateCategory     = errors.New("Error update category")
-	ErrDeleteCategory     = errors.New("Error delete category")
-	ErrFindCategoryId     = errors.New("Error find category id")
-	ErrCategoryExist      = errors.New("Category exist")
-	ErrFindCategoryByName = errors.New("Error find category by name")
-	ErrFindTreatment      = errors.New("Error find treatment")
-	ErrCreateTreatment    = errors.New("Error create treatment")
-	ErrUpdateTreatment    = errors.New("Error update treatment")
-	ErrDeleteTreatment    = errors.New("Error delete treatment")
-	ErrFindTreatmentId    = errors.New("Error find treatment id")
-	ErrFindTreatmentByName = errors.New("Error find treatment by name")
-	ErrTreatmentExist     = errors.New("Treatment exist")
+	ErrFindCategories     = New(http.StatusInternalServerError, "CATEGORY_FIND_FAILED", "Error find categories")
+	ErrCreateCategory     = New(http.StatusInternalServerError, "CATEGORY_CREATE_FAILED", "Error create category")
+	ErrUpdateCategory     = New(http.StatusInternalServerError, "CATEGORY_UPDATE_FAILED", "Error update category")
+	ErrDeleteCategory     = New(http.StatusInternalServerError, "CATEGORY_DELETE_FAILED", "Error delete category")
+	ErrFindCategoryId     = New(http.StatusNotFound, "CATEGORY_NOT_FOUND", "Error find category id")
+	ErrCategoryExist      = New(http.StatusConflict, "CATEGORY_ALREADY_EXISTS", "Category exist")
+	ErrFindCategoryByName = New(http.StatusNotFound, "CATEGORY_NAME_NOT_FOUND", "Error find category by name")
+	ErrFindTreatment      = New(http.StatusInternalServerError, "TREATMENT_FIND_FAILED", "Error find treatment")
+	ErrCreateTreatment    = New(http.StatusInternalServerError, "TREATMENT_CREATE_FAILED", "Error create treatment")
+	ErrUpdateTreatment    = New(http.StatusInternalServerError, "TREATMENT_UPDATE_FAILED", "Error update treatment")
+	ErrDeleteTreatment    = New(http.StatusInternalServerError, "TREATMENT_DELETE_FAILED", "Error delete treatment")
+	ErrFindTreatmentId    = New(http.StatusNotFound, "TREATMENT_NOT_FOUND", "Error find treatment id")
+	ErrFindTreatmentByName = New(http.StatusNotFound, "TREATMENT_NAME_NOT_FOUND", "Error find treatment by name")
+	ErrTreatmentExist     = New(http.StatusConflict, "TREATMENT_ALREADY_EXISTS", "Treatment exist")
 )
 
 var ServiceErrorMessage = []error{
@@ -1,38 +1,40 @@
 package errors
 
-import "errors"
+import "net/http"
 
 var (
-	ErrUserNotFound            = errors.New("user not found")
-	ErrUserAlreadyExists       = errors.New("user already exists")
-	ErrUserInvalid             = errors.New("user invalid")
-	ErrUserPassword            = errors.New("user password")
-	ErrUserPasswordNotMatch    = errors.New("user password not match")
-	ErrUserEmailNotFound       = errors.New("user email not found")
-	ErrUserEmailAlreadyExists  = errors.New("user email already exists")
-	ErrUserEmailInvalid        = errors.New("user email invalid")
-	ErrUserEmailPassword       = errors.New("user email password")
-	ErrRoleAttempted           = errors.New("invalid role attempted")
-	ErrOwnerAlreadyExists      = errors.New("owner already exists")
-	ErrTokenInvalid            = errors.New("verification token is invalid")
-	ErrTokenExpired            = errors.New("verification token is expired")
-	ErrVerifyCodeExpired       = errors.New("verification code is expired")
-	ErrUserAlreadyVerified     = errors.New("user is already verified")
-	ErrUserNotVerified         = errors.New("user is not verified")
-	ErrInvalidCredentials      = errors.New("invalid email or password")
-	ErrTokenNotFound           = errors.New("token not found")
-	ErrVerifyCodeNotFound      = errors.New("verify code not found")
-	ErrUserFailedToVerify      = errors.New("user failed to verify")
-	ErrCreateUser              = errors.New("failed to create new user")
-	ErrUpdatedUser             = errors.New("Failed to update user")
-	ErrUserNotOwner            = errors.New("User not owner")
-	ErrUserDeleted             = errors.New("User deleted")
-	ErrInsufficientPermissions = errors.New("insufficient permissions for this action")
-	ErrInvalidRole             = errors.New("invalid role")
+	ErrUserNotFound            = New(http.StatusNotFound, "USER_NOT_FOUND", "user not found")
+	ErrUserAlreadyExists       = New(http.StatusConflict, "USER_ALREADY_EXISTS", "user already exists")
+	ErrUserInvalid             = New(http.StatusUnprocessableEntity, "USER_INVALID", "user invalid")
+	ErrUserPassword            = New(http.StatusInternalServerError, "PASSWORD_HASH_FAILED", "user password")
+	ErrUserPasswordNotMatch    = New(http.StatusUnprocessableEntity, "PASSWORD_NOT_MATCH", "user password not match")
+	ErrUserEmailNotFound       = New(http.StatusNotFound, "EMAIL_NOT_FOUND", "user email not found")
+	ErrUserEmailAlreadyExists  = New(http.StatusConflict, "EMAIL_ALREADY_EXISTS", "user email already exists")
+	ErrUserEmailInvalid        = New(http.StatusUnprocessableEntity, "EMAIL_INVALID", "user email invalid")
+	ErrUserEmailPassword       = New(http.StatusUnauthorized, "EMAIL_PASSWORD_INVALID", "user email password")
+	ErrRoleAttempted           = New(http.StatusForbidden, "ROLE_NOT_ALLOWED", "invalid role attempted")
+	ErrOwnerAlreadyExists      = New(http.StatusConflict, "OWNER_ALREADY_EXISTS", "owner already exists")
+	ErrTokenInvalid            = New(http.StatusBadRequest, "VERIFICATION_TOKEN_INVALID", "verification token is invalid")
+	ErrTokenExpired            = New(http.StatusUnauthorized, "TOKEN_EXPIRED", "verification token is expired")
+	ErrVerifyCodeExpired       = New(http.StatusGone, "VERIFY_CODE_EXPIRED", "verification code is expired")
+	ErrUserAlreadyVerified     = New(http.StatusConflict, "USER_ALREADY_VERIFIED", "user is already verified")
+	ErrUserNotVerified         = New(http.StatusForbidden, "USER_NOT_VERIFIED", "user is not verified")
+	ErrInvalidCredentials      = New(http.StatusUnauthorized, "INVALID_CREDENTIALS", "invalid email or password")
+	ErrTokenNotFound           = New(http.StatusUnauthorized, "TOKEN_NOT_FOUND", "token not found")
+	ErrVerifyCodeNotFound      = New(http.StatusNotFound, "VERIFY_CODE_NOT_FOUND", "verify code not found")
+	ErrUserFailedToVerify      = New(http.StatusInternalServerError, "USER_VERIFY_FAILED", "user failed to verify")
+	ErrCreateUser              = New(http.StatusInternalServerError, "USER_CREATE_FAILED", "failed to create new user")
+	ErrUpdatedUser             = New(http.StatusInternalServerError, "USER_UPDATE_FAILED", "Failed to update user")
+	ErrUserNotOwner            = New(http.StatusForbidden, "USER_NOT_OWNER", "User not owner")
+	ErrUserDeleted             = New(http.StatusGone, "USER_DELETED", "User deleted")
+	ErrInsufficientPermissions = New(http.StatusForbidden, "INSUFFICIENT_PERMISSIONS", "insufficient permissions for this action")
+	ErrInvalidRole             = New(http.StatusForbidden, "INVALID_ROLE", "invalid role")
+	ErrLocaleUnsupported       = New(http.StatusUnprocessableEntity, "LOCALE_UNSUPPORTED", "unsupported locale")
 )
 
 var FindUserErr = []error{
 	ErrUserNotFound,
+	ErrUserAlreadyExists,
 	ErrUserInvalid,
 	ErrUserPassword,
 	ErrUserEmailNotFound,
@@ -44,6 +46,7 @@ var FindUserErr = []error{
 	ErrUserAlreadyVerified,
 	ErrTokenInvalid,
 	ErrTokenExpired,
+	ErrVerifyCodeExpired,
 	ErrUserNotVerified,
 	ErrInvalidCredentials,
 	ErrUserPasswordNotMatch,
@@ -56,4 +59,5 @@ var FindUserErr = []error{
 	ErrUserDeleted,
 	ErrInsufficientPermissions,
 	ErrInvalidRole,
+	ErrLocaleUnsupported,
 }
@@ -4,5 +4,13 @@ const (
 	RequiredErrorMsg = "%s is required"
 	EmailErrorMsg    = "%s is not a valid email address"
 	OneOfErrorMsg    = "%s must be one of [%s]"
+	MinErrorMsg      = "%s must be at least %s"
+	MaxErrorMsg      = "%s must be at most %s"
+	PasswordErrorMsg = "%s must contain an upper case letter, a lower case letter, a digit and a special character"
+	PhoneErrorMsg    = "%s is not a valid Indonesian phone number"
+	UUIDErrorMsg     = "%s is not a valid UUID"
+	MoneyErrorMsg    = "%s must be a non-negative amount with at most two decimals"
+	NotNullErrorMsg  = "%s cannot be null"
+	AfterErrorMsg    = "%s must be after %s"
 	GeneralError     = "An unexpected error occurred"
 )
@@ -0,0 +1,109 @@
+package locales
+
+import (
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/success"
+)
+
+// successMessages keys the success messages, whose English text lives in constants/success
+var successMessages = map[string]string{
+	// general
+	"SUCCESS_OPERATION_COMPLETED": success.SuccessGeneral,
+	"SUCCESS_DATA_RETRIEVED":      success.SuccessDataRetrieved,
+	"SUCCESS_DATA_CREATED":        success.SuccessDataCreated,
+	"SUCCESS_DATA_UPDATED":        success.SuccessDataUpdated,
+	"SUCCESS_DATA_DELETED":        success.SuccessDataDeleted,
+	"SUCCESS_EMAIL_SENT":          success.SuccessEmailSent,
+	"SUCCESS_FILE_UPLOADED":       success.SuccessFileUploaded,
+	"SUCCESS_ROUTES_FOUND":        success.SuccessFindAllRoutes,
+	"SUCCESS_AUDIT_EVENTS_FOUND":  success.SuccessFindAuditEvents,
+
+	// outbox
+	"SUCCESS_OUTBOX_MESSAGES_FOUND":   success.SuccessFindOutboxMessages,
+	"SUCCESS_OUTBOX_MESSAGE_REQUEUED": success.SuccessRequeueOutboxMessage,
+
+	// invites
+	"SUCCESS_INVITE_CREATED":        success.SuccessCreateInvite,
+	"SUCCESS_PENDING_INVITES_FOUND": success.SuccessFindPendingInvite,
+	"SUCCESS_INVITE_RESENT":         success.SuccessResendInvite,
+	"SUCCESS_INVITE_REVOKED":        success.SuccessRevokeInvite,
+	"SUCCESS_INVITE_ACCEPTED":       success.SuccessAcceptInvite,
+
+	// patients
+	"SUCCESS_PATIENT_RECORD_FOUND": success.SuccessFindPatientRecord,
+	"SUCCESS_BREAK_GLASS_GRANTED":  success.SuccessBreakGlass,
+	"SUCCESS_ACCESS_LOG_FOUND":     success.SuccessFindAccessLog,
+
+	// care team
+	"SUCCESS_CARE_TEAM_ASSIGNED":   success.SuccessAssignCareTeam,
+	"SUCCESS_CARE_TEAM_UNASSIGNED": success.SuccessUnassignCareTeam,
+
+	// roles
+	"SUCCESS_ROLES_FOUND":   success.SuccessFindAllRoles,
+	"SUCCESS_ROLE_CREATED":  success.SuccessCreateRole,
+	"SUCCESS_ROLE_UPDATED":  success.SuccessUpdateRole,
+	"SUCCESS_ROLE_ASSIGNED": success.SuccessAssignRole,
+
+	// categories and treatments
+	"SUCCESS_CATEGORY_CREATED":  success.SuccessCreateCategory,
+	"SUCCESS_CATEGORY_UPDATED":  success.SuccessUpdateCategory,
+	"SUCCESS_CATEGORY_DELETED":  success.SuccessDeleteCategory,
+	"SUCCESS_CATEGORIES_FOUND":  success.SuccessFindAllCategory,
+	"SUCCESS_CATEGORY_FOUND":    success.SuccessFindCategoryById,
+	"SUCCESS_TREATMENT_CREATED": success.SuccessCreateTreatment,
+	"SUCCESS_TREATMENT_UPDATED": success.SuccessUpdateTreatment,
+	"SUCCESS_TREATMENT_DELETED": success.SuccessDeleteTreatment,
+	"SUCCESS_TREATMENTS_FOUND":  success.SuccessFindAllTreatment,
+	"SUCCESS_TREATMENT_FOUND":   success.SuccessFindTreatmentById,
+
+	// users
+	"SUCCESS_USER_CREATED":               success.SuccessCreateUser,
+	"SUCCESS_USER_UPDATED":               success.SuccessUpdateUser,
+	"SUCCESS_USER_DELETED":               success.SuccessDeleteUser,
+	"SUCCESS_USER_VERIFIED":              success.SuccessVerifyUser,
+	"SUCCESS_USER_LOGGED_IN":             success.SuccessLoginUser,
+	"SUCCESS_USER_LOGGED_OUT":            success.SuccessLogoutUser,
+	"SUCCESS_FORGOT_PASSWORD":            success.SuccessForgotPassword,
+	"SUCCESS_PASSWORD_RESET":             success.SuccessResetPassword,
+	"SUCCESS_USER_EMAIL_SENT":            success.SuccessSendEmail,
+	"SUCCESS_FORGOT_PASSWORD_EMAIL_SENT": success.SuccessSendEmailForgotPassword,
+	"SUCCESS_RESET_PASSWORD_EMAIL_SENT":  success.SendEmailSuccessResetPassword,
+	"SUCCESS_EMAIL_VERIFIED":             success.SuccessVerifyEmail,
+	"SUCCESS_TOKEN_VERIFIED":             success.SuccessVerifyToken,
+	"SUCCESS_USER_FOUND":                 success.SuccessFindUser,
+	"SUCCESS_USER_FOUND_BY_ID":           success.SuccessFindUserId,
+	"SUCCESS_VERIFICATION_RESENT":        success.SuccessResendVerifyAccount,
+	"SUCCESS_TOKEN_GENERATED":            success.SuccessGenerateNewToken,
+	"SUCCESS_TOKEN_REFRESHED":            success.SuccessRefreshToken,
+	"SUCCESS_LOCALE_UPDATED":             success.SuccessUpdateLocale,
+}
+
+// en is the English catalog; error messages come from the application errors themselves
+var en = map[string]string{
+	// validation, formatted with the field name and the rule parameter
+	ValidationRequired: errConsts.RequiredErrorMsg,
+	ValidationEmail:    errConsts.EmailErrorMsg,
+	ValidationOneOf:    errConsts.OneOfErrorMsg,
+	ValidationMin:      errConsts.MinErrorMsg,
+	ValidationMax:      errConsts.MaxErrorMsg,
+	ValidationPassword: errConsts.PasswordErrorMsg,
+	ValidationPhone:    errConsts.PhoneErrorMsg,
+	ValidationUUID:     errConsts.UUIDErrorMsg,
+	ValidationMoney:    errConsts.MoneyErrorMsg,
+	ValidationNotNull:  errConsts.NotNullErrorMsg,
+	ValidationAfter:    errConsts.AfterErrorMsg,
+	ValidationUnknown:  "Something went wrong on %s; %s",
+	ValidationGeneral:  errConsts.GeneralError,
+
+	// emails
+	MailVerifySubject:               "Verify Your Account",
+	MailVerifyBody:                  "Thank you for registering with Bizpos. Please verify your account by clicking the link below:\n\n%s\n\nThis link will expire in 24 hours.",
+	MailResetPasswordSubject:        "Reset Password",
+	MailResetPasswordBody:           "You have requested to reset your password. Please click the link below to reset your password:\n\n%s\n\nThis link will expire in 24 hours.",
+	MailResetPasswordSuccessSubject: "Reset Password Success",
+	MailResetPasswordSuccessBody:    "You have successfully reset your password.",
+	MailInviteSubject:               "You're Invited to Medisuite",
+	MailInviteBody:                  "You have been invited to join Medisuite as %s. Set your name and password by clicking the link below:\n\n%s\n\nThis link will expire in %d hours and can only be used once.",
+	MailBreakGlassSubject:           "Emergency Access to Your Medical Record",
+	MailBreakGlassBody:              "%s (%s) opened your medical record using emergency access on %s.\n\nReason given: %s\n\nYou can review everyone who has accessed your record from your account.",
+}
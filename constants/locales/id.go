@@ -0,0 +1,193 @@
+package locales
+
+// id is the Indonesian (Bahasa Indonesia) catalog
+var id = map[string]string{
+	// general errors
+	"INTERNAL_ERROR":      "Terjadi kesalahan pada server",
+	"DATABASE_ERROR":      "Server basis data gagal menjalankan kueri",
+	"TOO_MANY_REQUESTS":   "Terlalu banyak permintaan",
+	"UNAUTHORIZED":        "Tidak terautentikasi",
+	"INVALID_TOKEN":       "Token tidak valid",
+	"INVALID_UPLOAD_FILE": "Berkas unggahan tidak valid",
+	"SIZE_TOO_BIG":        "Ukuran terlalu besar",
+	"FORBIDDEN":           "Akses ditolak",
+	"EMAIL_SEND_FAILED":   "Gagal mengirim email",
+	"RESOURCE_NOT_FOUND":  "Data tidak ditemukan",
+	"CSRF_TOKEN_INVALID":  "Token CSRF tidak ada atau tidak valid",
+	"INVALID_REQUEST":     "Format permintaan tidak valid",
+	"INVALID_ID":          "Format ID tidak valid",
+	"VALIDATION_FAILED":   "Validasi permintaan gagal",
+
+	"PRECONDITION_REQUIRED": "Header If-Match dengan ETag sumber daya wajib diisi",
+	"PRECONDITION_FAILED":   "Data telah diubah oleh permintaan lain; ambil ulang data dan coba lagi dengan ETag terbarunya",
+
+	"IDEMPOTENCY_KEY_INVALID":         "Idempotency-Key harus terdiri dari 1 sampai 255 karakter yang dapat dicetak",
+	"IDEMPOTENCY_KEY_REUSED":          "Idempotency-Key sudah digunakan untuk permintaan yang berbeda",
+	"IDEMPOTENCY_REQUEST_IN_PROGRESS": "Permintaan dengan Idempotency-Key ini masih diproses",
+
+	"OUTBOX_MESSAGE_NOT_FOUND": "Tidak ada pesan outbox gagal dengan ID ini",
+
+	"TOKEN_STALE": "Hak akses Anda berubah sejak token akses diterbitkan; perbarui token",
+
+	// invites
+	"INVITE_NOT_FOUND":       "Undangan tidak ditemukan",
+	"INVITE_INVALID":         "Undangan tidak valid atau sudah digunakan",
+	"INVITE_EXPIRED":         "Undangan sudah kedaluwarsa",
+	"INVITE_ALREADY_PENDING": "Undangan yang belum diterima sudah ada untuk email ini",
+
+	// roles
+	"ROLE_NOT_FOUND":                 "Peran tidak ditemukan",
+	"ROLE_INVALID":                   "Peran tidak valid",
+	"ROLE_ALREADY_EXISTS":            "Peran sudah ada",
+	"ROLE_DELETED":                   "Peran sudah dihapus",
+	"ROLE_SELF_REGISTER_NOT_ALLOWED": "Peran ini tidak dapat mendaftar sendiri",
+	"ROLE_LEVEL_TOO_HIGH":            "Tidak dapat mengelola peran yang setara atau lebih tinggi dari peran Anda",
+	"ROLE_LEVEL_TOO_LOW":             "Tingkat peran Anda terlalu rendah untuk tindakan ini",
+
+	// categories and treatments
+	"CATEGORY_FIND_FAILED":     "Gagal mencari kategori",
+	"CATEGORY_CREATE_FAILED":   "Gagal membuat kategori",
+	"CATEGORY_UPDATE_FAILED":   "Gagal memperbarui kategori",
+	"CATEGORY_DELETE_FAILED":   "Gagal menghapus kategori",
+	"CATEGORY_NOT_FOUND":       "Kategori tidak ditemukan",
+	"CATEGORY_ALREADY_EXISTS":  "Kategori sudah ada",
+	"CATEGORY_NAME_NOT_FOUND":  "Kategori dengan nama tersebut tidak ditemukan",
+	"TREATMENT_FIND_FAILED":    "Gagal mencari perawatan",
+	"TREATMENT_CREATE_FAILED":  "Gagal membuat perawatan",
+	"TREATMENT_UPDATE_FAILED":  "Gagal memperbarui perawatan",
+	"TREATMENT_DELETE_FAILED":  "Gagal menghapus perawatan",
+	"TREATMENT_NOT_FOUND":      "Perawatan tidak ditemukan",
+	"TREATMENT_NAME_NOT_FOUND": "Perawatan dengan nama tersebut tidak ditemukan",
+	"TREATMENT_ALREADY_EXISTS": "Perawatan sudah ada",
+
+	// users
+	"USER_NOT_FOUND":             "Pengguna tidak ditemukan",
+	"USER_ALREADY_EXISTS":        "Pengguna sudah ada",
+	"USER_INVALID":               "Pengguna tidak valid",
+	"PASSWORD_HASH_FAILED":       "Gagal memproses kata sandi",
+	"PASSWORD_NOT_MATCH":         "Kata sandi tidak cocok",
+	"EMAIL_NOT_FOUND":            "Email pengguna tidak ditemukan",
+	"EMAIL_ALREADY_EXISTS":       "Email pengguna sudah terdaftar",
+	"EMAIL_INVALID":              "Email pengguna tidak valid",
+	"EMAIL_PASSWORD_INVALID":     "Email atau kata sandi pengguna tidak valid",
+	"ROLE_NOT_ALLOWED":           "Peran yang dipilih tidak diizinkan",
+	"OWNER_ALREADY_EXISTS":       "Pemilik sudah ada",
+	"VERIFICATION_TOKEN_INVALID": "Token verifikasi tidak valid",
+	"TOKEN_EXPIRED":              "Token verifikasi sudah kedaluwarsa",
+	"VERIFY_CODE_EXPIRED":        "Kode verifikasi sudah kedaluwarsa",
+	"USER_ALREADY_VERIFIED":      "Pengguna sudah terverifikasi",
+	"USER_NOT_VERIFIED":          "Pengguna belum terverifikasi",
+	"INVALID_CREDENTIALS":        "Email atau kata sandi salah",
+	"TOKEN_NOT_FOUND":            "Token tidak ditemukan",
+	"VERIFY_CODE_NOT_FOUND":      "Kode verifikasi tidak ditemukan",
+	"USER_VERIFY_FAILED":         "Verifikasi pengguna gagal",
+	"USER_CREATE_FAILED":         "Gagal membuat pengguna baru",
+	"USER_UPDATE_FAILED":         "Gagal memperbarui pengguna",
+	"USER_NOT_OWNER":             "Pengguna bukan pemilik",
+	"USER_DELETED":               "Pengguna sudah dihapus",
+	"INSUFFICIENT_PERMISSIONS":   "Izin tidak cukup untuk tindakan ini",
+	"INVALID_ROLE":               "Peran tidak valid",
+	"LOCALE_UNSUPPORTED":         "Bahasa tidak didukung",
+
+	// patients
+	"CARE_TEAM_MEMBER_INVALID":   "Hanya staf yang dapat ditugaskan ke tim perawatan pasien",
+	"CARE_TEAM_MEMBER_NOT_FOUND": "Staf tersebut tidak termasuk dalam tim perawatan pasien ini",
+	"BREAK_GLASS_REQUIRED":       "Anda tidak termasuk dalam tim perawatan pasien ini; gunakan akses darurat dengan alasan",
+
+	// general successes
+	"SUCCESS_OPERATION_COMPLETED": "Operasi berhasil diselesaikan",
+	"SUCCESS_DATA_RETRIEVED":      "Data berhasil diambil",
+	"SUCCESS_DATA_CREATED":        "Data berhasil dibuat",
+	"SUCCESS_DATA_UPDATED":        "Data berhasil diperbarui",
+	"SUCCESS_DATA_DELETED":        "Data berhasil dihapus",
+	"SUCCESS_EMAIL_SENT":          "Email berhasil dikirim",
+	"SUCCESS_FILE_UPLOADED":       "Berkas berhasil diunggah",
+	"SUCCESS_ROUTES_FOUND":        "Rute berhasil ditemukan",
+	"SUCCESS_AUDIT_EVENTS_FOUND":  "Log audit berhasil ditemukan",
+
+	// outbox
+	"SUCCESS_OUTBOX_MESSAGES_FOUND":   "Pesan outbox berhasil ditemukan",
+	"SUCCESS_OUTBOX_MESSAGE_REQUEUED": "Pesan outbox berhasil diantrekan ulang",
+
+	// invites
+	"SUCCESS_INVITE_CREATED":        "Undangan berhasil dikirim",
+	"SUCCESS_PENDING_INVITES_FOUND": "Undangan yang belum diterima berhasil ditemukan",
+	"SUCCESS_INVITE_RESENT":         "Undangan berhasil dikirim ulang",
+	"SUCCESS_INVITE_REVOKED":        "Undangan berhasil dibatalkan",
+	"SUCCESS_INVITE_ACCEPTED":       "Undangan berhasil diterima",
+
+	// patients
+	"SUCCESS_PATIENT_RECORD_FOUND": "Rekam medis pasien berhasil ditemukan",
+	"SUCCESS_BREAK_GLASS_GRANTED":  "Akses darurat diberikan",
+	"SUCCESS_ACCESS_LOG_FOUND":     "Log akses berhasil ditemukan",
+
+	// care team
+	"SUCCESS_CARE_TEAM_ASSIGNED":   "Anggota tim perawatan berhasil ditugaskan",
+	"SUCCESS_CARE_TEAM_UNASSIGNED": "Anggota tim perawatan berhasil dihapus",
+
+	// roles
+	"SUCCESS_ROLES_FOUND":   "Peran berhasil ditemukan",
+	"SUCCESS_ROLE_CREATED":  "Peran berhasil dibuat",
+	"SUCCESS_ROLE_UPDATED":  "Peran berhasil diperbarui",
+	"SUCCESS_ROLE_ASSIGNED": "Peran berhasil ditetapkan",
+
+	// categories and treatments
+	"SUCCESS_CATEGORY_CREATED":  "Kategori berhasil dibuat",
+	"SUCCESS_CATEGORY_UPDATED":  "Kategori berhasil diperbarui",
+	"SUCCESS_CATEGORY_DELETED":  "Kategori berhasil dihapus",
+	"SUCCESS_CATEGORIES_FOUND":  "Daftar kategori berhasil ditemukan",
+	"SUCCESS_CATEGORY_FOUND":    "Kategori berhasil ditemukan",
+	"SUCCESS_TREATMENT_CREATED": "Perawatan berhasil dibuat",
+	"SUCCESS_TREATMENT_UPDATED": "Perawatan berhasil diperbarui",
+	"SUCCESS_TREATMENT_DELETED": "Perawatan berhasil dihapus",
+	"SUCCESS_TREATMENTS_FOUND":  "Daftar perawatan berhasil ditemukan",
+	"SUCCESS_TREATMENT_FOUND":   "Perawatan berhasil ditemukan",
+
+	// users
+	"SUCCESS_USER_CREATED":               "Pengguna berhasil dibuat",
+	"SUCCESS_USER_UPDATED":               "Pengguna berhasil diperbarui",
+	"SUCCESS_USER_DELETED":               "Pengguna berhasil dihapus",
+	"SUCCESS_USER_VERIFIED":              "Pengguna berhasil diverifikasi",
+	"SUCCESS_USER_LOGGED_IN":             "Berhasil masuk",
+	"SUCCESS_USER_LOGGED_OUT":            "Berhasil keluar",
+	"SUCCESS_FORGOT_PASSWORD":            "Permintaan lupa kata sandi berhasil",
+	"SUCCESS_PASSWORD_RESET":             "Kata sandi berhasil diatur ulang",
+	"SUCCESS_USER_EMAIL_SENT":            "Email berhasil dikirim ke pengguna",
+	"SUCCESS_FORGOT_PASSWORD_EMAIL_SENT": "Email lupa kata sandi berhasil dikirim",
+	"SUCCESS_RESET_PASSWORD_EMAIL_SENT":  "Email atur ulang kata sandi berhasil dikirim",
+	"SUCCESS_EMAIL_VERIFIED":             "Email berhasil diverifikasi",
+	"SUCCESS_TOKEN_VERIFIED":             "Token berhasil diverifikasi",
+	"SUCCESS_USER_FOUND":                 "Pengguna berhasil ditemukan",
+	"SUCCESS_USER_FOUND_BY_ID":           "Pengguna berhasil ditemukan berdasarkan ID",
+	"SUCCESS_VERIFICATION_RESENT":        "Verifikasi akun berhasil dikirim ulang ke email",
+	"SUCCESS_TOKEN_GENERATED":            "Token baru berhasil dibuat",
+	"SUCCESS_TOKEN_REFRESHED":            "Token berhasil diperbarui",
+	"SUCCESS_LOCALE_UPDATED":             "Preferensi bahasa berhasil diperbarui",
+
+	// validation, formatted with the field name and the rule parameter
+	ValidationRequired: "%s wajib diisi",
+	ValidationEmail:    "%s bukan alamat email yang valid",
+	ValidationOneOf:    "%s harus salah satu dari [%s]",
+	ValidationMin:      "%s minimal %s",
+	ValidationMax:      "%s maksimal %s",
+	ValidationPassword: "%s harus mengandung huruf besar, huruf kecil, angka dan karakter khusus",
+	ValidationPhone:    "%s bukan nomor telepon Indonesia yang valid",
+	ValidationUUID:     "%s bukan UUID yang valid",
+	ValidationMoney:    "%s harus berupa jumlah non-negatif dengan paling banyak dua desimal",
+	ValidationNotNull:  "%s tidak boleh null",
+	ValidationAfter:    "%s harus setelah %s",
+	ValidationUnknown:  "Terjadi kesalahan pada %s; %s",
+	ValidationGeneral:  "Terjadi kesalahan yang tidak terduga",
+
+	// emails
+	MailVerifySubject:               "Verifikasi Akun Anda",
+	MailVerifyBody:                  "Terima kasih telah mendaftar di Bizpos. Silakan verifikasi akun Anda dengan mengklik tautan di bawah ini:\n\n%s\n\nTautan ini akan kedaluwarsa dalam 24 jam.",
+	MailResetPasswordSubject:        "Atur Ulang Kata Sandi",
+	MailResetPasswordBody:           "Anda telah meminta untuk mengatur ulang kata sandi. Silakan klik tautan di bawah ini untuk mengatur ulang kata sandi Anda:\n\n%s\n\nTautan ini akan kedaluwarsa dalam 24 jam.",
+	MailResetPasswordSuccessSubject: "Kata Sandi Berhasil Diatur Ulang",
+	MailResetPasswordSuccessBody:    "Kata sandi Anda berhasil diatur ulang.",
+	MailInviteSubject:               "Anda Diundang ke Medisuite",
+	MailInviteBody:                  "Anda diundang untuk bergabung dengan Medisuite sebagai %s. Atur nama dan kata sandi Anda dengan mengklik tautan di bawah ini:\n\n%s\n\nTautan ini akan kedaluwarsa dalam %d jam dan hanya dapat digunakan sekali.",
+	MailBreakGlassSubject:           "Akses Darurat ke Rekam Medis Anda",
+	MailBreakGlassBody:              "%s (%s) membuka rekam medis Anda menggunakan akses darurat pada %s.\n\nAlasan yang diberikan: %s\n\nAnda dapat melihat siapa saja yang telah mengakses rekam medis Anda dari akun Anda.",
+}
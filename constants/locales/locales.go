@@ -0,0 +1,133 @@
+package locales
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	errConsts "medisuite-api/constants/errors"
+	"medisuite-api/constants/success"
+	"medisuite-api/pkg/i18n"
+)
+
+// Supported locales
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+// Validation message keys
+const (
+	ValidationRequired = "VALIDATION_REQUIRED"
+	ValidationEmail    = "VALIDATION_EMAIL"
+	ValidationOneOf    = "VALIDATION_ONEOF"
+	ValidationMin      = "VALIDATION_MIN"
+	ValidationMax      = "VALIDATION_MAX"
+	ValidationPassword = "VALIDATION_PASSWORD"
+	ValidationPhone    = "VALIDATION_PHONE"
+	ValidationUUID     = "VALIDATION_UUID"
+	ValidationMoney    = "VALIDATION_MONEY"
+	ValidationNotNull  = "VALIDATION_NOT_NULL"
+	ValidationAfter    = "VALIDATION_AFTER"
+	ValidationUnknown  = "VALIDATION_UNKNOWN"
+	ValidationGeneral  = "VALIDATION_GENERAL"
+)
+
+// Email message keys; bodies are formatted with the link (and the role and TTL for invites);
+// the break-the-glass alert takes the reader's name and role, the time and the reason
+const (
+	MailVerifySubject               = "MAIL_VERIFY_SUBJECT"
+	MailVerifyBody                  = "MAIL_VERIFY_BODY"
+	MailResetPasswordSubject        = "MAIL_RESET_PASSWORD_SUBJECT"
+	MailResetPasswordBody           = "MAIL_RESET_PASSWORD_BODY"
+	MailResetPasswordSuccessSubject = "MAIL_RESET_PASSWORD_SUCCESS_SUBJECT"
+	MailResetPasswordSuccessBody    = "MAIL_RESET_PASSWORD_SUCCESS_BODY"
+	MailInviteSubject               = "MAIL_INVITE_SUBJECT"
+	MailInviteBody                  = "MAIL_INVITE_BODY"
+	MailBreakGlassSubject           = "MAIL_BREAK_GLASS_SUBJECT"
+	MailBreakGlassBody              = "MAIL_BREAK_GLASS_BODY"
+)
+
+// successKeys maps the English success messages back to their keys
+var successKeys = map[string]string{}
+
+func init() {
+	for key, message := range successMessages {
+		successKeys[message] = key
+	}
+}
+
+// Catalogs returns the catalog of every supported locale, keyed by error code, success key,
+// validation key and email key. The English catalog takes error messages from the errors themselves.
+func Catalogs() map[string]i18n.Catalog {
+	english := i18n.Catalog{}
+	for _, appErr := range errConsts.All() {
+		english[appErr.Code] = appErr.Message
+	}
+	for key, message := range successMessages {
+		english[key] = message
+	}
+	for key, message := range en {
+		english[key] = message
+	}
+
+	return map[string]i18n.Catalog{
+		English:    english,
+		Indonesian: i18n.Catalog(id),
+	}
+}
+
+// NewBundle creates the bundle of every supported locale, falling back to fallback
+func NewBundle(fallback string) (*i18n.Bundle, error) {
+	return i18n.New(fallback, Catalogs())
+}
+
+// SuccessKey returns the key of an English success message from constants/success
+func SuccessKey(message string) (string, bool) {
+	key, ok := successKeys[message]
+	return key, ok
+}
+
+// Check reports every message missing from a locale: each catalog must have the same keys,
+// and every application error and success message must have a key
+func Check() error {
+	bundle, err := NewBundle(English)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	missing := bundle.Missing()
+	for _, locale := range bundle.Locales() {
+		if keys := missing[locale]; len(keys) > 0 {
+			errs = append(errs, fmt.Errorf("locale %q is missing %s", locale, strings.Join(keys, ", ")))
+		}
+	}
+
+	// keys shared with an error code would silently replace its message
+	for _, appErr := range errConsts.All() {
+		if _, ok := successMessages[appErr.Code]; ok {
+			errs = append(errs, fmt.Errorf("success key %q is also an error code", appErr.Code))
+		}
+		if _, ok := en[appErr.Code]; ok {
+			errs = append(errs, fmt.Errorf("message key %q is also an error code", appErr.Code))
+		}
+	}
+
+	lists := [][]string{
+		success.GeneralSuccessMessages,
+		success.InviteSuccessMessages,
+		success.PatientSuccessMessages,
+		success.RoleSuccessMessages,
+		success.ServiceSuccessMessages,
+		success.UserSuccessMessages,
+	}
+	for _, list := range lists {
+		for _, message := range list {
+			if _, ok := SuccessKey(message); !ok {
+				errs = append(errs, fmt.Errorf("success message %q has no key", message))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package locales
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	catalogs := Catalogs()
+	english := catalogs[English]
+	if len(english) == 0 {
+		t.Fatal("English catalog is empty")
+	}
+
+	for locale, catalog := range catalogs {
+		if locale == English {
+			continue
+		}
+		if missing := difference(english, catalog); len(missing) > 0 {
+			t.Errorf("locale %q is missing %v", locale, missing)
+		}
+		if extra := difference(catalog, english); len(extra) > 0 {
+			t.Errorf("locale %q has keys English does not: %v", locale, extra)
+		}
+	}
+}
+
+// verb matches a fmt verb, skipping escaped percent signs
+var verb = regexp.MustCompile(`%[^%]`)
+
+func TestTranslationsKeepFormatVerbs(t *testing.T) {
+	catalogs := Catalogs()
+	english := catalogs[English]
+
+	for locale, catalog := range catalogs {
+		if locale == English {
+			continue
+		}
+		for key, message := range catalog {
+			want, ok := english[key]
+			if !ok {
+				continue
+			}
+			if got, wantVerbs := verb.FindAllString(message, -1), verb.FindAllString(want, -1); len(got) != len(wantVerbs) {
+				t.Errorf("locale %q key %s has verbs %v, English has %v", locale, key, got, wantVerbs)
+			}
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(); err != nil {
+		t.Errorf("Check() = %v", err)
+	}
+}
+
+// difference returns the sorted keys of a that b does not have
+func difference(a, b map[string]string) []string {
+	var keys []string
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
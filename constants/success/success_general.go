@@ -1,14 +1,19 @@
 package success
 
 var (
-	SuccessGeneral       = "Operation completed successfully"
-	SuccessDataRetrieved = "Data retrieved successfully"
-	SuccessDataCreated   = "Data created successfully"
-	SuccessDataUpdated   = "Data updated successfully"
-	SuccessDataDeleted   = "Data deleted successfully"
-	SuccessEmailSent     = "Email sent successfully"
-	SuccessFileUploaded  = "File uploaded successfully"
-	SuccessOperationDone = "Operation completed successfully"
+	SuccessGeneral         = "Operation completed successfully"
+	SuccessDataRetrieved   = "Data retrieved successfully"
+	SuccessDataCreated     = "Data created successfully"
+	SuccessDataUpdated     = "Data updated successfully"
+	SuccessDataDeleted     = "Data deleted successfully"
+	SuccessEmailSent       = "Email sent successfully"
+	SuccessFileUploaded    = "File uploaded successfully"
+	SuccessOperationDone   = "Operation completed successfully"
+	SuccessFindAllRoutes   = "Routes found successfully"
+	SuccessFindAuditEvents = "Audit events found successfully"
+
+	SuccessFindOutboxMessages   = "Outbox messages found successfully"
+	SuccessRequeueOutboxMessage = "Outbox message requeued successfully"
 )
 
 var GeneralSuccessMessages = []string{
@@ -20,4 +25,8 @@ var GeneralSuccessMessages = []string{
 	SuccessEmailSent,
 	SuccessFileUploaded,
 	SuccessOperationDone,
+	SuccessFindAllRoutes,
+	SuccessFindAuditEvents,
+	SuccessFindOutboxMessages,
+	SuccessRequeueOutboxMessage,
 }
@@ -0,0 +1,17 @@
+package success
+
+var (
+	SuccessCreateInvite      = "Invite sent successfully"
+	SuccessFindPendingInvite = "Pending invites found successfully"
+	SuccessResendInvite      = "Invite resent successfully"
+	SuccessRevokeInvite      = "Invite revoked successfully"
+	SuccessAcceptInvite      = "Invite accepted successfully"
+)
+
+var InviteSuccessMessages = []string{
+	SuccessCreateInvite,
+	SuccessFindPendingInvite,
+	SuccessResendInvite,
+	SuccessRevokeInvite,
+	SuccessAcceptInvite,
+}
@@ -0,0 +1,17 @@
+package success
+
+var (
+	SuccessFindPatientRecord = "Patient record found successfully"
+	SuccessBreakGlass        = "Emergency access granted"
+	SuccessFindAccessLog     = "Access log found successfully"
+	SuccessAssignCareTeam    = "Care team member assigned successfully"
+	SuccessUnassignCareTeam  = "Care team member removed successfully"
+)
+
+var PatientSuccessMessages = []string{
+	SuccessFindPatientRecord,
+	SuccessBreakGlass,
+	SuccessFindAccessLog,
+	SuccessAssignCareTeam,
+	SuccessUnassignCareTeam,
+}
@@ -0,0 +1,15 @@
+package success
+
+var (
+	SuccessFindAllRoles = "Roles found successfully"
+	SuccessCreateRole   = "Role created successfully"
+	SuccessUpdateRole   = "Role updated successfully"
+	SuccessAssignRole   = "Role assigned successfully"
+)
+
+var RoleSuccessMessages = []string{
+	SuccessFindAllRoles,
+	SuccessCreateRole,
+	SuccessUpdateRole,
+	SuccessAssignRole,
+}
@@ -19,6 +19,7 @@ var (
 	SuccessResendVerifyAccount     = "Success resend verify account to email"
 	SuccessGenerateNewToken        = "Success generate new token"
 	SuccessRefreshToken            = "Success refresh new token"
+	SuccessUpdateLocale            = "Locale preference updated successfully"
 )
 
 var UserSuccessMessages = []string{
@@ -38,4 +39,5 @@ var UserSuccessMessages = []string{
 	SuccessResendVerifyAccount,
 	SuccessGenerateNewToken,
 	SuccessRefreshToken,
+	SuccessUpdateLocale,
 }
@@ -6,48 +6,50 @@ import (
 	"log/slog"
 
 	// "medisuite/pkg/logs"
-	"os"
+	"medisuite-api/config"
+	"medisuite-api/pkg/metrics"
+	"medisuite-api/pkg/tracing"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// InitDB initializes a new database connection
-func InitDB() (*pgx.Conn, error) {
-	// Get database configuration from environment variables with defaults
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASS")
-	dbname := os.Getenv("DB_NAME")
-
+// InitDB initializes a new database connection pool
+func InitDB(cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
 	// Log database configuration (without password for security)
-	logMsg := fmt.Sprintf("Connecting to database: %s@%s:%s/%s", user, host, port, dbname)
+	logMsg := fmt.Sprintf("Connecting to database: %s@%s:%d/%s", cfg.User, cfg.Host, cfg.Port, cfg.Name)
 	slog.Info(logMsg)
 
 	// Create connection string in DSN format
-	dsn := fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=disable&timezone=Asia/Jakarta",
-		user, password, host, port, dbname,
-	)
+	dsn := cfg.DSN()
+
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database config: %w", err)
+	}
+	poolConfig.MaxConns = int32(cfg.MaxConns)
+	// Record per-query durations and spans, labeled by sqlc query name
+	poolConfig.ConnConfig.Tracer = multitracer.New(metrics.QueryTracer{}, tracing.QueryTracer{})
 
-	// Create connection
-	conn, err := pgx.Connect(context.Background(), dsn)
+	// Create connection pool
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
 
 	// Test the connection
-	if err := conn.Ping(context.Background()); err != nil {
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("unable to ping database: %w", err)
 	}
 
 	slog.Info("Successfully connected to database")
-	return conn, nil
+	return pool, nil
 }
 
-// CloseDB closes the database connection
-func CloseDB(conn *pgx.Conn) {
-	if conn != nil {
-		conn.Close(context.Background())
+// CloseDB closes every connection in the pool
+func CloseDB(pool *pgxpool.Pool) {
+	if pool != nil {
+		pool.Close()
 	}
 }
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Higher-level roles can opt in to inheriting the permissions of every lower-level role.
+ALTER TABLE roles ADD COLUMN inherit_permissions BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE roles DROP COLUMN inherit_permissions;
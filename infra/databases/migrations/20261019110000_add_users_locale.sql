@@ -0,0 +1,6 @@
+-- +goose Up
+-- Preferred language for API messages and emails ("en" or "id"); empty follows Accept-Language.
+ALTER TABLE users ADD COLUMN locale VARCHAR(8) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN locale;
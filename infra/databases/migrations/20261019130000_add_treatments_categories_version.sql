@@ -0,0 +1,9 @@
+-- +goose Up
+-- Row versions for optimistic concurrency: every update bumps version and only applies
+-- when the client's If-Match still names the current one.
+ALTER TABLE treatments ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE categories DROP COLUMN IF EXISTS version;
+ALTER TABLE treatments DROP COLUMN IF EXISTS version;
@@ -0,0 +1,62 @@
+-- +goose Up
+-- Append-only record of every staff read of a patient's data. break_glass marks emergency
+-- access outside the reader's permissions, for which a reason is required.
+CREATE TABLE IF NOT EXISTS patient_access_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    patient_id UUID NOT NULL,
+    actor_user_id UUID NOT NULL,
+    actor_role VARCHAR(64) NOT NULL DEFAULT '',
+    actor_ip VARCHAR(45) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    resource VARCHAR(64) NOT NULL,
+    purpose VARCHAR(255) NOT NULL,
+    break_glass BOOLEAN NOT NULL DEFAULT false,
+    reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_access_logs_patient ON patient_access_logs (patient_id, accessed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_patient_access_logs_break_glass ON patient_access_logs (accessed_at DESC) WHERE break_glass;
+
+-- append-only in the same way as audit_events
+REVOKE UPDATE, DELETE, TRUNCATE ON patient_access_logs FROM PUBLIC, CURRENT_USER;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION patient_access_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'patient_access_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER patient_access_logs_no_update_delete
+    BEFORE UPDATE OR DELETE ON patient_access_logs
+    FOR EACH ROW EXECUTE FUNCTION patient_access_logs_append_only();
+
+CREATE TRIGGER patient_access_logs_no_truncate
+    BEFORE TRUNCATE ON patient_access_logs
+    FOR EACH STATEMENT EXECUTE FUNCTION patient_access_logs_append_only();
+
+-- reading patient records is granted per role through this permission; staff without it
+-- can only break the glass
+INSERT INTO permissions (module, action, name, description, is_active)
+SELECT 'patient', 'read', 'Read patient records', 'Read patient records for a stated purpose', true
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE module = 'patient' AND action = 'read');
+
+-- doctors and admins read patient records; doctors are still limited to their care team
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.module = 'patient' AND p.action = 'read'
+WHERE r.code IN ('doctor', 'admin')
+  AND NOT EXISTS (SELECT 1 FROM role_permissions rp WHERE rp.role_id = r.id AND rp.permission_id = p.id);
+
+-- +goose Down
+-- like audit_events, the access log must survive a rollback, so this migration cannot be undone
+-- +goose StatementBegin
+DO $$
+BEGIN
+    RAISE EXCEPTION 'patient_access_logs is append-only and cannot be rolled back';
+END;
+$$;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- phone_number now holds ciphertext written by the application (pkg/fieldcrypt), which is
+-- longer than any phone number, and phone_number_bidx its blind index for lookups by phone.
+-- Existing rows stay plaintext with an empty blind index until `encryption reencrypt` runs.
+ALTER TABLE users ALTER COLUMN phone_number TYPE TEXT;
+ALTER TABLE users ADD COLUMN phone_number_bidx VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_users_phone_number_bidx ON users (phone_number_bidx) WHERE phone_number_bidx <> '';
+
+-- +goose Down
+-- phone_number is left as TEXT, since encrypted values do not fit the previous type
+DROP INDEX IF EXISTS idx_users_phone_number_bidx;
+ALTER TABLE users DROP COLUMN phone_number_bidx;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Staff assigned to care for a patient. Doctors may only read the records of patients whose
+-- care team they are on; anyone else has to break the glass.
+CREATE TABLE IF NOT EXISTS patient_care_team (
+    patient_id UUID NOT NULL REFERENCES users(id),
+    staff_user_id UUID NOT NULL REFERENCES users(id),
+    assigned_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (patient_id, staff_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_care_team_staff ON patient_care_team (staff_user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS patient_care_team;
@@ -1,9 +1,9 @@
 package emails
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
-	"fmt"
 	"log/slog"
 
 	// "medisuite/pkg/logs"
@@ -11,36 +11,78 @@ import (
 	"strings"
 
 	"medisuite-api/config"
+	"medisuite-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
-type SMTPSender struct{}
+type SMTPSender struct {
+	cfg config.SMTPConfig
+}
+
+func NewSMTPSender(cfg config.SMTPConfig) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+// Ping checks that the SMTP server accepts a TLS connection, without authenticating
+func (s *SMTPSender) Ping(ctx context.Context) error {
+	dialer := &tls.Dialer{Config: &tls.Config{ServerName: s.cfg.Host}}
+	conn, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr())
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
 
-func NewSMTPSender() *SMTPSender {
-	return &SMTPSender{}
+// Send delivers email over SMTP with implicit TLS, recorded as a span on ctx.
+// The deadline of ctx bounds the whole exchange with the server.
+func (s *SMTPSender) Send(ctx context.Context, email config.Email) error {
+	ctx, span := tracing.Start(ctx, "SMTPSender.Send",
+		attribute.String("server.address", s.cfg.Host),
+		attribute.Int("server.port", s.cfg.Port),
+		attribute.Int("email.recipients", len(email.To)+len(email.Cc)),
+	)
+	defer span.End()
+
+	err := s.send(ctx, email)
+	tracing.RecordError(span, err)
+	return err
 }
 
-func (s *SMTPSender) Send(email config.Email) error {
-	body := "From: " + config.CONFIG_SMTP_USER + "\n" +
+func (s *SMTPSender) send(ctx context.Context, email config.Email) error {
+	if s.cfg.User == "" || s.cfg.Password == "" {
+		slog.Error("SMTP credentials are not configured, set SMTP_USER and SMTP_PASS (or SMTP_PASS_FILE)")
+		return errors.New("SMTP credentials are not configured")
+	}
+
+	body := "From: " + s.cfg.User + "\n" +
 		"To: " + strings.Join(email.To, ",") + "\n" +
 		"Cc: " + strings.Join(email.Cc, ",") + "\n" +
 		"Subject: " + email.Subject + "\n\n" +
 		email.Body
 
-	auth := smtp.PlainAuth("", config.CONFIG_SMTP_USER, config.CONFIG_SMTP_PASS, config.CONFIG_SMTP_HOST)
-	smtpAddr := fmt.Sprintf("%s:%s", config.CONFIG_SMTP_HOST, config.CONFIG_SMTP_PORT)
+	auth := smtp.PlainAuth("", s.cfg.User, s.cfg.Password, s.cfg.Host)
+	smtpAddr := s.cfg.Addr()
 
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: true,
-		ServerName:         config.CONFIG_SMTP_HOST,
+		ServerName:         s.cfg.Host,
 	}
 
-	conn, err := tls.Dial("tcp", smtpAddr, tlsConfig)
+	dialer := &tls.Dialer{Config: tlsConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", smtpAddr)
 	if err != nil {
 		slog.Error("error", "failed to connect to SMTP server", err)
 		return errors.New("failed to connect to SMTP server")
 	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return errors.New("failed to connect to SMTP server")
+		}
+	}
 
-	client, err := smtp.NewClient(conn, config.CONFIG_SMTP_HOST)
+	client, err := smtp.NewClient(conn, s.cfg.Host)
 	if err != nil {
 		slog.Error("error", "failed to create SMTP client", err)
 		return errors.New("failed to create SMTP client")
@@ -52,7 +94,7 @@ func (s *SMTPSender) Send(email config.Email) error {
 		return errors.New("failed to authenticate SMTP client")
 	}
 
-	if error := client.Mail(config.CONFIG_SMTP_USER); error != nil {
+	if error := client.Mail(s.cfg.User); error != nil {
 		slog.Error("error", "failed to send SMTP mail", err)
 		return errors.New("failed to send SMTP mail")
 	}
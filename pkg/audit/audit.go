@@ -0,0 +1,245 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"medisuite-api/pkg/logs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Actions recorded in audit events
+const (
+	ActionCreate        = "create"
+	ActionUpdate        = "update"
+	ActionDelete        = "delete"
+	ActionAssignRole    = "assign_role"
+	ActionVerify        = "verify"
+	ActionResetPassword = "reset_password"
+	ActionAccept        = "accept"
+	ActionRevoke        = "revoke"
+	ActionRequeue       = "requeue"
+)
+
+// Entity types recorded in audit events
+const (
+	EntityTreatment = "treatment"
+	EntityCategory  = "category"
+	EntityRole      = "role"
+	EntityUser      = "user"
+	EntityInvite    = "invite"
+	EntityCareTeam  = "care_team"
+	EntityOutbox    = "outbox_message"
+)
+
+// EntityTypes lists every entity type recorded in audit events
+var EntityTypes = []string{
+	EntityTreatment, EntityCategory, EntityRole, EntityUser, EntityInvite, EntityCareTeam, EntityOutbox,
+}
+
+// IsEntityType reports whether entityType is one of EntityTypes
+func IsEntityType(entityType string) bool {
+	return slices.Contains(EntityTypes, entityType)
+}
+
+// ignoredFields change on every write and are left out of diffs
+var ignoredFields = map[string]bool{"updated_at": true}
+
+// ignored reports whether the field is left out of diffs; blind indexes of encrypted
+// columns are derived from the plaintext and say nothing the masked column does not
+func ignored(name string) bool {
+	return ignoredFields[name] || strings.HasSuffix(name, "_bidx")
+}
+
+var redacted = json.RawMessage(`"` + logs.Redacted + `"`)
+
+// Actor is who performed a change; UserID is uuid.Nil for unauthenticated requests
+type Actor struct {
+	UserID uuid.UUID
+	Role   string
+	IP     string
+}
+
+type contextKey struct{}
+
+// WithActor returns a copy of ctx carrying actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, contextKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx, or the zero Actor
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(contextKey{}).(Actor)
+	return actor
+}
+
+// WithUser returns a copy of ctx whose actor is userID, keeping the client IP; used for changes
+// made without a session, such as registering or resetting a password
+func WithUser(ctx context.Context, userID uuid.UUID) context.Context {
+	actor := ActorFromContext(ctx)
+	return WithActor(ctx, Actor{UserID: userID, IP: actor.IP})
+}
+
+// Middleware records the client IP as the actor of the request;
+// AuthMiddleware adds the user and role once the token is verified
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithActor(c.Request.Context(), Actor{IP: c.ClientIP()}))
+		c.Next()
+	}
+}
+
+// Event describes one change; Before is nil for creates and After is nil for deletes
+type Event struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     any
+	After      any
+}
+
+// ResourcePatientRecord is the patient data read through the patient record endpoints
+const ResourcePatientRecord = "patient_record"
+
+// Access describes one read of a patient's data. BreakGlass marks emergency access
+// outside the reader's permissions, which must give a Reason.
+type Access struct {
+	PatientID  uuid.UUID
+	Resource   string
+	Purpose    string
+	BreakGlass bool
+	Reason     string
+}
+
+// Change is the before and after value of one field
+type Change struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// Diff returns the fields that differ between before and after as a JSON object of Changes.
+// before and after are structs (named by their db, then json tag) or string-keyed maps; either
+// may be nil. Secret fields such as passwords and tokens are recorded as changed but redacted,
+// and phone numbers are masked.
+func Diff(before, after any) (json.RawMessage, error) {
+	old, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]Change{}
+	for name, value := range updated {
+		if ignored(name) || bytes.Equal(old[name], value) {
+			continue
+		}
+		changes[name] = Change{Before: old[name], After: value}
+	}
+	for name, value := range old {
+		if _, ok := updated[name]; !ok && !ignored(name) {
+			changes[name] = Change{Before: value}
+		}
+	}
+
+	for name, change := range changes {
+		switch {
+		case logs.IsSecret(name):
+			if change.Before != nil {
+				change.Before = redacted
+			}
+			if change.After != nil {
+				change.After = redacted
+			}
+		case strings.Contains(name, "phone"):
+			// phone numbers are encrypted at rest, so the audit trail keeps them masked
+			change.Before = maskPhone(change.Before)
+			change.After = maskPhone(change.After)
+		default:
+			continue
+		}
+		changes[name] = change
+	}
+	return json.Marshal(changes)
+}
+
+// maskPhone masks an encoded phone number string; other values are returned unchanged
+func maskPhone(value json.RawMessage) json.RawMessage {
+	var phone string
+	if value == nil || json.Unmarshal(value, &phone) != nil || phone == "" {
+		return value
+	}
+	masked, _ := json.Marshal(logs.MaskPhone(phone))
+	return masked
+}
+
+// fields returns the JSON encoding of every exported field of v by name
+func fields(v any) (map[string]json.RawMessage, error) {
+	result := map[string]json.RawMessage{}
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return result, nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			name := fieldName(field)
+			if !field.IsExported() || name == "" {
+				continue
+			}
+			encoded, err := json.Marshal(value.Field(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			result[name] = encoded
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			if iter.Key().Kind() != reflect.String {
+				continue
+			}
+			encoded, err := json.Marshal(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			result[iter.Key().String()] = encoded
+		}
+	case reflect.Invalid:
+		// nil before of a create or after of a delete
+	default:
+		encoded, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, err
+		}
+		result["value"] = encoded
+	}
+	return result, nil
+}
+
+// fieldName names a struct field by its db tag, then its json tag; "" skips the field
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"db", "json"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
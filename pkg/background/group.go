@@ -0,0 +1,47 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Group tracks fire-and-forget goroutines (e.g. outgoing emails) so shutdown can wait for them.
+type Group struct {
+	wg sync.WaitGroup
+}
+
+// NewGroup creates an empty Group
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Go runs fn in a tracked goroutine; a panic in fn is logged instead of crashing the server
+func (g *Group) Go(name string, fn func()) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("Recovered from panic in background task", "task", name, "panic", r)
+			}
+		}()
+		fn()
+	}()
+}
+
+// Wait blocks until every tracked goroutine has finished or ctx is done
+func (g *Group) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// entry holds a cached value together with its expiry time
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// minSweep is the size below which Set never sweeps expired entries
+const minSweep = 64
+
+// TTLCache is a small concurrency-safe in-process cache whose entries expire after a fixed TTL.
+// Expired entries are dropped when read, and swept by Set whenever the cache has doubled since
+// the last sweep, so it holds at most about twice its live entries.
+type TTLCache[K comparable, V any] struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	entries   map[K]entry[V]
+	nextSweep int
+}
+
+// NewTTLCache creates a cache whose entries live for ttl.
+// A ttl <= 0 disables caching: Get always misses and Set is a no-op.
+func NewTTLCache[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		ttl:       ttl,
+		entries:   make(map[K]entry[V]),
+		nextSweep: minSweep,
+	}
+}
+
+// Get returns the cached value for key if it exists and has not expired
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	var zero V
+	if !ok {
+		return zero, false
+	}
+	if time.Now().After(e.expiresAt) {
+		c.mu.Lock()
+		// another Set may have refreshed the entry since it was read
+		if current, ok := c.entries[key]; ok && time.Now().After(current.expiresAt) {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores value for key, replacing any previous entry
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	if len(c.entries) >= c.nextSweep {
+		c.sweep(now)
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+}
+
+// Len returns the number of entries held, including expired ones not yet swept
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// sweep removes the entries expired at now and schedules the next sweep; c.mu must be held
+func (c *TTLCache[K, V]) sweep(now time.Time) {
+	for key, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	c.nextSweep = max(2*len(c.entries), minSweep)
+}
+
+// Delete removes the entry for key
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+// Purge removes every entry
+func (c *TTLCache[K, V]) Purge() {
+	c.mu.Lock()
+	c.entries = make(map[K]entry[V])
+	c.nextSweep = minSweep
+	c.mu.Unlock()
+}
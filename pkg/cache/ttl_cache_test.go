@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetReturnsLiveEntries(t *testing.T) {
+	c := NewTTLCache[string, int](time.Minute)
+	c.Set("a", 1)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %d, %v; want 1, true", v, ok)
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get(missing) hit")
+	}
+}
+
+func TestGetDeletesExpiredEntries(t *testing.T) {
+	c := NewTTLCache[string, int](time.Millisecond)
+	c.Set("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get returned an expired entry")
+	}
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len = %d after reading an expired entry, want 0", n)
+	}
+}
+
+func TestSetSweepsExpiredEntries(t *testing.T) {
+	c := NewTTLCache[int, int](time.Millisecond)
+
+	// expired keys that are never read again must not accumulate
+	for round := 0; round < 10; round++ {
+		for i := 0; i < minSweep; i++ {
+			c.Set(round*minSweep+i, i)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	if n := c.Len(); n > 2*minSweep {
+		t.Errorf("Len = %d, want at most %d", n, 2*minSweep)
+	}
+}
+
+func TestSetKeepsLiveEntriesWhenSweeping(t *testing.T) {
+	c := NewTTLCache[int, int](time.Minute)
+	for i := 0; i < 3*minSweep; i++ {
+		c.Set(i, i)
+	}
+	if n := c.Len(); n != 3*minSweep {
+		t.Fatalf("Len = %d, want %d", n, 3*minSweep)
+	}
+	for i := 0; i < 3*minSweep; i++ {
+		if _, ok := c.Get(i); !ok {
+			t.Fatalf("Get(%d) missed", i)
+		}
+	}
+}
+
+func TestZeroTTLDisablesCaching(t *testing.T) {
+	c := NewTTLCache[string, int](0)
+	c.Set("a", 1)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get hit with caching disabled")
+	}
+}
+
+func TestDeleteAndPurge(t *testing.T) {
+	c := NewTTLCache[string, int](time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) hit after Delete")
+	}
+	c.Purge()
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len = %d after Purge, want 0", n)
+	}
+}
@@ -0,0 +1,212 @@
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Options configures a Policy
+type Options struct {
+	// AllowedOrigins are exact origins ("https://app.example.com"), wildcard subdomains
+	// ("https://*.example.com", which does not match the apex domain) or "*" for any origin
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response; 0 omits the header
+	MaxAge time.Duration
+}
+
+// Policy decides which cross-origin requests browsers may make
+type Policy struct {
+	exact    map[string]bool
+	wildcard []wildcardOrigin
+	any      bool
+
+	methods     []string
+	headers     []string
+	credentials bool
+
+	allowMethods  string
+	allowHeaders  string
+	exposeHeaders string
+	maxAge        string
+}
+
+// wildcardOrigin matches any subdomain of suffix with the given scheme and port
+type wildcardOrigin struct {
+	scheme string
+	suffix string // ".example.com"
+	port   string
+}
+
+// New compiles opts into a Policy, rejecting malformed origins and "*" with credentials
+func New(opts Options) (*Policy, error) {
+	p := &Policy{
+		exact:       map[string]bool{},
+		credentials: opts.AllowCredentials,
+	}
+
+	for _, origin := range opts.AllowedOrigins {
+		if origin == "*" {
+			if opts.AllowCredentials {
+				return nil, errors.New(`cors: origin "*" cannot be combined with credentials`)
+			}
+			p.any = true
+			continue
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.User != nil {
+			return nil, fmt.Errorf("cors: invalid origin %q, expected scheme://host[:port]", origin)
+		}
+		host := strings.ToLower(u.Hostname())
+
+		if suffix, ok := strings.CutPrefix(host, "*."); ok {
+			if suffix == "" || strings.Contains(suffix, "*") {
+				return nil, fmt.Errorf("cors: invalid wildcard origin %q", origin)
+			}
+			p.wildcard = append(p.wildcard, wildcardOrigin{scheme: u.Scheme, suffix: "." + suffix, port: u.Port()})
+			continue
+		}
+		if strings.Contains(host, "*") {
+			return nil, fmt.Errorf("cors: wildcards are only allowed as the first label, got %q", origin)
+		}
+		p.exact[normalize(u)] = true
+	}
+
+	for _, method := range opts.AllowedMethods {
+		p.methods = append(p.methods, strings.ToUpper(method))
+	}
+	for _, header := range opts.AllowedHeaders {
+		p.headers = append(p.headers, http.CanonicalHeaderKey(header))
+	}
+	p.allowMethods = strings.Join(p.methods, ", ")
+	p.allowHeaders = strings.Join(p.headers, ", ")
+	p.exposeHeaders = strings.Join(opts.ExposedHeaders, ", ")
+	if opts.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(int(opts.MaxAge.Seconds()))
+	}
+
+	return p, nil
+}
+
+// AllowOrigin reports whether origin (the Origin request header) is allowed
+func (p *Policy) AllowOrigin(origin string) bool {
+	if p.any {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if p.exact[normalize(u)] {
+		return true
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, w := range p.wildcard {
+		if u.Scheme == w.scheme && u.Port() == w.port && len(host) > len(w.suffix) && strings.HasSuffix(host, w.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware applies the policy. Disallowed preflights are answered with 403; other requests
+// from disallowed origins proceed without CORS headers, so the browser withholds the response.
+func (p *Policy) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		// responses differ per origin, so shared caches must key on it
+		header.Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if !p.AllowOrigin(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if p.any && !p.credentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if p.credentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if p.exposeHeaders != "" {
+				header.Set("Access-Control-Expose-Headers", p.exposeHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		if !p.allowsPreflight(c.GetHeader("Access-Control-Request-Method"), c.GetHeader("Access-Control-Request-Headers")) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		header.Set("Access-Control-Allow-Methods", p.allowMethods)
+		if p.allowHeaders != "" {
+			header.Set("Access-Control-Allow-Headers", p.allowHeaders)
+		}
+		if p.maxAge != "" {
+			header.Set("Access-Control-Max-Age", p.maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// allowsPreflight checks the method and headers a preflight asks for
+func (p *Policy) allowsPreflight(method string, requestHeaders string) bool {
+	if !slices.Contains(p.methods, strings.ToUpper(method)) {
+		return false
+	}
+	for _, h := range strings.Split(requestHeaders, ",") {
+		h = strings.TrimSpace(h)
+		if h != "" && !slices.Contains(p.headers, http.CanonicalHeaderKey(h)) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize returns the lower-cased scheme://host[:port] of u, dropping default ports
+func normalize(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "https" && port == "443") || (u.Scheme == "http" && port == "80") {
+		port = ""
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	return strings.ToLower(u.Scheme) + "://" + host
+}
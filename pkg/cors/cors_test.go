@@ -0,0 +1,202 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serve runs req through a router with policy p in front of GET and POST /resource
+func serve(t *testing.T, p *Policy, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(p.Middleware())
+	handler := func(c *gin.Context) { c.String(http.StatusOK, "ok") }
+	router.GET("/resource", handler)
+	router.POST("/resource", handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func newPolicy(t *testing.T, opts Options) *Policy {
+	t.Helper()
+	p, err := New(opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return p
+}
+
+func credentialedOptions() Options {
+	return Options{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.clinic.example.com", "http://localhost:5173"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+}
+
+func TestSimpleRequests(t *testing.T) {
+	p := newPolicy(t, credentialedOptions())
+
+	tests := []struct {
+		name        string
+		origin      string
+		wantAllowed bool
+	}{
+		{"exact origin", "https://app.example.com", true},
+		{"default port is ignored", "https://app.example.com:443", true},
+		{"wildcard subdomain", "https://north.clinic.example.com", true},
+		{"explicit port", "http://localhost:5173", true},
+		{"wildcard does not match apex", "https://clinic.example.com", false},
+		{"scheme must match", "http://app.example.com", false},
+		{"port must match", "http://localhost:3000", false},
+		{"suffix is not a subdomain", "https://evilapp.example.com", false},
+		{"lookalike domain", "https://app.example.com.evil.test", false},
+		{"null origin", "null", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+			req.Header.Set("Origin", tt.origin)
+			w := serve(t, p, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d; simple requests always reach the handler", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Values("Vary"); len(got) == 0 || got[0] != "Origin" {
+				t.Errorf("Vary = %v, want Origin", got)
+			}
+
+			allowOrigin := w.Header().Get("Access-Control-Allow-Origin")
+			if !tt.wantAllowed {
+				if allowOrigin != "" || w.Header().Get("Access-Control-Allow-Credentials") != "" {
+					t.Errorf("disallowed origin got CORS headers: %v", w.Header())
+				}
+				return
+			}
+			if allowOrigin != tt.origin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", allowOrigin, tt.origin)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want true", got)
+			}
+			if got := w.Header().Get("Access-Control-Expose-Headers"); got != "X-Request-ID" {
+				t.Errorf("Access-Control-Expose-Headers = %q, want X-Request-ID", got)
+			}
+		})
+	}
+}
+
+func TestRequestWithoutOrigin(t *testing.T) {
+	p := newPolicy(t, credentialedOptions())
+	w := serve(t, p, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if len(w.Header().Values("Vary")) != 0 || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("same-origin request got CORS headers: %v", w.Header())
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	p := newPolicy(t, credentialedOptions())
+
+	tests := []struct {
+		name       string
+		origin     string
+		method     string
+		headers    string
+		wantStatus int
+	}{
+		{"allowed", "https://app.example.com", "POST", "content-type, x-csrf-token", http.StatusNoContent},
+		{"allowed without headers", "https://north.clinic.example.com", "GET", "", http.StatusNoContent},
+		{"disallowed origin", "https://evil.test", "POST", "content-type", http.StatusForbidden},
+		{"disallowed method", "https://app.example.com", "DELETE", "", http.StatusForbidden},
+		{"disallowed header", "https://app.example.com", "POST", "content-type, x-debug", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/resource", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			if tt.headers != "" {
+				req.Header.Set("Access-Control-Request-Headers", tt.headers)
+			}
+			w := serve(t, p, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Body.Len() != 0 {
+				t.Errorf("preflight reached the handler: %q", w.Body.String())
+			}
+			if tt.wantStatus != http.StatusNoContent {
+				if got := w.Header().Get("Access-Control-Allow-Methods"); got != "" {
+					t.Errorf("rejected preflight got Access-Control-Allow-Methods %q", got)
+				}
+				return
+			}
+
+			want := map[string]string{
+				"Access-Control-Allow-Origin":      tt.origin,
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, POST",
+				"Access-Control-Allow-Headers":     "Content-Type, X-Csrf-Token",
+				"Access-Control-Max-Age":           "600",
+			}
+			for header, value := range want {
+				if got := w.Header().Get(header); got != value {
+					t.Errorf("%s = %q, want %q", header, got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestAnyOrigin(t *testing.T) {
+	p := newPolicy(t, Options{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+	req.Header.Set("Origin", "https://anywhere.test")
+	w := serve(t, p, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want none", got)
+	}
+}
+
+func TestNewRejectsInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"any origin with credentials", Options{AllowedOrigins: []string{"*"}, AllowCredentials: true}},
+		{"missing scheme", Options{AllowedOrigins: []string{"app.example.com"}}},
+		{"unsupported scheme", Options{AllowedOrigins: []string{"ftp://app.example.com"}}},
+		{"path", Options{AllowedOrigins: []string{"https://app.example.com/app"}}},
+		{"wildcard not first label", Options{AllowedOrigins: []string{"https://app.*.example.com"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.opts); err == nil {
+				t.Errorf("New(%v) succeeded, want error", tt.opts.AllowedOrigins)
+			}
+		})
+	}
+}
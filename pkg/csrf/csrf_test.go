@@ -0,0 +1,38 @@
+package csrf
+
+import "testing"
+
+func TestValid(t *testing.T) {
+	p := NewProtector("signing-secret")
+	session := "refresh-token-a"
+	token := p.Token(session)
+
+	tests := []struct {
+		name    string
+		session string
+		token   string
+		want    bool
+	}{
+		{"matching token", session, token, true},
+		{"missing token", session, "", false},
+		{"missing session", "", token, false},
+		{"wrong token", session, "not-the-token", false},
+		{"token bound to another session", "refresh-token-b", token, false},
+		{"token of another secret", session, NewProtector("other-secret").Token(session), false},
+		{"truncated token", session, token[:len(token)-1], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Valid(tt.session, tt.token); got != tt.want {
+				t.Errorf("Valid(%q, %q) = %v, want %v", tt.session, tt.token, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenIsStable(t *testing.T) {
+	if NewProtector("signing-secret").Token("session") != NewProtector("signing-secret").Token("session") {
+		t.Error("tokens for the same secret and session differ")
+	}
+}
@@ -12,7 +12,7 @@ import (
 )
 
 const createCategory = `-- name: CreateCategory :one
-INSERT INTO categories (name_category) VALUES ($1) RETURNING id, name_category, created_at, updated_at
+INSERT INTO categories (name_category) VALUES ($1) RETURNING id, name_category, created_at, updated_at, version
 `
 
 func (q *Queries) CreateCategory(ctx context.Context, nameCategory string) (Category, error) {
@@ -23,6 +23,7 @@ func (q *Queries) CreateCategory(ctx context.Context, nameCategory string) (Cate
 		&i.NameCategory,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -37,7 +38,7 @@ func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
 }
 
 const findCategories = `-- name: FindCategories :many
-SELECT id, name_category, created_at, updated_at FROM categories
+SELECT id, name_category, created_at, updated_at, version FROM categories
 `
 
 func (q *Queries) FindCategories(ctx context.Context) ([]Category, error) {
@@ -54,6 +55,7 @@ func (q *Queries) FindCategories(ctx context.Context) ([]Category, error) {
 			&i.NameCategory,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -66,7 +68,7 @@ func (q *Queries) FindCategories(ctx context.Context) ([]Category, error) {
 }
 
 const findCategoryById = `-- name: FindCategoryById :one
-SELECT id, name_category, created_at, updated_at FROM categories WHERE id = $1
+SELECT id, name_category, created_at, updated_at, version FROM categories WHERE id = $1
 `
 
 func (q *Queries) FindCategoryById(ctx context.Context, id uuid.UUID) (Category, error) {
@@ -77,12 +79,13 @@ func (q *Queries) FindCategoryById(ctx context.Context, id uuid.UUID) (Category,
 		&i.NameCategory,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const findCategoryByName = `-- name: FindCategoryByName :one
-SELECT id, name_category, created_at, updated_at FROM categories WHERE name_category = $1
+SELECT id, name_category, created_at, updated_at, version FROM categories WHERE name_category = $1
 `
 
 func (q *Queries) FindCategoryByName(ctx context.Context, nameCategory string) (Category, error) {
@@ -93,22 +96,46 @@ func (q *Queries) FindCategoryByName(ctx context.Context, nameCategory string) (
 		&i.NameCategory,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const patchCategory = `-- name: PatchCategory :one
+UPDATE categories SET
+  name_category = COALESCE($1, name_category),
+  version = version + 1,
+  updated_at = NOW()
+WHERE id = $2 AND version = $3 RETURNING id, name_category, created_at, updated_at, version
+`
+
+func (q *Queries) PatchCategory(ctx context.Context, nameCategory *string, iD uuid.UUID, version int32) (Category, error) {
+	row := q.db.QueryRow(ctx, patchCategory, nameCategory, iD, version)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.NameCategory,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const updateCategory = `-- name: UpdateCategory :one
-UPDATE categories SET name_category = $2 WHERE id = $1 RETURNING id, name_category, created_at, updated_at
+UPDATE categories SET name_category = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND version = $3 RETURNING id, name_category, created_at, updated_at, version
 `
 
-func (q *Queries) UpdateCategory(ctx context.Context, iD uuid.UUID, nameCategory string) (Category, error) {
-	row := q.db.QueryRow(ctx, updateCategory, iD, nameCategory)
+func (q *Queries) UpdateCategory(ctx context.Context, iD uuid.UUID, nameCategory string, version int32) (Category, error) {
+	row := q.db.QueryRow(ctx, updateCategory, iD, nameCategory, version)
 	var i Category
 	err := row.Scan(
 		&i.ID,
 		&i.NameCategory,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -16,6 +16,7 @@ type Category struct {
 	NameCategory string    `db:"name_category"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
+	Version      int32     `db:"version"`
 }
 
 type Permission struct {
@@ -30,14 +31,15 @@ type Permission struct {
 }
 
 type Role struct {
-	ID              uuid.UUID `db:"id"`
-	Name            string    `db:"name"`
-	Code            string    `db:"code"`
-	Level           int32     `db:"level"`
-	Description     string    `db:"description"`
-	CanSelfRegister bool      `db:"can_self_register"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
+	ID                 uuid.UUID `db:"id"`
+	Name               string    `db:"name"`
+	Code               string    `db:"code"`
+	Level              int32     `db:"level"`
+	Description        string    `db:"description"`
+	CanSelfRegister    bool      `db:"can_self_register"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+	InheritPermissions bool      `db:"inherit_permissions"`
 }
 
 type RolePermission struct {
@@ -47,6 +49,19 @@ type RolePermission struct {
 	UpdatedAt    time.Time `db:"updated_at"`
 }
 
+type StaffInvite struct {
+	ID         uuid.UUID `db:"id"`
+	Email      string    `db:"email"`
+	RoleID     uuid.UUID `db:"role_id"`
+	TokenHash  string    `db:"token_hash"`
+	InvitedBy  uuid.UUID `db:"invited_by"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	AcceptedAt time.Time `db:"accepted_at"`
+	RevokedAt  time.Time `db:"revoked_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
 type Treatment struct {
 	ID            uuid.UUID      `db:"id"`
 	CategoryID    uuid.UUID      `db:"category_id"`
@@ -58,6 +73,7 @@ type Treatment struct {
 	IsActive      bool           `db:"is_active"`
 	CreatedAt     time.Time      `db:"created_at"`
 	UpdatedAt     time.Time      `db:"updated_at"`
+	Version       int32          `db:"version"`
 }
 
 type User struct {
@@ -23,15 +23,16 @@ type Permission struct {
 }
 
 type Role struct {
-	ID              uuid.UUID `db:"id"`
-	Name            string    `db:"name"`
-	Code            string    `db:"code"`
-	Level           int32     `db:"level"`
-	Description     string    `db:"description"`
-	CanSelfRegister bool      `db:"can_self_register"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
-	DeletedAt       time.Time `db:"deleted_at"`
+	ID                 uuid.UUID `db:"id"`
+	Name               string    `db:"name"`
+	Code               string    `db:"code"`
+	Level              int32     `db:"level"`
+	Description        string    `db:"description"`
+	CanSelfRegister    bool      `db:"can_self_register"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+	DeletedAt          time.Time `db:"deleted_at"`
+	InheritPermissions bool      `db:"inherit_permissions"`
 }
 
 type RolePermission struct {
@@ -42,6 +43,19 @@ type RolePermission struct {
 	DeletedAt    time.Time `db:"deleted_at"`
 }
 
+type StaffInvite struct {
+	ID         uuid.UUID `db:"id"`
+	Email      string    `db:"email"`
+	RoleID     uuid.UUID `db:"role_id"`
+	TokenHash  string    `db:"token_hash"`
+	InvitedBy  uuid.UUID `db:"invited_by"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	AcceptedAt time.Time `db:"accepted_at"`
+	RevokedAt  time.Time `db:"revoked_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
 type User struct {
 	ID              uuid.UUID `db:"id"`
 	Name            string    `db:"name"`
@@ -9,6 +9,37 @@ import (
 	"context"
 )
 
+const getActivePermissions = `-- name: GetActivePermissions :many
+SELECT module, action FROM permissions
+WHERE is_active = true
+ORDER BY module, action
+`
+
+type GetActivePermissionsRow struct {
+	Module string `db:"module"`
+	Action string `db:"action"`
+}
+
+func (q *Queries) GetActivePermissions(ctx context.Context) ([]GetActivePermissionsRow, error) {
+	rows, err := q.db.Query(ctx, getActivePermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActivePermissionsRow
+	for rows.Next() {
+		var i GetActivePermissionsRow
+		if err := rows.Scan(&i.Module, &i.Action); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPermissionByModuleAction = `-- name: GetPermissionByModuleAction :one
 SELECT id, module, action, name, description, is_active, created_at, updated_at, deleted_at FROM permissions
 WHERE module = $1 AND action = $2 AND is_active = true
@@ -11,6 +11,44 @@ import (
 	"github.com/google/uuid"
 )
 
+const getEffectiveRolePermissions = `-- name: GetEffectiveRolePermissions :many
+SELECT DISTINCT
+    p.module, p.action, p.name
+FROM roles r
+JOIN roles src ON src.id = r.id OR (r.inherit_permissions AND src.level < r.level)
+JOIN role_permissions rp ON rp.role_id = src.id
+JOIN permissions p ON rp.permission_id = p.id
+WHERE r.id = $1::uuid AND p.is_active = true
+`
+
+type GetEffectiveRolePermissionsRow struct {
+	Module string `db:"module"`
+	Action string `db:"action"`
+	Name   string `db:"name"`
+}
+
+// Permissions granted to the role itself, plus those of every lower-level role
+// when the role is configured to inherit them.
+func (q *Queries) GetEffectiveRolePermissions(ctx context.Context, dollar_1 uuid.UUID) ([]GetEffectiveRolePermissionsRow, error) {
+	rows, err := q.db.Query(ctx, getEffectiveRolePermissions, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEffectiveRolePermissionsRow
+	for rows.Next() {
+		var i GetEffectiveRolePermissionsRow
+		if err := rows.Scan(&i.Module, &i.Action, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRolePermissions = `-- name: GetRolePermissions :many
 SELECT
     p.module, p.action, p.name
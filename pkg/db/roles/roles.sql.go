@@ -11,8 +11,47 @@ import (
 	"github.com/google/uuid"
 )
 
+const createRole = `-- name: CreateRole :one
+INSERT INTO roles (name, code, level, description, can_self_register, inherit_permissions)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions
+`
+
+type CreateRoleParams struct {
+	Name               string `db:"name"`
+	Code               string `db:"code"`
+	Level              int32  `db:"level"`
+	Description        string `db:"description"`
+	CanSelfRegister    bool   `db:"can_self_register"`
+	InheritPermissions bool   `db:"inherit_permissions"`
+}
+
+func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error) {
+	row := q.db.QueryRow(ctx, createRole,
+		arg.Name,
+		arg.Code,
+		arg.Level,
+		arg.Description,
+		arg.CanSelfRegister,
+		arg.InheritPermissions,
+	)
+	var i Role
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Code,
+		&i.Level,
+		&i.Description,
+		&i.CanSelfRegister,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.InheritPermissions,
+	)
+	return i, err
+}
+
 const getAllRoles = `-- name: GetAllRoles :many
-SELECT id, name, code, level, description, can_self_register, created_at, updated_at FROM roles
+SELECT id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions FROM roles ORDER BY level DESC
 `
 
 func (q *Queries) GetAllRoles(ctx context.Context) ([]Role, error) {
@@ -33,6 +72,7 @@ func (q *Queries) GetAllRoles(ctx context.Context) ([]Role, error) {
 			&i.CanSelfRegister,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.InheritPermissions,
 		); err != nil {
 			return nil, err
 		}
@@ -45,7 +85,7 @@ func (q *Queries) GetAllRoles(ctx context.Context) ([]Role, error) {
 }
 
 const getRoleByCode = `-- name: GetRoleByCode :one
-SELECT id, name, code, level, description, can_self_register, created_at, updated_at FROM roles WHERE code = $1 LIMIT 1
+SELECT id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions FROM roles WHERE code = $1 LIMIT 1
 `
 
 func (q *Queries) GetRoleByCode(ctx context.Context, code string) (Role, error) {
@@ -60,12 +100,13 @@ func (q *Queries) GetRoleByCode(ctx context.Context, code string) (Role, error)
 		&i.CanSelfRegister,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InheritPermissions,
 	)
 	return i, err
 }
 
 const getRoleByID = `-- name: GetRoleByID :one
-SELECT id, name, code, level, description, can_self_register, created_at, updated_at FROM roles r WHERE r.id = $1
+SELECT id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions FROM roles r WHERE r.id = $1
 `
 
 func (q *Queries) GetRoleByID(ctx context.Context, id uuid.UUID) (Role, error) {
@@ -80,6 +121,52 @@ func (q *Queries) GetRoleByID(ctx context.Context, id uuid.UUID) (Role, error) {
 		&i.CanSelfRegister,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InheritPermissions,
+	)
+	return i, err
+}
+
+const updateRole = `-- name: UpdateRole :one
+UPDATE roles SET
+  name = $2,
+  level = $3,
+  description = $4,
+  can_self_register = $5,
+  inherit_permissions = $6,
+  updated_at = NOW()
+WHERE id = $1
+RETURNING id, name, code, level, description, can_self_register, created_at, updated_at, inherit_permissions
+`
+
+type UpdateRoleParams struct {
+	ID                 uuid.UUID `db:"id"`
+	Name               string    `db:"name"`
+	Level              int32     `db:"level"`
+	Description        string    `db:"description"`
+	CanSelfRegister    bool      `db:"can_self_register"`
+	InheritPermissions bool      `db:"inherit_permissions"`
+}
+
+func (q *Queries) UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error) {
+	row := q.db.QueryRow(ctx, updateRole,
+		arg.ID,
+		arg.Name,
+		arg.Level,
+		arg.Description,
+		arg.CanSelfRegister,
+		arg.InheritPermissions,
+	)
+	var i Role
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Code,
+		&i.Level,
+		&i.Description,
+		&i.CanSelfRegister,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.InheritPermissions,
 	)
 	return i, err
 }
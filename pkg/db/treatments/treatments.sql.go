@@ -14,7 +14,7 @@ import (
 const createTreatment = `-- name: CreateTreatment :one
 INSERT INTO treatments
 (category_id, name_treatment, description, thumbnail, price, duration, is_active)
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version
 `
 
 type CreateTreatmentParams struct {
@@ -49,6 +49,7 @@ func (q *Queries) CreateTreatment(ctx context.Context, arg CreateTreatmentParams
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -63,7 +64,7 @@ func (q *Queries) DeleteTreatment(ctx context.Context, id uuid.UUID) error {
 }
 
 const findTreatmentById = `-- name: FindTreatmentById :one
-SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at FROM treatments WHERE id = $1
+SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version FROM treatments WHERE id = $1
 `
 
 func (q *Queries) FindTreatmentById(ctx context.Context, id uuid.UUID) (Treatment, error) {
@@ -80,12 +81,13 @@ func (q *Queries) FindTreatmentById(ctx context.Context, id uuid.UUID) (Treatmen
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const findTreatmentByName = `-- name: FindTreatmentByName :one
-SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at FROM treatments WHERE name_treatment = $1
+SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version FROM treatments WHERE name_treatment = $1
 `
 
 func (q *Queries) FindTreatmentByName(ctx context.Context, nameTreatment string) (Treatment, error) {
@@ -102,12 +104,13 @@ func (q *Queries) FindTreatmentByName(ctx context.Context, nameTreatment string)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const findTreatments = `-- name: FindTreatments :many
-SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at FROM treatments
+SELECT id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version FROM treatments
 `
 
 func (q *Queries) FindTreatments(ctx context.Context) ([]Treatment, error) {
@@ -130,6 +133,7 @@ func (q *Queries) FindTreatments(ctx context.Context) ([]Treatment, error) {
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -141,6 +145,61 @@ func (q *Queries) FindTreatments(ctx context.Context) ([]Treatment, error) {
 	return items, nil
 }
 
+const patchTreatment = `-- name: PatchTreatment :one
+UPDATE treatments SET
+  category_id = COALESCE($1, category_id),
+  name_treatment = COALESCE($2, name_treatment),
+  description = COALESCE($3, description),
+  thumbnail = COALESCE($4, thumbnail),
+  price = COALESCE($5, price),
+  duration = COALESCE($6, duration),
+  is_active = COALESCE($7, is_active),
+  version = version + 1,
+  updated_at = NOW()
+WHERE id = $8 AND version = $9 RETURNING id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version
+`
+
+type PatchTreatmentParams struct {
+	CategoryID    *uuid.UUID `db:"category_id"`
+	NameTreatment *string    `db:"name_treatment"`
+	Description   *string    `db:"description"`
+	Thumbnail     *string    `db:"thumbnail"`
+	Price         *float64   `db:"price"`
+	Duration      *int32     `db:"duration"`
+	IsActive      *bool      `db:"is_active"`
+	ID            uuid.UUID  `db:"id"`
+	Version       int32      `db:"version"`
+}
+
+func (q *Queries) PatchTreatment(ctx context.Context, arg PatchTreatmentParams) (Treatment, error) {
+	row := q.db.QueryRow(ctx, patchTreatment,
+		arg.CategoryID,
+		arg.NameTreatment,
+		arg.Description,
+		arg.Thumbnail,
+		arg.Price,
+		arg.Duration,
+		arg.IsActive,
+		arg.ID,
+		arg.Version,
+	)
+	var i Treatment
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryID,
+		&i.NameTreatment,
+		&i.Description,
+		&i.Thumbnail,
+		&i.Price,
+		&i.Duration,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const updateTreatment = `-- name: UpdateTreatment :one
 UPDATE treatments SET
   category_id = $2,
@@ -149,8 +208,10 @@ UPDATE treatments SET
   thumbnail = $5,
   price = $6,
   duration = $7,
-  is_active = $8
-WHERE id = $1 RETURNING id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at
+  is_active = $8,
+  version = version + 1,
+  updated_at = NOW()
+WHERE id = $1 AND version = $9 RETURNING id, category_id, name_treatment, description, thumbnail, price, duration, is_active, created_at, updated_at, version
 `
 
 type UpdateTreatmentParams struct {
@@ -162,6 +223,7 @@ type UpdateTreatmentParams struct {
 	Price         float64   `db:"price"`
 	Duration      int32     `db:"duration"`
 	IsActive      bool      `db:"is_active"`
+	Version       int32     `db:"version"`
 }
 
 func (q *Queries) UpdateTreatment(ctx context.Context, arg UpdateTreatmentParams) (Treatment, error) {
@@ -174,6 +236,7 @@ func (q *Queries) UpdateTreatment(ctx context.Context, arg UpdateTreatmentParams
 		arg.Price,
 		arg.Duration,
 		arg.IsActive,
+		arg.Version,
 	)
 	var i Treatment
 	err := row.Scan(
@@ -187,6 +250,7 @@ func (q *Queries) UpdateTreatment(ctx context.Context, arg UpdateTreatmentParams
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
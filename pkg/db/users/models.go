@@ -15,6 +15,7 @@ type Category struct {
 	NameCategory string    `db:"name_category"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
+	Version      int32     `db:"version"`
 }
 
 type Permission struct {
@@ -29,14 +30,15 @@ type Permission struct {
 }
 
 type Role struct {
-	ID              uuid.UUID `db:"id"`
-	Name            string    `db:"name"`
-	Code            string    `db:"code"`
-	Level           int32     `db:"level"`
-	Description     string    `db:"description"`
-	CanSelfRegister bool      `db:"can_self_register"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
+	ID                 uuid.UUID `db:"id"`
+	Name               string    `db:"name"`
+	Code               string    `db:"code"`
+	Level              int32     `db:"level"`
+	Description        string    `db:"description"`
+	CanSelfRegister    bool      `db:"can_self_register"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+	InheritPermissions bool      `db:"inherit_permissions"`
 }
 
 type RolePermission struct {
@@ -46,6 +48,19 @@ type RolePermission struct {
 	UpdatedAt    time.Time `db:"updated_at"`
 }
 
+type StaffInvite struct {
+	ID         uuid.UUID `db:"id"`
+	Email      string    `db:"email"`
+	RoleID     uuid.UUID `db:"role_id"`
+	TokenHash  string    `db:"token_hash"`
+	InvitedBy  uuid.UUID `db:"invited_by"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	AcceptedAt time.Time `db:"accepted_at"`
+	RevokedAt  time.Time `db:"revoked_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
 type Treatment struct {
 	ID            uuid.UUID `db:"id"`
 	CategoryID    uuid.UUID `db:"category_id"`
@@ -57,6 +72,7 @@ type Treatment struct {
 	IsActive      bool      `db:"is_active"`
 	CreatedAt     time.Time `db:"created_at"`
 	UpdatedAt     time.Time `db:"updated_at"`
+	Version       int32     `db:"version"`
 }
 
 type User struct {
@@ -72,6 +88,8 @@ type User struct {
 	DeletedAt       time.Time `db:"deleted_at"`
 	CreatedAt       time.Time `db:"created_at"`
 	UpdatedAt       time.Time `db:"updated_at"`
+	Locale          string    `db:"locale"`
+	PhoneNumberBidx string    `db:"phone_number_bidx"`
 }
 
 type UserSession struct {
@@ -21,9 +21,11 @@ INSERT INTO users(
   role_id,
   is_verified,
   verify_code,
-  verify_expires_at
+  verify_expires_at,
+  locale,
+  phone_number_bidx
 )VALUES(
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
 RETURNING
   id,
@@ -47,6 +49,8 @@ type CreateUserParams struct {
 	IsVerified      bool      `db:"is_verified"`
 	VerifyCode      string    `db:"verify_code"`
 	VerifyExpiresAt time.Time `db:"verify_expires_at"`
+	Locale          string    `db:"locale"`
+	PhoneNumberBidx string    `db:"phone_number_bidx"`
 }
 
 type CreateUserRow struct {
@@ -72,6 +76,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		arg.IsVerified,
 		arg.VerifyCode,
 		arg.VerifyExpiresAt,
+		arg.Locale,
+		arg.PhoneNumberBidx,
 	)
 	var i CreateUserRow
 	err := row.Scan(
@@ -93,7 +99,7 @@ const deleteUser = `-- name: DeleteUser :one
 UPDATE users
 SET deleted_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, name, email, password, phone_number, role_id, is_verified, verify_code, verify_expires_at, deleted_at, created_at, updated_at
+RETURNING id, name, email, password, phone_number, role_id, is_verified, verify_code, verify_expires_at, deleted_at, created_at, updated_at, locale, phone_number_bidx
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) (User, error) {
@@ -112,6 +118,8 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
+		&i.PhoneNumberBidx,
 	)
 	return i, err
 }
@@ -194,7 +202,7 @@ func (q *Queries) FindAllUsers(ctx context.Context, limit int32) ([]FindAllUsers
 const findUserByEmail = `-- name: FindUserByEmail :one
 SELECT
     u.id, u.email, u.password, u.name, u.phone_number,
-    u.role_id, u.is_verified, COALESCE(u.verify_code, '') AS verify_code,COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,u.created_at, u.updated_at,
+    u.role_id, u.is_verified, COALESCE(u.verify_code, '') AS verify_code,COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,u.created_at, u.updated_at, u.locale,
     r.id as role_id, r.name as role_name, r.code as role_code,
     r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
 FROM users u
@@ -214,6 +222,7 @@ type FindUserByEmailRow struct {
 	VerifyExpiresAt     time.Time `db:"verify_expires_at"`
 	CreatedAt           time.Time `db:"created_at"`
 	UpdatedAt           time.Time `db:"updated_at"`
+	Locale              string    `db:"locale"`
 	RoleID_2            uuid.UUID `db:"role_id_2"`
 	RoleName            string    `db:"role_name"`
 	RoleCode            string    `db:"role_code"`
@@ -237,6 +246,7 @@ func (q *Queries) FindUserByEmail(ctx context.Context, email string) (FindUserBy
 		&i.VerifyExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
 		&i.RoleID_2,
 		&i.RoleName,
 		&i.RoleCode,
@@ -253,7 +263,7 @@ SELECT
 	u.role_id, u.is_verified,
 	COALESCE(u.verify_code, '') AS verify_code,
 	COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,
-	u.created_at, u.updated_at,
+	u.created_at, u.updated_at, u.locale,
 	r.id as role_id, r.name as role_name, r.code as role_code,
 	r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
 FROM users u
@@ -272,6 +282,7 @@ type FindUserByIdRow struct {
 	VerifyExpiresAt     time.Time `db:"verify_expires_at"`
 	CreatedAt           time.Time `db:"created_at"`
 	UpdatedAt           time.Time `db:"updated_at"`
+	Locale              string    `db:"locale"`
 	RoleID_2            uuid.UUID `db:"role_id_2"`
 	RoleName            string    `db:"role_name"`
 	RoleCode            string    `db:"role_code"`
@@ -294,6 +305,67 @@ func (q *Queries) FindUserById(ctx context.Context, id uuid.UUID) (FindUserByIdR
 		&i.VerifyExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
+		&i.RoleID_2,
+		&i.RoleName,
+		&i.RoleCode,
+		&i.RoleLevel,
+		&i.RoleDescription,
+		&i.RoleCanSelfRegister,
+	)
+	return i, err
+}
+
+const findUserByPhoneBidx = `-- name: FindUserByPhoneBidx :one
+SELECT
+	u.id, u.email, u.name, u.phone_number,
+	u.role_id, u.is_verified,
+	COALESCE(u.verify_code, '') AS verify_code,
+	COALESCE(u.verify_expires_at, NOW()) AS verify_expires_at,
+	u.created_at, u.updated_at, u.locale,
+	r.id as role_id, r.name as role_name, r.code as role_code,
+	r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
+FROM users u
+JOIN roles r ON u.role_id = r.id
+WHERE u.phone_number_bidx = $1 AND u.deleted_at IS NULL
+LIMIT 1
+`
+
+type FindUserByPhoneBidxRow struct {
+	ID                  uuid.UUID `db:"id"`
+	Email               string    `db:"email"`
+	Name                string    `db:"name"`
+	PhoneNumber         string    `db:"phone_number"`
+	RoleID              uuid.UUID `db:"role_id"`
+	IsVerified          bool      `db:"is_verified"`
+	VerifyCode          string    `db:"verify_code"`
+	VerifyExpiresAt     time.Time `db:"verify_expires_at"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
+	Locale              string    `db:"locale"`
+	RoleID_2            uuid.UUID `db:"role_id_2"`
+	RoleName            string    `db:"role_name"`
+	RoleCode            string    `db:"role_code"`
+	RoleLevel           int32     `db:"role_level"`
+	RoleDescription     string    `db:"role_description"`
+	RoleCanSelfRegister bool      `db:"role_can_self_register"`
+}
+
+func (q *Queries) FindUserByPhoneBidx(ctx context.Context, phoneNumberBidx string) (FindUserByPhoneBidxRow, error) {
+	row := q.db.QueryRow(ctx, findUserByPhoneBidx, phoneNumberBidx)
+	var i FindUserByPhoneBidxRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.PhoneNumber,
+		&i.RoleID,
+		&i.IsVerified,
+		&i.VerifyCode,
+		&i.VerifyExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Locale,
 		&i.RoleID_2,
 		&i.RoleName,
 		&i.RoleCode,
@@ -308,7 +380,7 @@ const findUserByVerifyCode = `-- name: FindUserByVerifyCode :one
 SELECT
     u.id, u.email, u.password, u.name, u.phone_number,
     u.role_id, u.is_verified, u.verify_code, u.verify_expires_at,
-    u.created_at, u.updated_at,
+    u.created_at, u.updated_at, u.locale,
     r.id as role_id, r.name as role_name, r.code as role_code,
     r.level as role_level, r.description as role_description, r.can_self_register as role_can_self_register
 FROM users u
@@ -328,6 +400,7 @@ type FindUserByVerifyCodeRow struct {
 	VerifyExpiresAt     time.Time `db:"verify_expires_at"`
 	CreatedAt           time.Time `db:"created_at"`
 	UpdatedAt           time.Time `db:"updated_at"`
+	Locale              string    `db:"locale"`
 	RoleID_2            uuid.UUID `db:"role_id_2"`
 	RoleName            string    `db:"role_name"`
 	RoleCode            string    `db:"role_code"`
@@ -351,6 +424,7 @@ func (q *Queries) FindUserByVerifyCode(ctx context.Context, verifyCode string) (
 		&i.VerifyExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
 		&i.RoleID_2,
 		&i.RoleName,
 		&i.RoleCode,
@@ -361,6 +435,114 @@ func (q *Queries) FindUserByVerifyCode(ctx context.Context, verifyCode string) (
 	return i, err
 }
 
+const findUserRoleID = `-- name: FindUserRoleID :one
+SELECT role_id
+FROM users
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) FindUserRoleID(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, findUserRoleID, id)
+	var role_id uuid.UUID
+	err := row.Scan(&role_id)
+	return role_id, err
+}
+
+const listUserPhoneNumbers = `-- name: ListUserPhoneNumbers :many
+SELECT id, phone_number, phone_number_bidx
+FROM users
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListUserPhoneNumbersRow struct {
+	ID              uuid.UUID `db:"id"`
+	PhoneNumber     string    `db:"phone_number"`
+	PhoneNumberBidx string    `db:"phone_number_bidx"`
+}
+
+func (q *Queries) ListUserPhoneNumbers(ctx context.Context, iD uuid.UUID, limit int32) ([]ListUserPhoneNumbersRow, error) {
+	rows, err := q.db.Query(ctx, listUserPhoneNumbers, iD, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserPhoneNumbersRow
+	for rows.Next() {
+		var i ListUserPhoneNumbersRow
+		if err := rows.Scan(&i.ID, &i.PhoneNumber, &i.PhoneNumberBidx); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const patchUser = `-- name: PatchUser :one
+UPDATE users
+SET
+    name = COALESCE($1, name),
+    phone_number = COALESCE($2, phone_number),
+    phone_number_bidx = COALESCE($3, phone_number_bidx),
+    updated_at = NOW()
+WHERE id = $4 AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    email,
+    phone_number,
+    role_id,
+    is_verified,
+    locale,
+    created_at,
+    updated_at
+`
+
+type PatchUserParams struct {
+	Name            *string   `db:"name"`
+	PhoneNumber     *string   `db:"phone_number"`
+	PhoneNumberBidx *string   `db:"phone_number_bidx"`
+	ID              uuid.UUID `db:"id"`
+}
+
+type PatchUserRow struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	Email       string    `db:"email"`
+	PhoneNumber string    `db:"phone_number"`
+	RoleID      uuid.UUID `db:"role_id"`
+	IsVerified  bool      `db:"is_verified"`
+	Locale      string    `db:"locale"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (q *Queries) PatchUser(ctx context.Context, arg PatchUserParams) (PatchUserRow, error) {
+	row := q.db.QueryRow(ctx, patchUser,
+		arg.Name,
+		arg.PhoneNumber,
+		arg.PhoneNumberBidx,
+		arg.ID,
+	)
+	var i PatchUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.RoleID,
+		&i.IsVerified,
+		&i.Locale,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET
@@ -371,6 +553,7 @@ SET
     is_verified = COALESCE($6, is_verified),
     verify_code = $7,
     verify_expires_at = $8,
+    phone_number_bidx = COALESCE($9, phone_number_bidx),
     updated_at = NOW()
 WHERE id = $1
 RETURNING
@@ -393,6 +576,7 @@ type UpdateUserParams struct {
 	IsVerified      bool      `db:"is_verified"`
 	VerifyCode      *string    `db:"verify_code"`
 	VerifyExpiresAt *time.Time `db:"verify_expires_at"`
+	PhoneNumberBidx string    `db:"phone_number_bidx"`
 }
 
 type UpdateUserRow struct {
@@ -416,6 +600,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 		arg.IsVerified,
 		arg.VerifyCode,
 		arg.VerifyExpiresAt,
+		arg.PhoneNumberBidx,
 	)
 	var i UpdateUserRow
 	err := row.Scan(
@@ -430,3 +615,88 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 	)
 	return i, err
 }
+
+const updateUserLocale = `-- name: UpdateUserLocale :exec
+UPDATE users
+SET
+    locale = $2,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) UpdateUserLocale(ctx context.Context, iD uuid.UUID, locale string) error {
+	_, err := q.db.Exec(ctx, updateUserLocale, iD, locale)
+	return err
+}
+
+const updateUserPhoneNumber = `-- name: UpdateUserPhoneNumber :execrows
+UPDATE users
+SET
+    phone_number = $1,
+    phone_number_bidx = $2
+WHERE id = $3 AND phone_number = $4
+`
+
+type UpdateUserPhoneNumberParams struct {
+	PhoneNumber     string    `db:"phone_number"`
+	PhoneNumberBidx string    `db:"phone_number_bidx"`
+	ID              uuid.UUID `db:"id"`
+	OldPhoneNumber  string    `db:"old_phone_number"`
+}
+
+func (q *Queries) UpdateUserPhoneNumber(ctx context.Context, arg UpdateUserPhoneNumberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserPhoneNumber,
+		arg.PhoneNumber,
+		arg.PhoneNumberBidx,
+		arg.ID,
+		arg.OldPhoneNumber,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET
+    role_id = $2,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING
+    id,
+    name,
+    email,
+    phone_number,
+    role_id,
+    is_verified,
+    created_at,
+    updated_at
+`
+
+type UpdateUserRoleRow struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	Email       string    `db:"email"`
+	PhoneNumber string    `db:"phone_number"`
+	RoleID      uuid.UUID `db:"role_id"`
+	IsVerified  bool      `db:"is_verified"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, iD uuid.UUID, roleID uuid.UUID) (UpdateUserRoleRow, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, iD, roleID)
+	var i UpdateUserRoleRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.PhoneNumber,
+		&i.RoleID,
+		&i.IsVerified,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,32 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Headers used for optimistic concurrency
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// Format returns the strong entity tag of a row version, e.g. `"3"`
+func Format(version int32) string {
+	return strconv.Quote(strconv.FormatInt(int64(version), 10))
+}
+
+// Parse returns the row version named by an If-Match header holding one strong entity tag.
+// Weak tags (W/"3"), lists and "*" are not accepted since an update must name the exact
+// version it was based on.
+func Parse(ifMatch string) (int32, bool) {
+	tag := strings.TrimSpace(ifMatch)
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 32)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return int32(version), true
+}
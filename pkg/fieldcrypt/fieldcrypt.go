@@ -0,0 +1,332 @@
+// Package fieldcrypt encrypts sensitive columns in the application before they reach the database.
+//
+// Every value is sealed with AES-256-GCM under its own random data key, and the data key is
+// wrapped with the active master key from the keyfile (envelope encryption). Rotating the
+// master key therefore only re-wraps data keys. Encrypted columns that must be searchable get
+// a blind index: an HMAC of the normalized plaintext stored in a <column>_bidx column.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// prefix marks encrypted values; values without it are legacy plaintext
+const prefix = "enc:v1:"
+
+// ErrUnknownKey is returned for values wrapped with a master key missing from the keyfile
+var ErrUnknownKey = errors.New("fieldcrypt: value is wrapped with an unknown master key")
+
+// Field is an encrypted column. Row structs are matched by their db tag, and the column's
+// blind index, if any, by the db tag Column + "_bidx".
+type Field struct {
+	Table  string
+	Column string
+	// Normalize prepares the plaintext for the blind index so equal values match; nil keeps it as is
+	Normalize func(string) string
+}
+
+// aad binds ciphertexts to their column so they cannot be moved to another one
+func (f Field) aad() []byte {
+	return []byte(f.Table + "." + f.Column)
+}
+
+// Keyring encrypts and decrypts fields with the keys of a keyfile
+type Keyring struct {
+	active   string
+	keys     map[string]cipher.AEAD
+	indexKey []byte
+}
+
+// Load reads the keyfile at path and returns its keyring
+func Load(path string) (*Keyring, error) {
+	f, err := ReadKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(f)
+}
+
+// New returns the keyring of f; the active master key and the blind index key are required
+func New(f *KeyFile) (*Keyring, error) {
+	k := &Keyring{active: f.Active, keys: make(map[string]cipher.AEAD, len(f.Keys))}
+	for id, encoded := range f.Keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("master key id %q must be non-empty and cannot contain ':'", id)
+		}
+		key, err := decodeKey("master key "+id, encoded)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[id] = aead
+	}
+	if _, ok := k.keys[k.active]; !ok {
+		return nil, fmt.Errorf("active master key %q is not in the keyfile", k.active)
+	}
+
+	indexKey, err := decodeKey("index key", f.IndexKey)
+	if err != nil {
+		return nil, err
+	}
+	k.indexKey = indexKey
+	return k, nil
+}
+
+// ActiveKeyID returns the id of the master key new values are wrapped with
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+// IsEncrypted reports whether value was produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt seals plaintext for field under a new data key wrapped with the active master key.
+// The empty string stays empty.
+func (k *Keyring) Encrypt(field Field, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(aead, []byte(plaintext), field.aad())
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := seal(k.keys[k.active], dataKey, wrapAAD(k.active))
+	if err != nil {
+		return "", err
+	}
+	return prefix + k.active + ":" + encode(wrapped) + ":" + encode(sealed), nil
+}
+
+// Decrypt opens a value of field produced by Encrypt. Legacy plaintext is returned unchanged
+// so rows written before encryption stay readable until they are re-encrypted.
+func (k *Keyring) Decrypt(field Field, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	keyID, wrapped, sealed, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := k.unwrap(keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, sealed, field.aad())
+	if err != nil {
+		return "", fmt.Errorf("fieldcrypt: decrypt %s.%s: %w", field.Table, field.Column, err)
+	}
+	return string(plaintext), nil
+}
+
+// Rewrap re-wraps the data key of value with the active master key, leaving the sealed value
+// as is. It reports whether value changed; values already on the active key are returned as is.
+func (k *Keyring) Rewrap(value string) (string, bool, error) {
+	keyID, wrapped, sealed, err := parse(value)
+	if err != nil {
+		return "", false, err
+	}
+	if keyID == k.active {
+		return value, false, nil
+	}
+
+	dataKey, err := k.unwrap(keyID, wrapped)
+	if err != nil {
+		return "", false, err
+	}
+	rewrapped, err := seal(k.keys[k.active], dataKey, wrapAAD(k.active))
+	if err != nil {
+		return "", false, err
+	}
+	return prefix + k.active + ":" + encode(rewrapped) + ":" + encode(sealed), true, nil
+}
+
+// BlindIndex returns the blind index of plaintext for field, or "" for the empty string.
+// It is deterministic, so lookups compare it instead of the ciphertext.
+func (k *Keyring) BlindIndex(field Field, plaintext string) string {
+	if field.Normalize != nil {
+		plaintext = field.Normalize(plaintext)
+	}
+	if plaintext == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write(field.aad())
+	mac.Write([]byte{0})
+	mac.Write([]byte(plaintext))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// EncryptRow encrypts the fields of the struct row points to in place and fills their
+// blind index columns. Fields are string or *string; nil pointers are left nil.
+func (k *Keyring) EncryptRow(row any, fields ...Field) error {
+	return eachField(row, fields, true, func(field Field, value *string, index *string) error {
+		if index != nil {
+			*index = k.BlindIndex(field, *value)
+		}
+		encrypted, err := k.Encrypt(field, *value)
+		if err != nil {
+			return err
+		}
+		*value = encrypted
+		return nil
+	})
+}
+
+// DecryptRow decrypts the fields of the struct row points to in place
+func (k *Keyring) DecryptRow(row any, fields ...Field) error {
+	return eachField(row, fields, false, func(field Field, value *string, _ *string) error {
+		decrypted, err := k.Decrypt(field, *value)
+		if err != nil {
+			return err
+		}
+		*value = decrypted
+		return nil
+	})
+}
+
+// eachField calls fn with the value of every field present in the struct row points to and,
+// when withIndex is set, its blind index (nil when the struct has none)
+func eachField(row any, fields []Field, withIndex bool, fn func(field Field, value *string, index *string) error) error {
+	v := reflect.ValueOf(row)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("fieldcrypt: row must be a pointer to a struct, got %T", row)
+	}
+	v = v.Elem()
+
+	columns := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		if tag := v.Type().Field(i).Tag.Get("db"); tag != "" {
+			columns[tag] = v.Field(i)
+		}
+	}
+
+	for _, field := range fields {
+		value := stringPointer(columns[field.Column], false)
+		if value == nil {
+			continue
+		}
+		var index *string
+		if withIndex {
+			index = stringPointer(columns[field.Column+"_bidx"], true)
+		}
+		if err := fn(field, value, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringPointer returns a pointer to the string held by the string or *string field v, or nil
+// when v is neither or a nil pointer and alloc is false. *string fields are pointed at a copy
+// first, so writes never reach a string shared with the caller.
+func stringPointer(v reflect.Value, alloc bool) *string {
+	switch {
+	case !v.IsValid():
+		return nil
+	case v.Kind() == reflect.String:
+		return v.Addr().Interface().(*string)
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String:
+		if v.IsNil() && !alloc {
+			return nil
+		}
+		var copied string
+		if !v.IsNil() {
+			copied = v.Elem().String()
+		}
+		v.Set(reflect.ValueOf(&copied))
+		return &copied
+	}
+	return nil
+}
+
+func (k *Keyring) unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	kek, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	dataKey, err := open(kek, wrapped, wrapAAD(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("fieldcrypt: unwrap data key with master key %s: %w", keyID, err)
+	}
+	return dataKey, nil
+}
+
+// parse splits an encrypted value into its master key id, wrapped data key and sealed value
+func parse(value string) (string, []byte, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if !IsEncrypted(value) || len(parts) != 3 {
+		return "", nil, nil, errors.New("fieldcrypt: malformed encrypted value")
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("fieldcrypt: malformed data key: %w", err)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("fieldcrypt: malformed ciphertext: %w", err)
+	}
+	return parts[0], wrapped, sealed, nil
+}
+
+// wrapAAD binds a wrapped data key to the id of the master key that wrapped it
+func wrapAAD(keyID string) []byte {
+	return []byte("data-key:" + keyID)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext under a random nonce, returned as nonce || ciphertext
+func seal(aead cipher.AEAD, plaintext []byte, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
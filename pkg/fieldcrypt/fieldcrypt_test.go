@@ -0,0 +1,219 @@
+package fieldcrypt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// phoneField normalizes like users.phone_number: separators dropped, +62 and 62 made local
+var phoneField = Field{Table: "users", Column: "phone_number", Normalize: func(s string) string {
+	s = strings.NewReplacer(" ", "", "-", "").Replace(s)
+	for _, p := range []string{"+62", "62"} {
+		if strings.HasPrefix(s, p) {
+			return "0" + strings.TrimPrefix(s, p)
+		}
+	}
+	return s
+}}
+
+func testKey(t *testing.T) string {
+	t.Helper()
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// newKeyring returns a keyring whose master keys have the given ids, the first one active
+func newKeyring(t *testing.T, ids ...string) (*Keyring, *KeyFile) {
+	t.Helper()
+	f := &KeyFile{Active: ids[0], Keys: map[string]string{}, IndexKey: testKey(t)}
+	for _, id := range ids {
+		f.Keys[id] = testKey(t)
+	}
+	k, err := New(f)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return k, f
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	k, _ := newKeyring(t, "k1")
+
+	for _, plaintext := range []string{"081234567890", "", "ünïcödé"} {
+		sealed, err := k.Encrypt(phoneField, plaintext)
+		if err != nil {
+			t.Fatalf("Encrypt(%q): %v", plaintext, err)
+		}
+		if plaintext != "" && (!IsEncrypted(sealed) || strings.Contains(sealed, plaintext)) {
+			t.Fatalf("Encrypt(%q) = %q, want ciphertext", plaintext, sealed)
+		}
+		opened, err := k.Decrypt(phoneField, sealed)
+		if err != nil {
+			t.Fatalf("Decrypt: %v", err)
+		}
+		if opened != plaintext {
+			t.Errorf("Decrypt = %q, want %q", opened, plaintext)
+		}
+	}
+}
+
+func TestDecryptRejectsAnotherColumn(t *testing.T) {
+	k, _ := newKeyring(t, "k1")
+	sealed, err := k.Encrypt(phoneField, "081234567890")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := Field{Table: "users", Column: "email"}
+	if _, err := k.Decrypt(other, sealed); err == nil {
+		t.Fatal("Decrypt succeeded with the wrong table.column")
+	}
+}
+
+func TestDecryptRejectsUnknownKey(t *testing.T) {
+	old, _ := newKeyring(t, "old")
+	sealed, err := old.Encrypt(phoneField, "081234567890")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, _ := newKeyring(t, "new")
+	if _, err := k.Decrypt(phoneField, sealed); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("Decrypt = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestRewrapMovesToActiveKey(t *testing.T) {
+	old, f := newKeyring(t, "old")
+	sealed, err := old.Encrypt(phoneField, "081234567890")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rotate: a new active key, the old one kept to unwrap existing values
+	f.Keys["new"] = testKey(t)
+	f.Active = "new"
+	k, err := New(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewrapped, changed, err := k.Rewrap(sealed)
+	if err != nil || !changed {
+		t.Fatalf("Rewrap = %v, %v; want changed", changed, err)
+	}
+	if !strings.HasPrefix(rewrapped, prefix+"new:") {
+		t.Errorf("Rewrap = %q, want it wrapped with the new key", rewrapped)
+	}
+	if _, changed, _ := k.Rewrap(rewrapped); changed {
+		t.Error("Rewrap changed a value already on the active key")
+	}
+
+	// the old key can be retired once every value is rewrapped
+	delete(f.Keys, "old")
+	retired, err := New(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened, err := retired.Decrypt(phoneField, rewrapped); err != nil || opened != "081234567890" {
+		t.Errorf("Decrypt after retiring the old key = %q, %v", opened, err)
+	}
+}
+
+func TestDecryptPassesLegacyPlaintextThrough(t *testing.T) {
+	k, _ := newKeyring(t, "k1")
+
+	opened, err := k.Decrypt(phoneField, "081234567890")
+	if err != nil || opened != "081234567890" {
+		t.Fatalf("Decrypt(plaintext) = %q, %v", opened, err)
+	}
+}
+
+func TestBlindIndexMatchesNormalizedForms(t *testing.T) {
+	k, _ := newKeyring(t, "k1")
+
+	want := k.BlindIndex(phoneField, "081234567890")
+	for _, form := range []string{"+6281234567890", "6281234567890", "0812-3456-7890", "+62 812 3456 7890"} {
+		if got := k.BlindIndex(phoneField, form); got != want {
+			t.Errorf("BlindIndex(%q) = %s, want %s", form, got, want)
+		}
+	}
+	if k.BlindIndex(phoneField, "081234567891") == want {
+		t.Error("different numbers share a blind index")
+	}
+	if k.BlindIndex(Field{Table: "users", Column: "email"}, "081234567890") == want {
+		t.Error("blind index is not bound to its column")
+	}
+	if got := k.BlindIndex(phoneField, ""); got != "" {
+		t.Errorf("BlindIndex(\"\") = %q, want empty", got)
+	}
+}
+
+func TestMalformedKeyFileIsRejected(t *testing.T) {
+	valid := func() *KeyFile {
+		return &KeyFile{Active: "k1", Keys: map[string]string{"k1": testKey(t)}, IndexKey: testKey(t)}
+	}
+	tests := []struct {
+		name   string
+		mutate func(f *KeyFile)
+	}{
+		{"active key missing", func(f *KeyFile) { f.Active = "k2" }},
+		{"key not base64", func(f *KeyFile) { f.Keys["k1"] = "not base64!" }},
+		{"key too short", func(f *KeyFile) { f.Keys["k1"] = base64.StdEncoding.EncodeToString([]byte("short")) }},
+		{"key id with colon", func(f *KeyFile) { f.Keys["k:2"] = testKey(t) }},
+		{"index key missing", func(f *KeyFile) { f.IndexKey = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := valid()
+			tt.mutate(f)
+			if _, err := New(f); err == nil {
+				t.Fatal("New accepted a malformed keyfile")
+			}
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keys.json")
+		if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Fatal("Load accepted an invalid keyfile")
+		}
+	})
+}
+
+func TestKeyFileWriteAndLoad(t *testing.T) {
+	f, err := NewKeyFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := f.Write(path); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("keyfile mode = %v, want 0600", perm)
+	}
+
+	k, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if k.ActiveKeyID() != f.Active {
+		t.Errorf("ActiveKeyID = %s, want %s", k.ActiveKeyID(), f.Active)
+	}
+}
@@ -0,0 +1,113 @@
+package fieldcrypt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// keySize is the size of master, data and blind index keys (AES-256, HMAC-SHA256)
+const keySize = 32
+
+// KeyFile is the local keyfile holding the master keys by id, the id of the active one
+// new values are wrapped with, and the blind index key. Keys are base64 encoded.
+// Retired master keys stay in the file until every value has been re-encrypted.
+type KeyFile struct {
+	Active   string            `json:"active"`
+	Keys     map[string]string `json:"keys"`
+	IndexKey string            `json:"index_key"`
+}
+
+// NewKeyFile returns a keyfile with a fresh master key and blind index key
+func NewKeyFile() (*KeyFile, error) {
+	indexKey, err := randomKey()
+	if err != nil {
+		return nil, err
+	}
+	f := &KeyFile{Keys: map[string]string{}, IndexKey: indexKey}
+	if _, err := f.Rotate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ReadKeyFile reads the keyfile at path
+func ReadKeyFile(path string) (*KeyFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f KeyFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse keyfile %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Rotate adds a new master key and makes it active, returning its id.
+// The blind index key is kept, so existing blind indexes stay valid.
+func (f *KeyFile) Rotate() (string, error) {
+	key, err := randomKey()
+	if err != nil {
+		return "", err
+	}
+	id := time.Now().UTC().Format("20060102T150405Z")
+	if _, exists := f.Keys[id]; exists {
+		return "", fmt.Errorf("master key %s already exists", id)
+	}
+	f.Keys[id] = key
+	f.Active = id
+	return id, nil
+}
+
+// Write stores the keyfile at path, readable by the owner only. The file is replaced
+// atomically so a failed write never leaves a truncated keyfile behind.
+func (f *KeyFile) Write(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".keyfile-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// decodeKey decodes a base64 key and checks its size
+func decodeKey(name string, encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not valid base64: %w", name, err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("%s must be %d bytes, got %d", name, keySize, len(key))
+	}
+	return key, nil
+}
+
+func randomKey() (string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generate key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
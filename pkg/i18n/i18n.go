@@ -0,0 +1,210 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Catalog maps message keys (error and success codes) to a locale's message;
+// messages may contain fmt verbs filled by the arguments given to T
+type Catalog map[string]string
+
+// Bundle holds the catalog of every supported locale.
+// Lookups fall back from the requested locale to the fallback locale, then to the key itself.
+type Bundle struct {
+	fallback string
+	catalogs map[string]Catalog
+}
+
+// New creates a Bundle; locales are lower-case base languages ("en", "id")
+func New(fallback string, catalogs map[string]Catalog) (*Bundle, error) {
+	if _, ok := catalogs[fallback]; !ok {
+		return nil, fmt.Errorf("fallback locale %q has no catalog", fallback)
+	}
+	return &Bundle{fallback: fallback, catalogs: catalogs}, nil
+}
+
+// Fallback returns the locale used when no requested locale is supported
+func (b *Bundle) Fallback() string {
+	return b.fallback
+}
+
+// Locales returns the supported locales, sorted
+func (b *Bundle) Locales() []string {
+	locales := make([]string, 0, len(b.catalogs))
+	for locale := range b.catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Supported returns the supported locale for tag: "id-ID" and "ID" both resolve to "id"
+func (b *Bundle) Supported(tag string) (string, bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	base, _, _ = strings.Cut(base, "_")
+	if _, ok := b.catalogs[base]; ok {
+		return base, true
+	}
+	return "", false
+}
+
+// Match returns the supported locale preferred by an Accept-Language header, or the fallback
+func (b *Bundle) Match(acceptLanguage string) string {
+	for _, tag := range ParseAcceptLanguage(acceptLanguage) {
+		if locale, ok := b.Supported(tag); ok {
+			return locale
+		}
+	}
+	return b.fallback
+}
+
+// Translate returns the message for key in locale, formatted with args
+func (b *Bundle) Translate(locale string, key string, args ...any) string {
+	message, ok := b.catalogs[locale][key]
+	if !ok {
+		if message, ok = b.catalogs[b.fallback][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Missing returns, per locale, the keys present in another locale's catalog but not in its own.
+// A complete bundle returns an empty map.
+func (b *Bundle) Missing() map[string][]string {
+	keys := map[string]bool{}
+	for _, catalog := range b.catalogs {
+		for key := range catalog {
+			keys[key] = true
+		}
+	}
+
+	missing := map[string][]string{}
+	for locale, catalog := range b.catalogs {
+		for key := range keys {
+			if _, ok := catalog[key]; !ok {
+				missing[locale] = append(missing[locale], key)
+			}
+		}
+		slices.Sort(missing[locale])
+	}
+	return missing
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header ordered by
+// preference; tags with q=0 and the "*" wildcard are dropped
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag, q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+type contextKey struct{}
+
+// WithLocale returns a copy of ctx whose messages are in locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale stored in ctx, or ""
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(contextKey{}).(string)
+	return locale
+}
+
+var defaultBundle atomic.Pointer[Bundle]
+
+// SetDefault makes b the bundle used by the package-level functions
+func SetDefault(b *Bundle) {
+	defaultBundle.Store(b)
+}
+
+// Default returns the bundle set by SetDefault, or nil
+func Default() *Bundle {
+	return defaultBundle.Load()
+}
+
+// T translates key into the locale of ctx (or the fallback locale) with the default bundle
+func T(ctx context.Context, key string, args ...any) string {
+	return Translate(LocaleFromContext(ctx), key, args...)
+}
+
+// Translate translates key into locale with the default bundle; an empty locale uses the fallback.
+// Without a default bundle the key is returned as is.
+func Translate(locale string, key string, args ...any) string {
+	b := Default()
+	if b == nil {
+		return key
+	}
+	if locale == "" {
+		locale = b.fallback
+	}
+	return b.Translate(locale, key, args...)
+}
+
+// Locale returns the locale messages for ctx are written in: the first supported preference
+// (e.g. a user's stored locale), then the locale of ctx, then the fallback
+func Locale(ctx context.Context, preferences ...string) string {
+	b := Default()
+	if b == nil {
+		return ""
+	}
+	for _, preference := range preferences {
+		if locale, ok := b.Supported(preference); ok {
+			return locale
+		}
+	}
+	if locale := LocaleFromContext(ctx); locale != "" {
+		return locale
+	}
+	return b.fallback
+}
+
+// Middleware negotiates the request locale from Accept-Language and stores it in the request context
+func Middleware(b *Bundle) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := b.Match(c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), locale))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
@@ -2,31 +2,45 @@ package jwt
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
-// GenerateAccessToken creates a signed JWT access token
-func GenerateAccessToken(userID uuid.UUID, role string, ttl time.Duration) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", errors.New("JWT_SECRET is not set")
+// Signer issues and validates tokens with the configured secrets
+type Signer struct {
+	secret        []byte
+	refreshSecret []byte
+}
+
+// NewSigner creates a Signer; refreshSecret is only needed by ValidateRefreshToken
+func NewSigner(secret string, refreshSecret string) *Signer {
+	return &Signer{secret: []byte(secret), refreshSecret: []byte(refreshSecret)}
+}
+
+// GenerateAccessToken creates a signed JWT access token.
+// The role claim is informational: authorization resolves the user's current role on every request.
+// permVersion fingerprints the permissions the token was issued with and may be empty.
+// locale is the user's preferred locale for API messages; empty follows Accept-Language.
+func (s *Signer) GenerateAccessToken(userID uuid.UUID, role string, permVersion string, locale string, ttl time.Duration) (string, error) {
+	if len(s.secret) == 0 {
+		return "", errors.New("JWT secret is not set")
 	}
 
 	now := time.Now()
 	claims := jwt.MapClaims{
-		"user_id": userID,
-		"role":    role,
-		"exp":     now.Add(ttl).Unix(),
-		"iat":     now.Unix(),
-		"nbf":     now.Unix(),
+		"user_id":  userID,
+		"role":     role,
+		"perm_ver": permVersion,
+		"locale":   locale,
+		"exp":      now.Add(ttl).Unix(),
+		"iat":      now.Unix(),
+		"nbf":      now.Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(secret))
+	signed, err := token.SignedString(s.secret)
 	if err != nil {
 		return "", err
 	}
@@ -34,10 +48,9 @@ func GenerateAccessToken(userID uuid.UUID, role string, ttl time.Duration) (stri
 }
 
 // GenerateRefreshToken creates a signed JWT refresh token
-func GenerateRefreshToken(userID uuid.UUID, role string, ttl time.Duration) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", errors.New("JWT_SECRET is not set")
+func (s *Signer) GenerateRefreshToken(userID uuid.UUID, role string, ttl time.Duration) (string, error) {
+	if len(s.secret) == 0 {
+		return "", errors.New("JWT secret is not set")
 	}
 
 	now := time.Now()
@@ -49,25 +62,37 @@ func GenerateRefreshToken(userID uuid.UUID, role string, ttl time.Duration) (str
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(secret))
+	signed, err := token.SignedString(s.secret)
 	if err != nil {
 		return "", err
 	}
 	return signed, nil
 }
 
+// ParseAccessToken verifies an access token and returns its claims
+func (s *Signer) ParseAccessToken(tokenString string) (jwt.MapClaims, error) {
+	return parse(tokenString, s.secret)
+}
+
 // ValidateRefreshToken validates and parses refresh token
-func ValidateRefreshToken(tokenString string) (map[string]any, error) {
-	secret := os.Getenv("REFRESH_SECRET")
-	if secret == "" {
-		return nil, errors.New("REFRESH_SECRET is not set")
+func (s *Signer) ValidateRefreshToken(tokenString string) (map[string]any, error) {
+	if len(s.refreshSecret) == 0 {
+		return nil, errors.New("refresh secret is not set")
+	}
+	return parse(tokenString, s.refreshSecret)
+}
+
+// parse verifies an HMAC-signed token against secret
+func parse(tokenString string, secret []byte) (jwt.MapClaims, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("JWT secret is not set")
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
+			return nil, jwt.ErrSignatureInvalid
 		}
-		return []byte(secret), nil
+		return secret, nil
 	})
 	if err != nil {
 		return nil, err
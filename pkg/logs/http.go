@@ -0,0 +1,72 @@
+package logs
+
+import (
+	"log/slog"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits the incoming request IDs that are trusted and echoed back
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,128}$`)
+
+// RequestID reuses a well-formed X-Request-ID from the client or proxy, otherwise generates one.
+// The ID is echoed in the response, stored in the gin context as "requestID" and attached to
+// the request context so every log record of the request carries it.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set("requestID", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), requestID, c.FullPath()))
+
+		c.Next()
+	}
+}
+
+// AccessLog writes one structured record per request, replacing gin's text logger.
+// The query string is never logged since it may carry tokens; requests to quietPaths
+// (probes, metrics scrapes) are logged at debug level.
+func AccessLog(quietPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		case slices.Contains(quietPaths, c.Request.URL.Path):
+			level = slog.LevelDebug
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if errs := c.Errors.ByType(gin.ErrorTypeAny).String(); errs != "" {
+			attrs = append(attrs, slog.String("error", errs))
+		}
+
+		// c.Request carries the user ID once AuthMiddleware has run
+		slog.LogAttrs(c.Request.Context(), level, "HTTP request", attrs...)
+	}
+}
@@ -0,0 +1,100 @@
+package logs
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewLogger creates the application logger: records are redacted, enriched with the request
+// ID, user ID, route and trace ID found in their context, and written to w as JSON,
+// or as text when format is "text"
+func NewLogger(w io.Writer, format string, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	if format == "text" {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(NewRedactingHandler(&ContextHandler{next: handler}))
+}
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	userIDKey
+	routeKey
+)
+
+// WithRequestID returns a copy of ctx carrying the request ID and route template
+func WithRequestID(ctx context.Context, requestID string, route string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+	return context.WithValue(ctx, routeKey, route)
+}
+
+// WithUserID returns a copy of ctx carrying the authenticated user's ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// ContextHandler adds request_id, user_id, route and trace_id from the record's context.
+// Use the slog *Context functions (slog.ErrorContext(ctx, ...)) for these to be present.
+type ContextHandler struct {
+	next slog.Handler
+}
+
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil {
+		return h.next.Handle(ctx, r)
+	}
+
+	// attributes given explicitly (e.g. "user_id" of another user) take precedence
+	present := map[string]bool{}
+	r.Attrs(func(a slog.Attr) bool {
+		present[a.Key] = true
+		return true
+	})
+	for _, field := range contextFields {
+		if value, ok := ctx.Value(field.key).(string); ok && value != "" && !present[field.name] {
+			r.AddAttrs(slog.String(field.name, value))
+		}
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		r.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// contextFields are the context values added to every record, in output order
+var contextFields = []struct {
+	key  contextKey
+	name string
+}{
+	{requestIDKey, "request_id"},
+	{userIDKey, "user_id"},
+	{routeKey, "route"},
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{next: h.next.WithGroup(name)}
+}
@@ -0,0 +1,213 @@
+package logs
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the value of secret attributes
+const Redacted = "[REDACTED]"
+
+// secretKeys are matched against normalized attribute keys (lower case, without "_", "-" or spaces)
+var secretKeys = []string{"password", "passwd", "secret", "token", "verifycode", "otp", "authorization", "cookie", "apikey"}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`)
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+[^\s"']+`)
+	// phone numbers: 9 to 15 digits, optionally prefixed by +, not part of a longer token such as a UUID
+	phonePattern = regexp.MustCompile(`(^|[^\w\-+])(\+?\d{9,15})($|[^\w\-])`)
+)
+
+// maxRedactDepth bounds how deep nested structs and maps are inspected
+const maxRedactDepth = 5
+
+// RedactingHandler masks secrets before records reach the next handler:
+// attributes with secret-looking keys (password, token, ...) are replaced, email and phone
+// attributes are masked, and emails, phone numbers and bearer/JWT tokens are masked wherever
+// they appear in the message or in string and error values. Structs and maps are inspected field by field.
+type RedactingHandler struct {
+	next slog.Handler
+}
+
+// NewRedactingHandler wraps next with redaction
+func NewRedactingHandler(next slog.Handler) *RedactingHandler {
+	return &RedactingHandler{next: next}
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, scrub(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a, 0))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a, 0)
+	}
+	return &RedactingHandler{next: h.next.WithAttrs(redacted)}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{next: h.next.WithGroup(name)}
+}
+
+// redactAttr returns a with its value masked according to its key and content
+func redactAttr(a slog.Attr, depth int) slog.Attr {
+	key := normalizeKey(a.Key)
+	value := a.Value.Resolve()
+
+	switch {
+	case isSecretKey(key):
+		if value.Kind() == slog.KindString && value.String() == "" {
+			return slog.String(a.Key, "")
+		}
+		return slog.String(a.Key, Redacted)
+	case strings.Contains(key, "email") && value.Kind() == slog.KindString:
+		return slog.String(a.Key, MaskEmail(value.String()))
+	case strings.Contains(key, "phone") && value.Kind() == slog.KindString:
+		return slog.String(a.Key, MaskPhone(value.String()))
+	}
+
+	return slog.Attr{Key: a.Key, Value: redactValue(value, depth)}
+}
+
+// redactValue scrubs strings and errors and inspects groups, structs and maps
+func redactValue(value slog.Value, depth int) slog.Value {
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.StringValue(scrub(value.String()))
+	case slog.KindGroup:
+		attrs := value.Group()
+		redacted := make([]slog.Attr, len(attrs))
+		for i, a := range attrs {
+			redacted[i] = redactAttr(a, depth+1)
+		}
+		return slog.GroupValue(redacted...)
+	case slog.KindAny:
+		return redactAny(value.Any(), depth)
+	default:
+		return value
+	}
+}
+
+// redactAny handles values logged with slog.Any, such as errors and database rows
+func redactAny(v any, depth int) slog.Value {
+	switch val := v.(type) {
+	case nil:
+		return slog.AnyValue(nil)
+	case error:
+		return slog.StringValue(scrub(val.Error()))
+	case json.Marshaler, encoding.TextMarshaler:
+		// values with their own encoding (UUIDs, timestamps, pgtype values)
+		return slog.AnyValue(v)
+	case fmt.Stringer:
+		return slog.StringValue(scrub(val.String()))
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return slog.AnyValue(nil)
+		}
+		rv = rv.Elem()
+	}
+	if depth >= maxRedactDepth {
+		return slog.StringValue(Redacted)
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		t := rv.Type()
+		attrs := make([]slog.Attr, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Name
+			if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			attrs = append(attrs, redactAttr(slog.Any(name, rv.Field(i).Interface()), depth+1))
+		}
+		return slog.GroupValue(attrs...)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return slog.AnyValue(v)
+		}
+		attrs := make([]slog.Attr, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			attrs = append(attrs, redactAttr(slog.Any(iter.Key().String(), iter.Value().Interface()), depth+1))
+		}
+		return slog.GroupValue(attrs...)
+	case reflect.String:
+		return slog.StringValue(scrub(rv.String()))
+	default:
+		return slog.AnyValue(v)
+	}
+}
+
+// scrub masks emails, phone numbers and bearer/JWT tokens inside free text
+func scrub(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "Bearer "+Redacted)
+	s = jwtPattern.ReplaceAllString(s, Redacted)
+	s = emailPattern.ReplaceAllStringFunc(s, MaskEmail)
+	s = phonePattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := phonePattern.FindStringSubmatch(match)
+		return parts[1] + MaskPhone(parts[2]) + parts[3]
+	})
+	return s
+}
+
+// MaskEmail keeps the first character of the local part and the domain: "j***@example.com"
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return Redacted
+	}
+	return local[:1] + "***@" + domain
+}
+
+// MaskPhone keeps only the last two digits: "**********90"
+func MaskPhone(phone string) string {
+	if len(phone) <= 2 {
+		return Redacted
+	}
+	return strings.Repeat("*", len(phone)-2) + phone[len(phone)-2:]
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "", ".", "").Replace(strings.ToLower(key))
+}
+
+func isSecretKey(key string) bool {
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSecret reports whether values stored under key must never be recorded, e.g. "password" or "verify_code"
+func IsSecret(key string) bool {
+	return isSecretKey(normalizeKey(key))
+}
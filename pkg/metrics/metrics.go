@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "medisuite"
+
+// Registry holds every collector exposed on the metrics endpoint
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Database query latency by sqlc query name and outcome.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"query", "outcome"})
+
+	logins = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Successful logins.",
+	})
+
+	loginFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "login_failures_total",
+		Help:      "Rejected logins by reason.",
+	}, []string{"reason"})
+
+	tokenRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "token_refreshes_total",
+		Help:      "Refresh token exchanges by outcome.",
+	}, []string{"outcome"})
+
+	permissionDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "authz",
+		Name:      "permission_denials_total",
+		Help:      "Requests rejected by RequirePermission by module and action.",
+	}, []string{"module", "action"})
+
+	emails = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "email",
+		Name:      "sent_total",
+		Help:      "Outgoing emails by outcome.",
+	}, []string{"outcome"})
+)
+
+// Outcomes used as label values
+const (
+	OutcomeOK       = "ok"
+	OutcomeError    = "error"
+	OutcomeRejected = "rejected"
+)
+
+// Login failure reasons
+const (
+	ReasonUnknownUser     = "unknown_user"
+	ReasonUnverified      = "unverified"
+	ReasonInvalidPassword = "invalid_password"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		dbQueryDuration,
+		logins,
+		loginFailures,
+		tokenRefreshes,
+		permissionDenials,
+		emails,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveLogin counts a successful login
+func ObserveLogin() {
+	logins.Inc()
+}
+
+// ObserveLoginFailure counts a rejected login; reason is one of the Reason constants
+func ObserveLoginFailure(reason string) {
+	loginFailures.WithLabelValues(reason).Inc()
+}
+
+// ObserveTokenRefresh counts a refresh token exchange; outcome is OutcomeOK or OutcomeRejected
+func ObserveTokenRefresh(outcome string) {
+	tokenRefreshes.WithLabelValues(outcome).Inc()
+}
+
+// ObservePermissionDenied counts a request rejected for lacking module.action
+func ObservePermissionDenied(module, action string) {
+	permissionDenials.WithLabelValues(module, action).Inc()
+}
+
+// ObserveEmail counts an email send attempt by its result
+func ObserveEmail(err error) {
+	outcome := OutcomeOK
+	if err != nil {
+		outcome = OutcomeError
+	}
+	emails.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unnamedQuery labels statements without a sqlc "-- name:" header (raw SQL, BEGIN/COMMIT)
+const unnamedQuery = "unnamed"
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	name  string
+	start time.Time
+}
+
+// QueryTracer is a pgx.QueryTracer recording query durations per sqlc query name.
+// Set it as ConnConfig.Tracer when creating the pool.
+type QueryTracer struct{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: QueryName(data.SQL), start: time.Now()})
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	started, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	outcome := OutcomeOK
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		outcome = OutcomeError
+	}
+	dbQueryDuration.WithLabelValues(started.name, outcome).Observe(time.Since(started.start).Seconds())
+}
+
+// QueryName extracts the query name from the "-- name: FindUserById :one" header sqlc puts
+// at the start of every generated statement
+func QueryName(sql string) string {
+	header, ok := strings.CutPrefix(strings.TrimSpace(sql), "-- name:")
+	if !ok {
+		return unnamedQuery
+	}
+	fields := strings.Fields(header)
+	if len(fields) == 0 {
+		return unnamedQuery
+	}
+	return fields[0]
+}
+
+// RegisterPool exposes the connection pool statistics of pool on the registry
+func RegisterPool(pool *pgxpool.Pool) error {
+	return Registry.Register(&poolCollector{pool: pool})
+}
+
+// poolCollector reads pgxpool.Stat on every scrape
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+var (
+	poolAcquiredConns       = poolDesc("acquired_conns", "Connections currently in use.")
+	poolIdleConns           = poolDesc("idle_conns", "Idle connections in the pool.")
+	poolTotalConns          = poolDesc("total_conns", "Total connections in the pool.")
+	poolMaxConns            = poolDesc("max_conns", "Maximum size of the pool.")
+	poolAcquireCount        = poolDesc("acquires_total", "Successful connection acquires.")
+	poolAcquireDuration     = poolDesc("acquire_duration_seconds_total", "Total time spent waiting to acquire a connection.")
+	poolEmptyAcquireCount   = poolDesc("empty_acquires_total", "Acquires that had to wait because the pool was empty.")
+	poolCanceledAcquires    = poolDesc("canceled_acquires_total", "Acquires canceled by their context.")
+	poolNewConnsCount       = poolDesc("new_conns_total", "Connections opened by the pool.")
+	poolMaxLifetimeDestroys = poolDesc("max_lifetime_destroys_total", "Connections closed for exceeding their maximum lifetime.")
+	poolMaxIdleDestroys     = poolDesc("max_idle_destroys_total", "Connections closed for exceeding their maximum idle time.")
+)
+
+func poolDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+}
+
+func (p *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredConns
+	ch <- poolIdleConns
+	ch <- poolTotalConns
+	ch <- poolMaxConns
+	ch <- poolAcquireCount
+	ch <- poolAcquireDuration
+	ch <- poolEmptyAcquireCount
+	ch <- poolCanceledAcquires
+	ch <- poolNewConnsCount
+	ch <- poolMaxLifetimeDestroys
+	ch <- poolMaxIdleDestroys
+}
+
+func (p *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := p.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolNewConnsCount, prometheus.CounterValue, float64(stat.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(poolMaxLifetimeDestroys, prometheus.CounterValue, float64(stat.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(poolMaxIdleDestroys, prometheus.CounterValue, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,54 @@
+// Package outbox defines the messages written to the transactional outbox. A message is
+// enqueued with the repository of the ExecTx that makes the state change, so it exists exactly
+// when the change commits, and is delivered afterwards by the dispatcher with retries.
+package outbox
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Topics name what a message is and pick the handler that delivers it
+const (
+	TopicEmail = "email"
+)
+
+// Statuses of an outbox message; dead messages exhausted their attempts and wait to be requeued
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusDead    = "dead"
+)
+
+// Message is one side effect to deliver after commit; Payload is encoded as JSON
+type Message struct {
+	Topic   string
+	Payload any
+}
+
+// Email is the payload of TopicEmail
+type Email struct {
+	To      []string `json:"to"`
+	Cc      []string `json:"cc,omitempty"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// NewEmail returns a message that sends an email; subject and body are already translated
+func NewEmail(to []string, subject string, body string) Message {
+	return Message{Topic: TopicEmail, Payload: Email{To: to, Subject: subject, Body: body}}
+}
+
+// Backoff returns the delay before retrying after the given number of failed attempts:
+// base doubled per attempt up to maxDelay, with up to half of it randomized so messages that
+// failed together do not retry together
+func Backoff(attempts int, base time.Duration, maxDelay time.Duration) time.Duration {
+	delay := maxDelay
+	if attempts < 1 {
+		attempts = 1
+	}
+	if shift := attempts - 1; shift < 32 && base<<shift > 0 && base<<shift < maxDelay {
+		delay = base << shift
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Middleware starts a server span per request, continuing the trace from incoming W3C
+// traceparent/tracestate headers. Requests to skipPaths (probes, metrics scrapes) are not traced.
+func Middleware(serviceName string, skipPaths ...string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName,
+		otelgin.WithFilter(func(r *http.Request) bool {
+			return !slices.Contains(skipPaths, r.URL.Path)
+		}),
+	)
+}
@@ -0,0 +1,24 @@
+#!/bin/sh
+# Fails when Go sources under the given directories (default: config) contain
+# credential-looking string literals: email addresses, long opaque tokens such as
+# app passwords or API keys, or literals assigned to password/secret/token/key names.
+# Secrets belong in env vars or *_FILE paths, never in source.
+set -u
+
+dirs=${*:-config}
+status=0
+
+check() {
+	description=$1
+	shift
+	if grep -rnE --include='*.go' "$@" $dirs; then
+		echo "error: $description found in $dirs" >&2
+		status=1
+	fi
+}
+
+check "email address literal" '"[^" ]+@[^" ]+\.[A-Za-z]{2,}"'
+check "token-like literal" '"[A-Za-z0-9]{16,}"'
+check "credential assignment" -i '(pass|passwd|password|secret|token|apikey|api_key)[A-Za-z_]*[[:space:]]*:?=[[:space:]]*"[^"]+"'
+
+exit $status